@@ -21,8 +21,9 @@ var (
 	log         = logrus.New()
 	assetSource *string
 	// key         *string
-	secret  *string
-	caching *bool
+	secret           *string
+	caching          *bool
+	exchangeRegistry *string
 )
 
 var exchanges map[string]dia.Exchange
@@ -31,13 +32,18 @@ func init() {
 	assetSource = flag.String("source", "Uniswap", "Data source for asset collection")
 	secret = flag.String("secret", "", "secret for asset source")
 	caching = flag.Bool("caching", true, "caching assets in redis")
+	exchangeRegistry = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from postgres.")
 	flag.Parse()
 
 	// source, err := datasource.InitSource()
 	// if err != nil {
 	// 	panic(err)
 	// }
-	exchanges = scrapers.Exchanges
+	registry, err := scrapers.InitExchangeRegistry(*exchangeRegistry)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
+	exchanges = registry.Exchanges
 }
 
 // NewAssetScraper returns a scraper for assets on @exchange.
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	diaOracleV2MultiupdateService "github.com/diadata-org/diadata/pkg/dia/scraper/blockchain-scrapers/blockchains/ethereum/diaOracleV2MultiupdateService"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// pendingUpdate is an asset whose price crossed its deviation or heartbeat in the current cycle.
+type pendingUpdate struct {
+	// index is the position of the asset in the oracle config.
+	index     int
+	key       string
+	price     float64
+	timestamp int64
+	// oldPrice is the price in the oracle before the update.
+	oldPrice float64
+	// heartbeat is true if the update is triggered by the heartbeat instead of the deviation.
+	heartbeat bool
+}
+
+// compressedValue returns the value as stored by the multiupdate contract,
+// i.e. the price in the upper and the timestamp in the lower 128 bits.
+func (u pendingUpdate) compressedValue() *big.Int {
+	value := big.NewInt(int64(u.price * 100000000))
+	value.Lsh(value, 128)
+	return value.Add(value, big.NewInt(u.timestamp))
+}
+
+// batchFeeder writes all pending updates of a cycle to the multiupdate contract
+// in as few transactions as fit into gasLimit.
+type batchFeeder struct {
+	contract *diaOracleV2MultiupdateService.DIAOracleV2Multiupdate
+	conn     oracleBackend
+	auth     *bind.TransactOpts
+	// gasLimit is the maximal gas of a single batch transaction.
+	gasLimit uint64
+	// bumpRetries is the number of times a transaction not mined within mineTimeout is resent with a higher gas price.
+	bumpRetries int
+	bumpPercent int
+	mineTimeout time.Duration
+	// sim mines and reports the batches in simulation mode. It is nil otherwise.
+	sim *simulation
+}
+
+// newBatchFeeder binds the multiupdate contract at @deployedContract.
+func newBatchFeeder(deployedContract string, conn oracleBackend, auth *bind.TransactOpts, gasLimit uint64, bumpRetries int, bumpPercent int, mineTimeout time.Duration) (*batchFeeder, error) {
+	if deployedContract == "" {
+		return nil, errors.New("batch mode needs the address of a deployed multiupdate contract in DEPLOYED_CONTRACT")
+	}
+	contract, err := diaOracleV2MultiupdateService.NewDIAOracleV2Multiupdate(common.HexToAddress(deployedContract), conn)
+	if err != nil {
+		return nil, err
+	}
+	return &batchFeeder{
+		contract:    contract,
+		conn:        conn,
+		auth:        auth,
+		gasLimit:    gasLimit,
+		bumpRetries: bumpRetries,
+		bumpPercent: bumpPercent,
+		mineTimeout: mineTimeout,
+	}, nil
+}
+
+// push writes @updates to the oracle and returns the updates which were mined successfully.
+func (f *batchFeeder) push(updates []pendingUpdate) []pendingUpdate {
+	var written []pendingUpdate
+	if len(updates) == 0 {
+		return written
+	}
+	batches, err := f.packBatches(updates)
+	if err != nil {
+		log.Printf("Failed to pack batches: %v", err)
+		return written
+	}
+	for _, batch := range batches {
+		receipt, err := f.send(batch)
+		if err != nil {
+			log.Printf("Failed to send batch of %d updates: %v", len(batch), err)
+			continue
+		}
+		log.Printf("Batch of %d updates mined in block %d, gas used %d, tx hash 0x%x\n", len(batch), receipt.BlockNumber, receipt.GasUsed, receipt.TxHash)
+		if f.sim != nil {
+			f.sim.reportBatch(receipt, batch)
+		}
+		written = append(written, batch...)
+	}
+	return written
+}
+
+// packBatches greedily splits @updates into batches whose estimated gas does not exceed gasLimit.
+// The gas of each update is estimated once, such that the number of estimations grows linearly
+// with the number of updates. The gas of a batch is the sum of the gas of its updates, less the
+// overhead of the transaction which the updates in a batch share.
+// An update exceeding gasLimit on its own is sent in a batch of its own.
+func (f *batchFeeder) packBatches(updates []pendingUpdate) ([][]pendingUpdate, error) {
+	updateGas := make([]uint64, len(updates))
+	for i, update := range updates {
+		gas, err := f.estimateGas([]pendingUpdate{update})
+		if err != nil {
+			return [][]pendingUpdate{}, err
+		}
+		updateGas[i] = gas
+	}
+	overhead, err := f.batchOverhead(updates, updateGas)
+	if err != nil {
+		return [][]pendingUpdate{}, err
+	}
+
+	var batches [][]pendingUpdate
+	var current []pendingUpdate
+	var currentGas uint64
+	for i, update := range updates {
+		if updateGas[i] > f.gasLimit {
+			log.Printf("Update of %s needs %d gas, exceeding the batch gas limit %d", update.key, updateGas[i], f.gasLimit)
+		}
+		// The overhead of the transaction is accounted for by the first update of a batch.
+		gas := updateGas[i]
+		if len(current) > 0 && gas > overhead {
+			gas -= overhead
+		}
+		if len(current) > 0 && currentGas+gas > f.gasLimit {
+			batches = append(batches, current)
+			current = []pendingUpdate{}
+			currentGas = 0
+			gas = updateGas[i]
+		}
+		current = append(current, update)
+		currentGas += gas
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches, nil
+}
+
+// batchOverhead returns the gas shared by the updates in a batch, such as the base cost of the transaction.
+// It is the difference between the gas of the first two @updates written separately and in a single batch.
+func (f *batchFeeder) batchOverhead(updates []pendingUpdate, updateGas []uint64) (uint64, error) {
+	if len(updates) < 2 {
+		return 0, nil
+	}
+	gas, err := f.estimateGas(updates[:2])
+	if err != nil {
+		return 0, err
+	}
+	if updateGas[0]+updateGas[1] < gas {
+		return 0, nil
+	}
+	return updateGas[0] + updateGas[1] - gas, nil
+}
+
+// estimateGas returns the gas needed to write @batch.
+func (f *batchFeeder) estimateGas(batch []pendingUpdate) (uint64, error) {
+	keys, values := batchArguments(batch)
+	tx, err := f.contract.SetMultipleValues(&bind.TransactOpts{
+		From:   f.auth.From,
+		Signer: f.auth.Signer,
+		NoSend: true,
+	}, keys, values)
+	if err != nil {
+		return 0, err
+	}
+	return tx.Gas(), nil
+}
+
+// send writes @batch in a single transaction and waits until it is mined.
+// If the transaction is not mined within mineTimeout, it is replaced by a transaction
+// with the same nonce and a gas price raised by bumpPercent, up to bumpRetries times.
+func (f *batchFeeder) send(batch []pendingUpdate) (*types.Receipt, error) {
+	keys, values := batchArguments(batch)
+
+	gasPrice, err := f.conn.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	// Get 110% of the gas price
+	fGas := new(big.Float).SetInt(gasPrice)
+	fGas.Mul(fGas, big.NewFloat(1.1))
+	gasPrice, _ = fGas.Int(nil)
+
+	var nonce *big.Int
+	var sent []*types.Transaction
+	for attempt := 0; attempt <= f.bumpRetries; attempt++ {
+		tx, err := f.contract.SetMultipleValues(&bind.TransactOpts{
+			From:     f.auth.From,
+			Signer:   f.auth.Signer,
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+		}, keys, values)
+		if err != nil {
+			// A previous attempt might have been mined in the meantime.
+			if receipt := f.minedReceipt(sent); receipt != nil {
+				return receipt, nil
+			}
+			return nil, err
+		}
+		sent = append(sent, tx)
+		nonce = new(big.Int).SetUint64(tx.Nonce())
+		log.Printf("Sent batch of %d updates with gas price %d, nonce %d, tx hash 0x%x\n", len(batch), tx.GasPrice(), tx.Nonce(), tx.Hash())
+		if f.sim != nil {
+			f.sim.Commit()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), f.mineTimeout)
+		receipt, err := bind.WaitMined(ctx, f.conn, tx)
+		cancel()
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return nil, errors.New("batch transaction reverted")
+			}
+			return receipt, nil
+		}
+		if receipt := f.minedReceipt(sent); receipt != nil {
+			return receipt, nil
+		}
+
+		gasPrice = new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(int64(100+f.bumpPercent))), big.NewInt(100))
+		log.Printf("Batch transaction 0x%x not mined within %v, bumping gas price to %d\n", tx.Hash(), f.mineTimeout, gasPrice)
+	}
+	return nil, errors.New("batch transaction not mined after gas bump retries")
+}
+
+// minedReceipt returns the receipt of the first transaction in @txs which is mined, or nil if none is.
+func (f *batchFeeder) minedReceipt(txs []*types.Transaction) *types.Receipt {
+	for _, tx := range txs {
+		receipt, err := f.conn.TransactionReceipt(context.Background(), tx.Hash())
+		if err == nil && receipt.Status == types.ReceiptStatusSuccessful {
+			return receipt
+		}
+	}
+	return nil
+}
+
+func batchArguments(batch []pendingUpdate) (keys []string, values []*big.Int) {
+	for _, update := range batch {
+		keys = append(keys, update.key)
+		values = append(values, update.compressedValue())
+	}
+	return
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// quotationMethodology uses the asset quotation from DIA's REST API instead of a GraphQL filter.
+const quotationMethodology = "quotation"
+
+// methodologies maps the methodologies accepted in the config onto the filter names of DIA's GraphQL GetChart query.
+var methodologies = map[string]string{
+	"vwap":   "vwap",
+	"vwapir": "vwapir",
+	"median": "medir",
+	"medir":  "medir",
+	"twap":   "twap",
+	"ma":     "ma",
+	"mair":   "mair",
+	"ema":    "ema",
+}
+
+// assetConfig is the oracle methodology of a single asset.
+// Values missing in the config are taken from Defaults of the oracle config.
+type assetConfig struct {
+	Blockchain string `json:"Blockchain"`
+	Address    string `json:"Address"`
+	// Methodology is one of the keys in methodologies or quotationMethodology (case insensitive).
+	// If empty, the asset quotation from DIA's REST API is used.
+	Methodology   string `json:"Methodology"`
+	WindowSeconds int    `json:"WindowSeconds"`
+	// DeviationPermille is the price deviation triggering an update. 0 updates on every price change.
+	// HeartbeatSeconds is the maximal time between two updates of the asset. 0 disables the heartbeat.
+	// Both are pointers such that an explicit 0 is not replaced by the default.
+	DeviationPermille *int `json:"DeviationPermille"`
+	HeartbeatSeconds  *int `json:"HeartbeatSeconds"`
+	// Exchanges restricts the trades the price is computed from. Only used for GraphQL methodologies.
+	Exchanges []string `json:"Exchanges"`
+}
+
+// oracleConfig is the content of the file given by ORACLE_CONFIG_FILE.
+type oracleConfig struct {
+	Defaults assetConfig   `json:"Defaults"`
+	Assets   []assetConfig `json:"Assets"`
+}
+
+// useGql returns true if the price of @asset is computed by DIA's GraphQL API.
+func (asset assetConfig) useGql() bool {
+	return asset.Methodology != "" && !strings.EqualFold(asset.Methodology, quotationMethodology)
+}
+
+// gqlFilter returns the name of the GraphQL filter corresponding to the asset's methodology.
+func (asset assetConfig) gqlFilter() string {
+	return methodologies[strings.ToLower(asset.Methodology)]
+}
+
+// deviationPermille returns the deviation of @asset, 0 if it is not set.
+func (asset assetConfig) deviationPermille() int {
+	if asset.DeviationPermille == nil {
+		return 0
+	}
+	return *asset.DeviationPermille
+}
+
+// heartbeatSeconds returns the heartbeat of @asset, 0 if it is not set.
+func (asset assetConfig) heartbeatSeconds() int {
+	if asset.HeartbeatSeconds == nil {
+		return 0
+	}
+	return *asset.HeartbeatSeconds
+}
+
+// withDefaults returns @asset with all missing values replaced by the corresponding values in @defaults.
+// Exchanges are only inherited by assets using a GraphQL methodology.
+func (asset assetConfig) withDefaults(defaults assetConfig) assetConfig {
+	if asset.Methodology == "" {
+		asset.Methodology = defaults.Methodology
+	}
+	if asset.WindowSeconds == 0 {
+		asset.WindowSeconds = defaults.WindowSeconds
+	}
+	if asset.DeviationPermille == nil {
+		asset.DeviationPermille = defaults.DeviationPermille
+	}
+	if asset.HeartbeatSeconds == nil {
+		asset.HeartbeatSeconds = defaults.HeartbeatSeconds
+	}
+	if len(asset.Exchanges) == 0 && asset.useGql() {
+		asset.Exchanges = defaults.Exchanges
+	}
+	return asset
+}
+
+func (asset assetConfig) validate() error {
+	if asset.Blockchain == "" || asset.Address == "" {
+		return errors.New("asset must have blockchain and address")
+	}
+	if asset.useGql() && asset.gqlFilter() == "" {
+		return fmt.Errorf("unknown methodology %s for asset %s-%s", asset.Methodology, asset.Blockchain, asset.Address)
+	}
+	if asset.useGql() && asset.WindowSeconds <= 0 {
+		return fmt.Errorf("window size of asset %s-%s must be positive", asset.Blockchain, asset.Address)
+	}
+	if !asset.useGql() && len(asset.Exchanges) > 0 {
+		return fmt.Errorf("exchanges of asset %s-%s can only be restricted together with a methodology", asset.Blockchain, asset.Address)
+	}
+	if asset.deviationPermille() < 0 || asset.heartbeatSeconds() < 0 {
+		return fmt.Errorf("deviation and heartbeat of asset %s-%s must not be negative", asset.Blockchain, asset.Address)
+	}
+	return nil
+}
+
+// resolvedAssets returns all assets with defaults applied. It returns an error if an asset is not valid.
+func (c oracleConfig) resolvedAssets() ([]assetConfig, error) {
+	if len(c.Assets) == 0 {
+		return []assetConfig{}, errors.New("no assets in oracle config")
+	}
+	var assets []assetConfig
+	for _, asset := range c.Assets {
+		asset = asset.withDefaults(c.Defaults)
+		if err := asset.validate(); err != nil {
+			return []assetConfig{}, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// loadOracleConfig reads the oracle config from the json file @filename.
+// Values missing in the file's Defaults are taken from @defaults.
+func loadOracleConfig(filename string, defaults assetConfig) (oracleConfig, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return oracleConfig{}, err
+	}
+	var config oracleConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return oracleConfig{}, err
+	}
+	config.Defaults = config.Defaults.withDefaults(defaults)
+	return config, nil
+}
+
+// oracleConfigFromEnv returns the oracle config given by the comma separated list of Blockchain-Address
+// pairs in @assetsStr, all using the methodology in @defaults.
+func oracleConfigFromEnv(assetsStr string, defaults assetConfig) oracleConfig {
+	config := oracleConfig{Defaults: defaults}
+	for _, asset := range strings.Split(assetsStr, ",") {
+		entries := strings.Split(asset, "-")
+		if len(entries) < 2 {
+			continue
+		}
+		config.Assets = append(config.Assets, assetConfig{
+			Blockchain: strings.TrimSpace(entries[0]),
+			Address:    strings.TrimSpace(entries[1]),
+		})
+	}
+	return config
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestAssetConfigWithDefaults(t *testing.T) {
+	defaults := assetConfig{
+		Methodology:       "vwap",
+		WindowSeconds:     120,
+		DeviationPermille: intPtr(10),
+		HeartbeatSeconds:  intPtr(86400),
+		Exchanges:         []string{"Binance"},
+	}
+	cases := []struct {
+		name     string
+		asset    assetConfig
+		expected assetConfig
+	}{
+		{
+			name:  "all values from defaults",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01"},
+			expected: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				Methodology:       "vwap",
+				WindowSeconds:     120,
+				DeviationPermille: intPtr(10),
+				HeartbeatSeconds:  intPtr(86400),
+				Exchanges:         []string{"Binance"},
+			},
+		},
+		{
+			name: "explicit zero deviation and heartbeat are kept",
+			asset: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				DeviationPermille: intPtr(0),
+				HeartbeatSeconds:  intPtr(0),
+			},
+			expected: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				Methodology:       "vwap",
+				WindowSeconds:     120,
+				DeviationPermille: intPtr(0),
+				HeartbeatSeconds:  intPtr(0),
+				Exchanges:         []string{"Binance"},
+			},
+		},
+		{
+			name:  "quotation assets do not inherit exchanges",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "Quotation"},
+			expected: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				Methodology:       "Quotation",
+				WindowSeconds:     120,
+				DeviationPermille: intPtr(10),
+				HeartbeatSeconds:  intPtr(86400),
+			},
+		},
+		{
+			name: "own values are kept",
+			asset: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				Methodology:       "median",
+				WindowSeconds:     300,
+				DeviationPermille: intPtr(2),
+				HeartbeatSeconds:  intPtr(3600),
+				Exchanges:         []string{"UniswapV3"},
+			},
+			expected: assetConfig{
+				Blockchain:        "Ethereum",
+				Address:           "0x01",
+				Methodology:       "median",
+				WindowSeconds:     300,
+				DeviationPermille: intPtr(2),
+				HeartbeatSeconds:  intPtr(3600),
+				Exchanges:         []string{"UniswapV3"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			asset := c.asset.withDefaults(defaults)
+			if !reflect.DeepEqual(asset, c.expected) {
+				t.Errorf("expected %+v, got %+v", c.expected, asset)
+			}
+			if err := asset.validate(); err != nil {
+				t.Errorf("expected valid asset, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAssetConfigValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		asset assetConfig
+		valid bool
+	}{
+		{
+			name:  "quotation",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01"},
+			valid: true,
+		},
+		{
+			name:  "quotation methodology is case insensitive",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "QUOTATION"},
+			valid: true,
+		},
+		{
+			name:  "graphql methodology",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "MAIR", WindowSeconds: 120, Exchanges: []string{"Binance"}},
+			valid: true,
+		},
+		{
+			name:  "missing address",
+			asset: assetConfig{Blockchain: "Ethereum"},
+		},
+		{
+			name:  "unknown methodology",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "foo", WindowSeconds: 120},
+		},
+		{
+			name:  "missing window",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "vwap"},
+		},
+		{
+			name:  "exchanges without methodology",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", Methodology: "quotation", Exchanges: []string{"Binance"}},
+		},
+		{
+			name:  "negative deviation",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", DeviationPermille: intPtr(-1)},
+		},
+		{
+			name:  "negative heartbeat",
+			asset: assetConfig{Blockchain: "Ethereum", Address: "0x01", HeartbeatSeconds: intPtr(-1)},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.asset.validate()
+			if c.valid && err != nil {
+				t.Errorf("expected valid asset, got %v", err)
+			}
+			if !c.valid && err == nil {
+				t.Error("expected invalid asset")
+			}
+		})
+	}
+}
@@ -54,50 +54,125 @@ func main() {
 	gqlMethodology := utils.Getenv("GQL_METHODOLOGY", "vwap")
 	assetsStr := utils.Getenv("ASSETS", "")
 	gqlAssetsStr := utils.Getenv("GQL_ASSETS", "")
+	oracleConfigFile := utils.Getenv("ORACLE_CONFIG_FILE", "")
+	// In simulation mode, updates are sent to a simulated chain and reported instead of sent to BLOCKCHAIN_NODE.
+	simulationMode, err := strconv.ParseBool(utils.Getenv("SIMULATION_MODE", "false"))
+	if err != nil {
+		log.Fatalf("Failed to parse simulationMode: %v", err)
+	}
+	simulationGasPriceGwei, err := strconv.ParseFloat(utils.Getenv("SIMULATION_GAS_PRICE_GWEI", "30"), 64)
+	if err != nil {
+		log.Fatalf("Failed to parse simulationGasPriceGwei: %v", err)
+	}
+	// In batch mode, all updates of a cycle are written to a multiupdate contract in as few transactions as possible.
+	batchMode, err := strconv.ParseBool(utils.Getenv("BATCH_MODE", "false"))
+	if err != nil {
+		log.Fatalf("Failed to parse batchMode: %v", err)
+	}
+	batchGasLimit, err := strconv.ParseUint(utils.Getenv("BATCH_GAS_LIMIT", "2000000"), 10, 64)
+	if err != nil {
+		log.Fatalf("Failed to parse batchGasLimit: %v", err)
+	}
+	gasBumpRetries, err := strconv.Atoi(utils.Getenv("GAS_BUMP_RETRIES", "3"))
+	if err != nil {
+		log.Fatalf("Failed to parse gasBumpRetries: %v", err)
+	}
+	gasBumpPercent, err := strconv.Atoi(utils.Getenv("GAS_BUMP_PERCENT", "20"))
+	if err != nil {
+		log.Fatalf("Failed to parse gasBumpPercent: %v", err)
+	}
+	mineTimeoutSeconds, err := strconv.Atoi(utils.Getenv("MINE_TIMEOUT_SECONDS", "120"))
+	if err != nil {
+		log.Fatalf("Failed to parse mineTimeoutSeconds: %v", err)
+	}
 
-	addresses := []string{}
-	blockchains := []string{}
-	useGql := false
-	var assetsToParse string
+	// Env variables are the defaults for all assets not setting their own methodology.
+	defaults := assetConfig{
+		WindowSeconds:     gqlWindowSize,
+		DeviationPermille: &deviationPermille,
+		HeartbeatSeconds:  &mandatoryFrequencySeconds,
+	}
 
-	// Either Assets or GQL Assets must be non-empty
-	if gqlAssetsStr != "" && assetsStr == "" {
-		useGql = true
-		assetsToParse = gqlAssetsStr
+	var config oracleConfig
+	if oracleConfigFile != "" {
+		config, err = loadOracleConfig(oracleConfigFile, defaults)
+		if err != nil {
+			log.Fatalf("Failed to load oracle config %s: %v", oracleConfigFile, err)
+		}
+	} else if gqlAssetsStr != "" && assetsStr == "" {
+		// Either Assets or GQL Assets must be non-empty
+		defaults.Methodology = gqlMethodology
+		config = oracleConfigFromEnv(gqlAssetsStr, defaults)
 	} else if gqlAssetsStr == "" && assetsStr != "" {
-		useGql = false
-		assetsToParse = assetsStr
+		config = oracleConfigFromEnv(assetsStr, defaults)
 	} else {
 		log.Fatalf("Use either ASSETS or GQL_ASSETS env variable")
 	}
-	assetsParsed := strings.Split(assetsToParse, ",")
 
-	for _, asset := range assetsParsed {
-		entries := strings.Split(asset, "-")
-		blockchains = append(blockchains, strings.TrimSpace(entries[0]))
-		addresses = append(addresses, strings.TrimSpace(entries[1]))
+	assets, err := config.resolvedAssets()
+	if err != nil {
+		log.Fatalf("Invalid oracle config: %v", err)
 	}
 
 	oldPrices := make(map[int]float64)
+	lastUpdates := make(map[int]time.Time)
 
 	/*
 	 * Setup connection to contract, deploy if necessary
 	 */
 
-	conn, err := ethclient.Dial(blockchainNode)
-	if err != nil {
-		log.Fatalf("Failed to connect to the Ethereum client: %v", err)
-	}
+	var conn oracleBackend
+	var auth *bind.TransactOpts
+	var contract *diaOracleServiceV2.DIAOracleV2
+	var sim *simulation
+	var feeder *batchFeeder
 
-	auth, err := bind.NewTransactorWithChainID(strings.NewReader(key), key_password, big.NewInt(chainId))
-	if err != nil {
-		log.Fatalf("Failed to create authorized transactor: %v", err)
-	}
+	if simulationMode {
+		sim, auth, err = newSimulation(blockchainNode, simulationGasPriceGwei)
+		if err != nil {
+			log.Fatalf("Failed to set up simulation: %v", err)
+		}
+		conn = sim
+		if batchMode {
+			var addr common.Address
+			addr, err = sim.deployMultiupdate(auth)
+			if err != nil {
+				log.Fatalf("Failed to deploy simulated multiupdate contract: %v", err)
+			}
+			feeder, err = newBatchFeeder(addr.Hex(), conn, auth, batchGasLimit, gasBumpRetries, gasBumpPercent, time.Duration(mineTimeoutSeconds)*time.Second)
+			if err != nil {
+				log.Fatalf("Failed to bind simulated multiupdate contract: %v", err)
+			}
+			feeder.sim = sim
+		} else {
+			contract, err = sim.deploy(auth)
+			if err != nil {
+				log.Fatalf("Failed to deploy simulated contract: %v", err)
+			}
+		}
+	} else {
+		client, err := ethclient.Dial(blockchainNode)
+		if err != nil {
+			log.Fatalf("Failed to connect to the Ethereum client: %v", err)
+		}
+		conn = client
 
-	var contract *diaOracleServiceV2.DIAOracleV2
-	err = deployOrBindContract(deployedContract, conn, auth, &contract)
-	if err != nil {
-		log.Fatalf("Failed to Deploy or Bind contract: %v", err)
+		auth, err = bind.NewTransactorWithChainID(strings.NewReader(key), key_password, big.NewInt(chainId))
+		if err != nil {
+			log.Fatalf("Failed to create authorized transactor: %v", err)
+		}
+
+		if batchMode {
+			feeder, err = newBatchFeeder(deployedContract, conn, auth, batchGasLimit, gasBumpRetries, gasBumpPercent, time.Duration(mineTimeoutSeconds)*time.Second)
+			if err != nil {
+				log.Fatalf("Failed to bind multiupdate contract: %v", err)
+			}
+		} else {
+			err = deployOrBindContract(deployedContract, conn, auth, &contract)
+			if err != nil {
+				log.Fatalf("Failed to Deploy or Bind contract: %v", err)
+			}
+		}
 	}
 
 	/*
@@ -108,123 +183,128 @@ func main() {
 		for {
 			select {
 			case <-ticker.C:
-				for i, address := range addresses {
-					blockchain := blockchains[i]
+				if feeder != nil {
+					batchUpdateHelper(assets, oldPrices, lastUpdates, feeder)
+					continue
+				}
+				for i, asset := range assets {
 					oldPrice := oldPrices[i]
 					log.Println("old price", oldPrice)
-					oldPrice, err = periodicOracleUpdateHelper(oldPrice, deviationPermille, auth, contract, conn, blockchain, address, useGql, gqlWindowSize, gqlMethodology)
-					oldPrices[i] = oldPrice
+					// The heartbeat is checked on each tick, so it should be a multiple of FREQUENCY_SECONDS.
+					heartbeat := asset.heartbeatSeconds() > 0 && time.Since(lastUpdates[i]) >= time.Duration(asset.heartbeatSeconds())*time.Second
+					newPrice, err := oracleUpdateHelper(oldPrice, heartbeat, asset, auth, contract, conn, sim)
 					if err != nil {
 						log.Println(err)
 					}
+					if newPrice != oldPrice || heartbeat && err == nil {
+						oldPrices[i] = newPrice
+						lastUpdates[i] = time.Now()
+					}
 					time.Sleep(time.Duration(sleepSeconds) * time.Second)
 				}
 			}
 		}
 	}()
 
-	if mandatoryFrequencySeconds > 0 {
-		mandatoryticker := time.NewTicker(time.Duration(mandatoryFrequencySeconds) * time.Second)
-		go func() {
-			for {
-				select {
-				case <-mandatoryticker.C:
-					for i, address := range addresses {
-						blockchain := blockchains[i]
-						oldPrice := oldPrices[i]
-						log.Println("old price", oldPrice)
-						oldPrice, err = oracleUpdateHelper(oldPrice, auth, contract, conn, blockchain, address, useGql, gqlWindowSize, gqlMethodology)
-						oldPrices[i] = oldPrice
-						if err != nil {
-							log.Println(err)
-						}
-						time.Sleep(time.Duration(sleepSeconds) * time.Second)
-					}
-				}
-			}
-		}()
-	}
-
 	select {}
 }
 
-func oracleUpdateHelper(oldPrice float64, auth *bind.TransactOpts, contract *diaOracleServiceV2.DIAOracleV2, conn *ethclient.Client, blockchain string, address string, useGql bool, gqlWindowSize int, gqlMethodology string) (float64, error) {
-	// Empty quotation for our request
-	var rawQ *models.Quotation
-	rawQ = new(models.Quotation)
-	var err error
+// oracleUpdateHelper updates the price of @asset in the oracle if it deviates from @oldPrice by more than
+// the asset's deviation threshold, or unconditionally if @force is true. It returns the price in the oracle.
+// If @sim is not nil, the update is reported by the simulation.
+func oracleUpdateHelper(oldPrice float64, force bool, asset assetConfig, auth *bind.TransactOpts, contract *diaOracleServiceV2.DIAOracleV2, conn oracleBackend, sim *simulation) (float64, error) {
+	rawQ, err := getQuotation(asset)
+	if err != nil {
+		return oldPrice, err
+	}
+	newPrice := rawQ.Price
 
-	// Get quotation for token and update Oracle
-	if useGql {
-		price, symbol, err := getGraphqlAssetQuotationFromDia(blockchain, address, gqlWindowSize, gqlMethodology)
+	if needsUpdate(oldPrice, newPrice, force, asset.deviationPermille()) {
+		tx, err := updateQuotation(rawQ, auth, contract, conn)
 		if err != nil {
-			log.Printf("Failed to retrieve %s quotation data from Graphql on DIA: %v", address, err)
+			log.Fatalf("Failed to update DIA Oracle: %v", err)
 			return oldPrice, err
 		}
-		rawQ.Symbol = symbol
-		rawQ.Price = price
-	} else {
-		rawQ, err = getAssetQuotationFromDia(blockchain, address)
-		if err != nil {
-			log.Fatalf("Failed to retrieve %s quotation data from DIA: %v", address, err)
-			return oldPrice, err
+		if sim != nil {
+			sim.report(tx, rawQ.Symbol+"/USD", oldPrice, newPrice, force)
 		}
+		return newPrice, nil
 	}
-	rawQ.Name = rawQ.Symbol
 
-	newPrice := rawQ.Price
+	return oldPrice, nil
+}
 
-	err = updateQuotation(rawQ, auth, contract, conn)
-	if err != nil {
-		log.Fatalf("Failed to update DIA Oracle: %v", err)
-		return oldPrice, err
+// batchUpdateHelper collects all @assets crossing their deviation or heartbeat and writes them using @feeder.
+// @oldPrices and @lastUpdates are updated for all assets written successfully.
+func batchUpdateHelper(assets []assetConfig, oldPrices map[int]float64, lastUpdates map[int]time.Time, feeder *batchFeeder) {
+	var updates []pendingUpdate
+	for i, asset := range assets {
+		rawQ, err := getQuotation(asset)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		heartbeat := asset.heartbeatSeconds() > 0 && time.Since(lastUpdates[i]) >= time.Duration(asset.heartbeatSeconds())*time.Second
+		if !needsUpdate(oldPrices[i], rawQ.Price, heartbeat, asset.deviationPermille()) {
+			continue
+		}
+		updates = append(updates, pendingUpdate{
+			index:     i,
+			key:       rawQ.Symbol + "/USD",
+			price:     rawQ.Price,
+			timestamp: time.Now().Unix(),
+			oldPrice:  oldPrices[i],
+			heartbeat: heartbeat,
+		})
 	}
-	return newPrice, nil
 
+	for _, update := range feeder.push(updates) {
+		oldPrices[update.index] = update.price
+		lastUpdates[update.index] = time.Now()
+	}
 }
 
-func periodicOracleUpdateHelper(oldPrice float64, deviationPermille int, auth *bind.TransactOpts, contract *diaOracleServiceV2.DIAOracleV2, conn *ethclient.Client, blockchain string, address string, useGql bool, gqlWindowSize int, gqlMethodology string) (float64, error) {
-
+// getQuotation returns the current quotation of @asset using the asset's methodology.
+func getQuotation(asset assetConfig) (*models.Quotation, error) {
 	// Empty quotation for our request
 	var rawQ *models.Quotation
 	rawQ = new(models.Quotation)
 	var err error
 
-	// Get quotation for token and update Oracle
-	if useGql {
-		price, symbol, err := getGraphqlAssetQuotationFromDia(blockchain, address, gqlWindowSize, gqlMethodology)
+	if asset.useGql() {
+		price, symbol, err := getGraphqlAssetQuotationFromDia(asset.Blockchain, asset.Address, asset.WindowSeconds, asset.gqlFilter(), asset.Exchanges)
 		if err != nil {
-			log.Printf("Failed to retrieve %s quotation data from Graphql on DIA: %v", address, err)
-			return oldPrice, err
+			log.Printf("Failed to retrieve %s quotation data from Graphql on DIA: %v", asset.Address, err)
+			return nil, err
 		}
 		rawQ.Symbol = symbol
 		rawQ.Price = price
 	} else {
-		rawQ, err = getAssetQuotationFromDia(blockchain, address)
+		rawQ, err = getAssetQuotationFromDia(asset.Blockchain, asset.Address)
 		if err != nil {
-			log.Fatalf("Failed to retrieve %s quotation data from DIA: %v", address, err)
-			return oldPrice, err
+			log.Fatalf("Failed to retrieve %s quotation data from DIA: %v", asset.Address, err)
+			return nil, err
 		}
 	}
 	rawQ.Name = rawQ.Symbol
+	return rawQ, nil
+}
 
-	// Check for deviation
-	newPrice := rawQ.Price
-
-	if (newPrice > (oldPrice * (1 + float64(deviationPermille)/1000))) || (newPrice < (oldPrice * (1 - float64(deviationPermille)/1000))) {
+// needsUpdate returns true if @force is set or @newPrice deviates from @oldPrice by more than @deviationPermille.
+func needsUpdate(oldPrice float64, newPrice float64, force bool, deviationPermille int) bool {
+	deviation := float64(deviationPermille) / 1000
+	if force {
+		log.Printf("Entering heartbeat update zone")
+		return true
+	}
+	if (newPrice > (oldPrice * (1 + deviation))) || (newPrice < (oldPrice * (1 - deviation))) {
 		log.Println("Entering deviation based update zone")
-		err = updateQuotation(rawQ, auth, contract, conn)
-		if err != nil {
-			log.Fatalf("Failed to update DIA Oracle: %v", err)
-			return oldPrice, err
-		}
-		return newPrice, nil
+		return true
 	}
-
-	return oldPrice, nil
+	return false
 }
 
-func deployOrBindContract(deployedContract string, conn *ethclient.Client, auth *bind.TransactOpts, contract **diaOracleServiceV2.DIAOracleV2) error {
+func deployOrBindContract(deployedContract string, conn oracleBackend, auth *bind.TransactOpts, contract **diaOracleServiceV2.DIAOracleV2) error {
 	var err error
 	if deployedContract != "" {
 		*contract, err = diaOracleServiceV2.NewDIAOracleV2(common.HexToAddress(deployedContract), conn)
@@ -247,26 +327,26 @@ func deployOrBindContract(deployedContract string, conn *ethclient.Client, auth
 	return nil
 }
 
-func updateQuotation(quotation *models.Quotation, auth *bind.TransactOpts, contract *diaOracleServiceV2.DIAOracleV2, conn *ethclient.Client) error {
+func updateQuotation(quotation *models.Quotation, auth *bind.TransactOpts, contract *diaOracleServiceV2.DIAOracleV2, conn oracleBackend) (*types.Transaction, error) {
 	symbol := quotation.Symbol + "/USD"
 	price := quotation.Price
 	timestamp := time.Now().Unix()
-	err := updateOracle(conn, contract, auth, symbol, int64(price*100000000), timestamp)
+	tx, err := updateOracle(conn, contract, auth, symbol, int64(price*100000000), timestamp)
 	if err != nil {
 		log.Fatalf("Failed to update Oracle: %v", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return tx, nil
 }
 
 func updateOracle(
-	client *ethclient.Client,
+	client oracleBackend,
 	contract *diaOracleServiceV2.DIAOracleV2,
 	auth *bind.TransactOpts,
 	key string,
 	value int64,
-	timestamp int64) error {
+	timestamp int64) (*types.Transaction, error) {
 
 	gasPrice, err := client.SuggestGasPrice(context.Background())
 	if err != nil {
@@ -286,7 +366,7 @@ func updateOracle(
 		GasPrice: gasPrice,
 	}, key, big.NewInt(value), big.NewInt(timestamp))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	log.Printf("Gas price: %d\n", tx.GasPrice())
 	log.Printf("key: %s\n", key)
@@ -294,7 +374,7 @@ func updateOracle(
 	log.Printf("Nonce: %d\n", tx.Nonce())
 	log.Printf("Tx To: %s\n", tx.To().String())
 	log.Printf("Tx Hash: 0x%x\n", tx.Hash())
-	return nil
+	return tx, nil
 }
 
 func getAssetQuotationFromDia(blockchain, address string) (*models.Quotation, error) {
@@ -319,7 +399,13 @@ func getAssetQuotationFromDia(blockchain, address string) (*models.Quotation, er
 	return &quotation, nil
 }
 
-func getGraphqlAssetQuotationFromDia(blockchain, address string, windowSize int, gqlMethodology string) (float64, string, error) {
+// getGraphqlAssetQuotationFromDia returns price and symbol of the asset using the filter @gqlMethodology.
+// If @exchanges is non-empty, only trades from these exchanges are taken into account.
+func getGraphqlAssetQuotationFromDia(blockchain, address string, windowSize int, gqlMethodology string, exchanges []string) (float64, string, error) {
+	var exchangesArg string
+	if len(exchanges) > 0 {
+		exchangesArg = `Exchanges: ["` + strings.Join(exchanges, `", "`) + `"],`
+	}
 	currentTime := time.Now()
 	starttime := currentTime.Add(time.Duration(-windowSize*2) * time.Second)
 	type Response struct {
@@ -341,6 +427,7 @@ func getGraphqlAssetQuotationFromDia(blockchain, address string, windowSize int,
 			StartTime: ` + strconv.FormatInt(starttime.Unix(), 10) + `, 
 			EndTime: ` + strconv.FormatInt(currentTime.Unix(), 10) + `, 
 			Address: "` + address + `", 
+			` + exchangesArg + `
 			BlockChain: "` + blockchain + `") {
 				Name
 				Symbol
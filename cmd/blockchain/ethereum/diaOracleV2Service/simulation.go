@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/big"
+
+	diaOracleServiceV2 "github.com/diadata-org/diadata/pkg/dia/scraper/blockchain-scrapers/blockchains/ethereum/diaOracleServiceV2"
+	diaOracleV2MultiupdateService "github.com/diadata-org/diadata/pkg/dia/scraper/blockchain-scrapers/blockchains/ethereum/diaOracleV2MultiupdateService"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// go-ethereum's simulated backend always uses chain ID 1337.
+	simulationChainID  = 1337
+	simulationGasLimit = 8000000
+)
+
+// oracleBackend is the chain the oracle contract is deployed on.
+// It is satisfied by ethclient.Client as well as by simulation.
+type oracleBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// simulation runs the oracle updates against go-ethereum's simulated backend instead of a live chain.
+// Each update is mined immediately and reported together with its gas cost and the deviation that triggered it.
+type simulation struct {
+	*backends.SimulatedBackend
+	// node is used to estimate costs with the gas price of the live chain. It is nil if no node is given.
+	node *ethclient.Client
+	// fallbackGasPrice is the gas price in wei used for cost estimates if node is not available.
+	fallbackGasPrice *big.Int
+	updates          int
+	totalGasUsed     uint64
+	totalCost        *big.Int
+}
+
+// newSimulation returns a simulated chain together with a funded transactor.
+// If @blockchainNode is non-empty, its gas price is used for cost estimates, otherwise @fallbackGasPriceGwei.
+func newSimulation(blockchainNode string, fallbackGasPriceGwei float64) (*simulation, *bind.TransactOpts, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(simulationChainID))
+	if err != nil {
+		return nil, nil, err
+	}
+	balance := new(big.Int).Mul(big.NewInt(1000000), big.NewInt(params.Ether))
+	alloc := core.GenesisAlloc{auth.From: {Balance: balance}}
+
+	fallbackGasPrice, _ := new(big.Float).Mul(big.NewFloat(fallbackGasPriceGwei), big.NewFloat(params.GWei)).Int(nil)
+	sim := &simulation{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, simulationGasLimit),
+		fallbackGasPrice: fallbackGasPrice,
+		totalCost:        big.NewInt(0),
+	}
+	if blockchainNode != "" {
+		sim.node, err = ethclient.Dial(blockchainNode)
+		if err != nil {
+			log.Printf("Failed to connect to %s, estimating costs with fallback gas price: %v", blockchainNode, err)
+			sim.node = nil
+		}
+	}
+	return sim, auth, nil
+}
+
+// SuggestGasPrice returns a gas price accepted by the simulated chain, i.e. twice its current base fee.
+// The simulated backend itself suggests 1 wei, which is below the base fee of its London genesis.
+func (s *simulation) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	header, err := s.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFee == nil {
+		return big.NewInt(1), nil
+	}
+	return new(big.Int).Mul(header.BaseFee, big.NewInt(2)), nil
+}
+
+// deploy deploys the oracle contract on the simulated chain.
+func (s *simulation) deploy(auth *bind.TransactOpts) (*diaOracleServiceV2.DIAOracleV2, error) {
+	addr, _, contract, err := diaOracleServiceV2.DeployDIAOracleV2(auth, s)
+	if err != nil {
+		return nil, err
+	}
+	s.Commit()
+	log.Printf("Simulated contract deployed: 0x%x\n", addr)
+	return contract, nil
+}
+
+// deployMultiupdate deploys the multiupdate oracle contract used in batch mode on the simulated chain.
+func (s *simulation) deployMultiupdate(auth *bind.TransactOpts) (common.Address, error) {
+	addr, _, _, err := diaOracleV2MultiupdateService.DeployDIAOracleV2Multiupdate(auth, s)
+	if err != nil {
+		return common.Address{}, err
+	}
+	s.Commit()
+	log.Printf("Simulated multiupdate contract deployed: 0x%x\n", addr)
+	return addr, nil
+}
+
+// referenceGasPrice returns the gas price used for cost estimates in wei.
+// As in updateOracle, 110% of the gas price suggested by the live chain is used.
+func (s *simulation) referenceGasPrice() *big.Int {
+	if s.node == nil {
+		return s.fallbackGasPrice
+	}
+	gasPrice, err := s.node.SuggestGasPrice(context.Background())
+	if err != nil {
+		log.Printf("Failed to get gas price, using fallback: %v", err)
+		return s.fallbackGasPrice
+	}
+	fGas := new(big.Float).SetInt(gasPrice)
+	fGas.Mul(fGas, big.NewFloat(1.1))
+	gasPrice, _ = fGas.Int(nil)
+	return gasPrice
+}
+
+// report mines @tx updating @key and logs its gas cost along with the price change from @oldPrice to @newPrice.
+// @heartbeat is true if the update was triggered by the heartbeat instead of the deviation.
+func (s *simulation) report(tx *types.Transaction, key string, oldPrice float64, newPrice float64, heartbeat bool) {
+	s.Commit()
+	receipt, err := s.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		log.Printf("Failed to get receipt of simulated update of %s: %v", key, err)
+		return
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		log.Printf("Simulated update of %s reverted", key)
+	}
+
+	gasPrice, cost := s.account(receipt)
+	trigger, deviationPermille := updateTrigger(oldPrice, newPrice, heartbeat)
+	log.Printf("Simulated update of %s: trigger %s, deviation %.2f permille, old price %f, new price %f, gas used %d, gas price %s wei, cost %s",
+		key, trigger, deviationPermille, oldPrice, newPrice, receipt.GasUsed, gasPrice.String(), weiToEther(cost))
+	log.Printf("Simulation total: %d updates, gas used %d, cost %s", s.updates, s.totalGasUsed, weiToEther(s.totalCost))
+}
+
+// reportBatch logs the gas cost of the mined batch transaction with @receipt along with the price changes of @batch.
+// Transactions sent in simulation mode are mined by the batch feeder.
+func (s *simulation) reportBatch(receipt *types.Receipt, batch []pendingUpdate) {
+	gasPrice, cost := s.account(receipt)
+	s.updates += len(batch) - 1
+	for _, update := range batch {
+		trigger, deviationPermille := updateTrigger(update.oldPrice, update.price, update.heartbeat)
+		log.Printf("Simulated batch update of %s: trigger %s, deviation %.2f permille, old price %f, new price %f",
+			update.key, trigger, deviationPermille, update.oldPrice, update.price)
+	}
+	log.Printf("Simulated batch of %d updates: gas used %d, gas price %s wei, cost %s",
+		len(batch), receipt.GasUsed, gasPrice.String(), weiToEther(cost))
+	log.Printf("Simulation total: %d updates, gas used %d, cost %s", s.updates, s.totalGasUsed, weiToEther(s.totalCost))
+}
+
+// account adds the transaction with @receipt to the simulation totals and returns its gas price and cost.
+func (s *simulation) account(receipt *types.Receipt) (*big.Int, *big.Int) {
+	gasPrice := s.referenceGasPrice()
+	cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	s.updates++
+	s.totalGasUsed += receipt.GasUsed
+	s.totalCost.Add(s.totalCost, cost)
+	return gasPrice, cost
+}
+
+// updateTrigger returns what triggered the update from @oldPrice to @newPrice and the deviation in permille.
+func updateTrigger(oldPrice float64, newPrice float64, heartbeat bool) (string, float64) {
+	if oldPrice == 0 {
+		return "initial", 0
+	}
+	trigger := "deviation"
+	if heartbeat {
+		trigger = "heartbeat"
+	}
+	return trigger, math.Abs(newPrice-oldPrice) / oldPrice * 1000
+}
+
+func weiToEther(wei *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Text('f', 8)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -12,8 +13,8 @@ import (
 
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
 	models "github.com/diadata-org/diadata/pkg/model"
-	"github.com/segmentio/kafka-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -46,15 +47,24 @@ var (
 	// mode==assetmap:   	Bridged Trades, asstes are mapped and trades are not saved.
 	mode              = flag.String("mode", "current", "either storeTrades, current, historical or estimation.")
 	pairsfile         = flag.Bool("pairsfile", false, "read pairs from json file in config folder.")
+	exchangeRegistry  = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from postgres.")
 	replicaKafkaTopic string
+	registry          *scrapers.ExchangeRegistry
+	// dedup records trades of centralized exchanges in order to drop trades resent by the exchange.
+	dedup dia.DedupStore
 )
 
 func init() {
 	log = logrus.New()
 	flag.Parse()
+	var err error
+	registry, err = scrapers.InitExchangeRegistry(*exchangeRegistry)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
 	if *exchange == "" {
 		flag.Usage()
-		for e := range scrapers.Exchanges {
+		for e := range registry.Exchanges {
 			log.Info("exchange: ", e)
 		}
 		for {
@@ -65,6 +75,16 @@ func init() {
 		log.Fatal("Invalid exchange string: ", *exchange)
 	}
 	replicaKafkaTopic = utils.Getenv("REPLICA_KAFKA_TOPIC", "false")
+
+	dedupTTL, err := strconv.Atoi(utils.Getenv("DEDUP_TTL_SECONDS", "600"))
+	if err != nil {
+		log.Fatal("parse DEDUP_TTL_SECONDS: ", err)
+	}
+	// All collector instances of an exchange share the dedup store in redis.
+	dedup, err = models.NewDedupStore(utils.Getenv("DEDUP_STORE", ""), "collector_"+*exchange, time.Duration(dedupTTL)*time.Second)
+	if err != nil {
+		log.Fatal("init dedup store: ", err)
+	}
 }
 
 // main manages all PairScrapers and handles incoming trade information
@@ -101,29 +121,30 @@ func main() {
 	if err != nil {
 		log.Warning("no config for exchange's api ", err)
 	}
-	es := scrapers.NewAPIScraper(*exchange, true, configApi.ApiKey, configApi.SecretKey, relDB)
+	es := scrapers.NewAPIScraper(*exchange, true, configApi.ApiKey, configApi.SecretKey, relDB, registry)
 
-	// Set up kafka writers for various modes.
+	// Set up kafka topics for various modes.
 	var (
-		w *kafka.Writer
+		tradesTopic int
 		// This topic can be used to forward trades to services other than the prod. tradesblockservice.
-		wReplica *kafka.Writer
-		wTest    *kafka.Writer
+		tradesTopicReplica int
+		tradesTopicTest    int
 	)
 
 	switch *mode {
 	case "current":
-		w = kafkaHelper.NewWriter(kafkaHelper.TopicTrades)
-		wReplica = kafkaHelper.NewWriter(kafkaHelper.TopicTradesReplica)
-		wTest = kafkaHelper.NewWriter(kafkaHelper.TopicTradesTest)
+		tradesTopic = kafkaHelper.TopicTrades
+		tradesTopicReplica = kafkaHelper.TopicTradesReplica
+		tradesTopicTest = kafkaHelper.TopicTradesTest
 	case "estimation":
-		w = kafkaHelper.NewWriter(kafkaHelper.TopicTradesEstimation)
+		tradesTopic = kafkaHelper.TopicTradesEstimation
 	case "assetmap":
-		w = kafkaHelper.NewWriter(kafkaHelper.TopicTradesEstimation)
+		tradesTopic = kafkaHelper.TopicTradesEstimation
 	}
 
+	bus := messageBus.NewKafkaBus(kafkaHelper.NewWriter)
 	defer func() {
-		err := w.Close()
+		err := bus.Close()
 		if err != nil {
 			log.Error(err)
 		}
@@ -131,7 +152,7 @@ func main() {
 
 	wg := sync.WaitGroup{}
 
-	if scrapers.Exchanges[*exchange].Centralized || scrapers.ExchangeDuplicates[*exchange].Centralized {
+	if registry.IsCentralized(*exchange) || scrapers.ExchangeDuplicates[*exchange].Centralized {
 
 		// Scrape pairs for CEX scrapers.
 		for _, configPair := range pairsExchange {
@@ -154,12 +175,12 @@ func main() {
 		defer wg.Wait()
 
 	}
-	go handleTrades(es.Channel(), &wg, w, wTest, wReplica, ds, *exchange, *mode)
+	go handleTrades(es.Channel(), &wg, bus, tradesTopic, tradesTopicTest, tradesTopicReplica, ds, *exchange, *mode)
 }
 
-func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest *kafka.Writer, wReplica *kafka.Writer, ds *models.DB, exchange string, mode string) {
+func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, bus messageBus.MessageBus, tradesTopic int, tradesTopicTest int, tradesTopicReplica int, ds *models.DB, exchange string, mode string) {
 	lastTradeTime := time.Now()
-	watchdogDelay := scrapers.Exchanges[exchange].WatchdogDelay
+	watchdogDelay := registry.Exchanges[exchange].WatchdogDelay
 	if watchdogDelay == 0 {
 		watchdogDelay = scrapers.ExchangeDuplicates[exchange].WatchdogDelay
 	}
@@ -179,20 +200,29 @@ func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest
 				return
 			}
 			lastTradeTime = time.Now()
+			if dedup != nil && registry.IsCentralized(t.Source) && t.IsDuplicate(dedup) {
+				log.Warn("drop duplicate trade: ", t)
+				continue
+			}
+			// processed is true once the trade is written to productive Kafka or influx.
+			// Only processed trades are recorded for deduplication, such that a resent trade is not dropped after a failure.
+			processed := false
 			// Trades are sent to the tradesblockservice through a kafka channel - either
 			// through trades topic or historical trades topic.
 			if mode == "current" || mode == "historical" || mode == "estimation" {
 
 				// Write trade to productive Kafka.
-				err := writeTradeToKafka(w, t)
+				err := publishTrade(bus, tradesTopic, t)
 				if err != nil {
 					log.Error(err)
+				} else {
+					processed = true
 				}
 
-				if scrapers.Exchanges[t.Source].Centralized {
+				if registry.IsCentralized(t.Source) {
 					// Write CEX trades to test Kafka.
 					if mode == "current" {
-						err = writeTradeToKafka(wTest, t)
+						err = publishTrade(bus, tradesTopicTest, t)
 						if err != nil {
 							log.Error(err)
 						}
@@ -200,7 +230,7 @@ func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest
 				}
 
 				if replicaKafkaTopic == "true" {
-					err := writeTradeToKafka(wReplica, t)
+					err := publishTrade(bus, tradesTopicReplica, t)
 					if err != nil {
 						log.Error(err)
 					}
@@ -212,6 +242,8 @@ func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest
 				err := ds.SaveTradeInflux(t)
 				if err != nil {
 					log.Error(err)
+				} else {
+					processed = true
 				}
 			}
 
@@ -220,13 +252,17 @@ func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest
 				fmt.Println("recieved trade", t)
 
 			}
+
+			if processed && dedup != nil && registry.IsCentralized(t.Source) {
+				t.RecordProcessed(dedup)
+			}
 		}
 	}
 }
 
-func writeTradeToKafka(w *kafka.Writer, t *dia.Trade) error {
+func publishTrade(bus messageBus.MessageBus, topic int, t *dia.Trade) error {
 	// Write trade to Kafka.
-	err := kafkaHelper.WriteMessage(w, t)
+	err := bus.Publish(topic, t)
 	if err != nil {
 		return err
 	}
@@ -237,7 +273,7 @@ func writeTradeToKafka(w *kafka.Writer, t *dia.Trade) error {
 		if err != nil {
 			log.Error("swap trade: ", err)
 		} else {
-			err = kafkaHelper.WriteMessage(w, &tSwapped)
+			err = bus.Publish(topic, &tSwapped)
 			if err != nil {
 				return err
 			}
@@ -247,7 +283,7 @@ func writeTradeToKafka(w *kafka.Writer, t *dia.Trade) error {
 }
 
 func isValidExchange(estring string) bool {
-	for e := range scrapers.Exchanges {
+	for e := range registry.Exchanges {
 		if e == estring {
 			return true
 		}
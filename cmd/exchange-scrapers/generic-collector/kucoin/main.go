@@ -39,8 +39,10 @@ var (
 		dia.HuckleberryExchange,
 		dia.NetswapExchange,
 	}
-	pairsfile   = flag.Bool("pairsfile", false, "read pairs from json file in config folder.")
-	StartupDone = false
+	pairsfile        = flag.Bool("pairsfile", false, "read pairs from json file in config folder.")
+	exchangeRegistry = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from postgres.")
+	registry         *scrapers.ExchangeRegistry
+	StartupDone      = false
 	// delay until the service will be restarted
 	restartDelayMinutes, _ = strconv.Atoi(utils.Getenv("RESTART_DELAY_MINUTES", "720"))
 	startTime              = time.Now()
@@ -60,9 +62,14 @@ func live() bool {
 
 func init() {
 	flag.Parse()
+	var err error
+	registry, err = scrapers.InitExchangeRegistry(*exchangeRegistry)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
 	if *exchange == "" {
 		flag.Usage()
-		for e := range scrapers.Exchanges {
+		for e := range registry.Exchanges {
 			log.Info("exchange: ", e)
 		}
 		for {
@@ -144,7 +151,7 @@ func main() {
 
 	wg := sync.WaitGroup{}
 
-	if scrapers.Exchanges[*exchange].Centralized {
+	if registry.IsCentralized(*exchange) {
 
 		// Scrape pairs for CEX scrapers.
 		for _, configPair := range pairsExchange {
@@ -175,7 +182,7 @@ func main() {
 
 func handleTrades(c chan *dia.Trade, wg *sync.WaitGroup, w *kafka.Writer, wTest *kafka.Writer, ds *models.DB, exchange string, mode string) {
 	lastTradeTime := time.Now()
-	watchdogDelay := scrapers.Exchanges[exchange].WatchdogDelay
+	watchdogDelay := registry.Exchanges[exchange].WatchdogDelay
 	t := time.NewTicker(time.Duration(watchdogDelay) * time.Second)
 	for {
 		select {
@@ -253,7 +260,7 @@ func writeTradeToKafka(w *kafka.Writer, t *dia.Trade) error {
 }
 
 func isValidExchange(estring string) bool {
-	for e := range scrapers.Exchanges {
+	for e := range registry.Exchanges {
 		if e == estring {
 			return true
 		}
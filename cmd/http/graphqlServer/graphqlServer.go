@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -9,7 +10,10 @@ import (
 
 	"github.com/diadata-org/diadata/pkg/utils"
 
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
 	"github.com/diadata-org/diadata/pkg/graphql/resolver"
+	"github.com/diadata-org/diadata/pkg/http/streamApi"
 	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -40,7 +44,17 @@ func main() {
 		log.Fatal("parse batch duration ", err)
 	}
 
-	diaSchema := graphql.MustParseSchema(ds, &resolver.DiaResolver{DS: *datastore, RelDB: *relStore, InfluxBatchSize: influxBatchSize}, graphql.UseFieldResolvers())
+	// Subscriptions are served from filtersBlocks as soon as they are published.
+	// Without consumer group, each instance of the server receives all filtersBlocks.
+	bus := messageBus.NewKafkaBus(nil)
+	filtersBlockSub, err := bus.Subscribe(kafkaHelper.TopicFiltersBlock, "")
+	if err != nil {
+		log.Error("subscribe to filtersBlocks: ", err)
+	}
+	hub := streamApi.NewHub()
+	go hub.Run(context.Background(), filtersBlockSub)
+
+	diaSchema := graphql.MustParseSchema(ds, &resolver.DiaResolver{DS: *datastore, RelDB: *relStore, InfluxBatchSize: influxBatchSize, Stream: hub}, graphql.UseFieldResolvers())
 
 	mux := http.NewServeMux()
 	urlFolderPrefix := utils.Getenv("URL_FOLDER_PREFIX", "/graphql")
@@ -55,6 +69,7 @@ func main() {
 	}))
 
 	mux.Handle(urlFolderPrefix+"/query", &relay.Handler{Schema: diaSchema})
+	mux.Handle(urlFolderPrefix+"/subscriptions", subscriptionHandler(diaSchema))
 
 	log.WithFields(log.Fields{"time": time.Now()}).Info("starting server")
 	log.Fatal(http.ListenAndServe(utils.Getenv("LISTEN_PORT", ":1111"), logged(mux)))
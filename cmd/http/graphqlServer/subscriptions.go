@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Message types of the graphql-transport-ws protocol.
+const (
+	msgConnectionInit = "connection_init"
+	msgConnectionAck  = "connection_ack"
+	msgPing           = "ping"
+	msgPong           = "pong"
+	msgSubscribe      = "subscribe"
+	msgNext           = "next"
+	msgError          = "error"
+	msgComplete       = "complete"
+
+	subscriptionProtocol = "graphql-transport-ws"
+	writeTimeout         = 10 * time.Second
+	// maxOperations is the maximal number of concurrent subscriptions per connection.
+	maxOperations = 20
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type subscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+var upgrader = websocket.Upgrader{
+	Subprotocols: []string{subscriptionProtocol},
+	CheckOrigin:  func(r *http.Request) bool { return true },
+}
+
+// wsConnection serves the subscriptions of a single websocket client.
+type wsConnection struct {
+	conn       *websocket.Conn
+	schema     *graphql.Schema
+	writeMu    sync.Mutex
+	mu         sync.Mutex
+	operations map[string]context.CancelFunc
+}
+
+// subscriptionHandler serves graphql subscriptions of @schema over websockets.
+func subscriptionHandler(schema *graphql.Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Error("upgrade to websocket: ", err)
+			return
+		}
+		c := &wsConnection{conn: conn, schema: schema, operations: make(map[string]context.CancelFunc)}
+		c.serve(r.Context())
+	})
+}
+
+func (c *wsConnection) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		err := c.conn.Close()
+		if err != nil {
+			log.Error("close websocket: ", err)
+		}
+	}()
+
+	initialised := false
+	for {
+		var msg wsMessage
+		err := c.conn.ReadJSON(&msg)
+		if err != nil {
+			return
+		}
+		switch msg.Type {
+		case msgConnectionInit:
+			initialised = true
+			c.write(wsMessage{Type: msgConnectionAck})
+		case msgPing:
+			c.write(wsMessage{Type: msgPong})
+		case msgPong:
+		case msgSubscribe:
+			if !initialised {
+				return
+			}
+			c.subscribe(ctx, msg)
+		case msgComplete:
+			c.stop(msg.ID)
+		default:
+			log.Warn("unknown websocket message type: ", msg.Type)
+			return
+		}
+	}
+}
+
+// subscribe starts the operation in @msg and forwards its results until it completes or is stopped.
+func (c *wsConnection) subscribe(ctx context.Context, msg wsMessage) {
+	var payload subscribePayload
+	err := json.Unmarshal(msg.Payload, &payload)
+	if err != nil {
+		c.writeError(msg.ID, err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.operations[msg.ID]; ok || msg.ID == "" || len(c.operations) >= maxOperations {
+		c.mu.Unlock()
+		c.writeError(msg.ID, "invalid or too many subscriptions")
+		return
+	}
+	opCtx, cancel := context.WithCancel(ctx)
+	c.operations[msg.ID] = cancel
+	c.mu.Unlock()
+
+	responses, err := c.schema.Subscribe(opCtx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		c.stop(msg.ID)
+		c.writeError(msg.ID, err.Error())
+		return
+	}
+
+	go func() {
+		for response := range responses {
+			data, err := json.Marshal(response)
+			if err != nil {
+				log.Error("marshal subscription response: ", err)
+				continue
+			}
+			c.write(wsMessage{ID: msg.ID, Type: msgNext, Payload: data})
+		}
+		// Do not send complete if the client stopped the subscription.
+		if opCtx.Err() == nil {
+			c.write(wsMessage{ID: msg.ID, Type: msgComplete})
+		}
+		c.stop(msg.ID)
+	}()
+}
+
+// stop cancels the operation with @id.
+func (c *wsConnection) stop(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.operations[id]; ok {
+		cancel()
+		delete(c.operations, id)
+	}
+}
+
+func (c *wsConnection) writeError(id string, message string) {
+	data, err := json.Marshal([]map[string]string{{"message": message}})
+	if err != nil {
+		log.Error("marshal subscription error: ", err)
+		return
+	}
+	c.write(wsMessage{ID: id, Type: msgError, Payload: data})
+}
+
+func (c *wsConnection) write(msg wsMessage) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		log.Error("set websocket write deadline: ", err)
+		return
+	}
+	err = c.conn.WriteJSON(msg)
+	if err != nil {
+		log.Error("write to websocket: ", err)
+	}
+}
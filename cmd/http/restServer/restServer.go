@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	jwt "github.com/appleboy/gin-jwt/v2"
 	cacheTime "github.com/diadata-org/diadata/pkg/constants"
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
+	"github.com/diadata-org/diadata/pkg/http/restServer/apiKeyApi"
 	"github.com/diadata-org/diadata/pkg/http/restServer/diaApi"
 	"github.com/diadata-org/diadata/pkg/http/restServer/kafkaApi"
+	"github.com/diadata-org/diadata/pkg/http/streamApi"
 	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/diadata-org/diadata/pkg/utils"
 	"github.com/gin-contrib/cache"
@@ -165,13 +169,6 @@ func main() {
 		auth.GET("/refresh_token", authMiddleware.RefreshHandler)
 	}
 
-	kafka := r.Group("/kafka")
-	{
-		kafka.GET("/tradesBlock", GetTradesBlock)
-		kafka.GET("/filtersBlock", GetFiltersBlock)
-		kafka.GET("/trades", GetTrades)
-	}
-
 	memoryStore := persistence.NewInMemoryStore(time.Second)
 
 	store, err := models.NewDataStore()
@@ -182,29 +179,69 @@ func main() {
 	if err != nil {
 		log.Errorln("NewRelDataStore", err)
 	}
+	err = diaApi.LoadBlockchains(relStore)
+	if err != nil {
+		log.Fatal("get all chains: ", err)
+	}
 	diaApiEnv := &diaApi.Env{
 		DataStore: store,
 		RelDB:     *relStore,
 	}
 
+	// API keys are metered and limited on all endpoints. If REQUIRE_API_KEY is not true,
+	// public endpoints are also served without key.
+	apiKeys := apiKeyApi.NewManager(relStore, utils.Getenv("REQUIRE_API_KEY", "false") == "true")
+	go apiKeys.Run(context.Background())
+
+	admin := r.Group("/v1/admin")
+	admin.Use(authMiddleware.MiddlewareFunc())
+	apiKeys.AddAdminRoutes(admin)
+	apiKeys.AddRoutes(r.Group("/v1"))
+
+	kafka := r.Group("/kafka")
+	kafka.Use(apiKeys.Middleware(dia.ScopeKafka))
+	{
+		kafka.GET("/tradesBlock", GetTradesBlock)
+		kafka.GET("/filtersBlock", GetFiltersBlock)
+		kafka.GET("/trades", GetTrades)
+	}
+
+	// Write endpoints accept API keys with write scope as well as the admin login.
 	diaAuth := r.Group("/v1")
-	diaAuth.Use(authMiddleware.MiddlewareFunc())
+	diaAuth.Use(apiKeys.KeyOrElse(dia.ScopeWrite, authMiddleware.MiddlewareFunc()))
 	{
 		diaAuth.POST("/supply", diaApiEnv.PostSupply)
 		diaAuth.POST("/quotation", diaApiEnv.SetQuotation)
 	}
 
+	// Quotations and filter points are streamed as soon as filtersBlocks are published.
+	// Without consumer group, each instance of the server receives all filtersBlocks.
+	bus := messageBus.NewKafkaBus(nil)
+	filtersBlockSub, err := bus.Subscribe(kafkaHelper.TopicFiltersBlock, "")
+	if err != nil {
+		log.Error("subscribe to filtersBlocks: ", err)
+	}
+	hub := streamApi.NewHub()
+	go hub.Run(context.Background(), filtersBlockSub)
+
 	diaGroup := r.Group("/v1")
+	diaGroup.Use(apiKeys.Middleware(dia.ScopeRead))
 	{
+		// Streaming endpoints.
+		hub.AddRoutes(diaGroup)
+
 		// Trades and prices endpoints.
 		diaGroup.GET("/quotation/:symbol", cache.CachePageAtomic(memoryStore, cacheTime.CachingTime20Secs, diaApiEnv.GetQuotation))
 		diaGroup.GET("/assetQuotation/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTime20Secs, diaApiEnv.GetAssetQuotation))
+		diaGroup.POST("/assetQuotations", diaApiEnv.GetAssetQuotationsBatch)
 		diaGroup.GET("/lastTradeTime/:exchange/:blockchain/:address", diaApiEnv.GetLastTradeTime)
 		diaGroup.GET("/lastTradesAsset/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeLong, diaApiEnv.GetLastTradesAsset))
 
 		// Filters endpoints.
 		diaGroup.GET("/chartPoints/:filter/:exchange/:symbol", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetChartPoints))
 		diaGroup.GET("/assetChartPoints/:filter/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetAssetChartPoints))
+		diaGroup.GET("/rejectedSources/:filter/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetRejectedSources))
+		diaGroup.GET("/candles/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetCandles))
 		diaGroup.GET("/chartPointsAllExchanges/:filter/:symbol", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetChartPointsAllExchanges))
 
 		// Supply endpoints.
@@ -294,6 +331,7 @@ func main() {
 		diaGroup.GET("/topNFT/:numCollections", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeLong, diaApiEnv.GetTopNFTClasses))
 		diaGroup.GET("/NFTVolume/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeLong, diaApiEnv.GetNFTVolume))
 		diaGroup.GET("/assetmap/:blockchain/:address", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeLong, diaApiEnv.GetAssetMap))
+		diaGroup.GET("/assetBridges", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeLong, diaApiEnv.GetAssetBridges))
 		diaGroup.GET("/assetUpdates/:blockchain/:address/:deviation/:frequencySeconds", cache.CachePageAtomic(memoryStore, cacheTime.CachingTimeShort, diaApiEnv.GetAssetUpdates))
 
 		// Endpoints for Synthassets
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
 	liquidityscraper "github.com/diadata-org/diadata/pkg/dia/scraper/liquidity-scrapers"
 	models "github.com/diadata-org/diadata/pkg/model"
 
@@ -37,7 +38,11 @@ func main() {
 
 func runLiquiditySource(relDB *models.RelDB, source string) {
 	log.Info("Fetching pools from ", source)
-	scraper := liquidityscraper.NewLiquidityScraper(source)
+	registry, err := scrapers.LoadExchangeRegistry(relDB)
+	if err != nil {
+		log.Fatal("load exchange registry: ", err)
+	}
+	scraper := liquidityscraper.NewLiquidityScraper(source, registry)
 
 	for {
 		select {
@@ -0,0 +1,10 @@
+module github.com/diadata-org/diadata/services/allInOne
+
+go 1.17
+
+require (
+	github.com/diadata-org/diadata v1.4.151
+	github.com/gin-gonic/gin v1.8.1
+	github.com/mattn/go-sqlite3 v1.11.0
+	github.com/sirupsen/logrus v1.8.1
+)
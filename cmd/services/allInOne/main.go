@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	filters "github.com/diadata-org/diadata/internal/pkg/filtersBlockService"
+	"github.com/diadata-org/diadata/internal/pkg/tradesBlockService"
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
+	"github.com/diadata-org/diadata/pkg/http/restServer/nodeApi"
+	"github.com/diadata-org/diadata/pkg/http/streamApi"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/diadata-org/diadata/pkg/model/embedded"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// The all-in-one service runs a price node in a single process: the exchange scrapers, the tradesblock service,
+// the filtersblock service, the REST endpoints of nodeApi and the streaming endpoints of streamApi.
+// Services are connected by an in-process message bus instead of kafka. Relational data is stored in sqlite and trades, filter values and quotations in daily files,
+// both in the data directory.
+// On first start, exchanges and blockchains are read from the registry file. They are stored in sqlite
+// such that later starts do not need the file.
+
+const (
+	busBufferSize   = 10000
+	flushInterval   = 10 * time.Second
+	pruneInterval   = time.Hour
+	shutdownTimeout = 10 * time.Second
+)
+
+var (
+	exchanges         = flag.String("exchanges", "", "comma separated list of exchanges to scrape.")
+	dataDir           = flag.String("dataDir", "data", "directory of the sqlite database and the time-series files.")
+	retention         = flag.Duration("retention", 7*24*time.Hour, "time trades, filter values and quotations are kept.")
+	listen            = flag.String("listen", ":8080", "address the REST API is served on.")
+	exchangeRegistry  = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from sqlite.")
+	assetBridges      = flag.String("assetBridges", "", "json file in the config folder with the asset bridging table. If empty, it is loaded from sqlite.")
+	pairsfile         = flag.Bool("pairsfile", false, "read pairs from json files in config folder and store them in sqlite.")
+	filtersConfigFile = flag.String("filtersConfig", "", "json file in the config folder assigning filters to assets, such as filters/filters. Defaults to the built-in filters.")
+	stablecoinsFile   = flag.String("stablecoins", models.StablecoinsFile, "json file in the config folder with the stablecoin pegs.")
+)
+
+func init() {
+	flag.Parse()
+	if *exchanges == "" {
+		flag.Usage()
+		log.Fatal("no exchanges given")
+	}
+}
+
+func main() {
+	err := os.MkdirAll(*dataDir, 0755)
+	if err != nil {
+		log.Fatal("create data directory: ", err)
+	}
+	relDB, err := embedded.NewSQLiteRelDataStore(filepath.Join(*dataDir, "reldb.sqlite"))
+	if err != nil {
+		log.Fatal("open sqlite: ", err)
+	}
+	defer closeStore("sqlite", relDB.Close)
+	ds, err := embedded.NewFileDataStore(filepath.Join(*dataDir, "timeseries"), *retention)
+	if err != nil {
+		log.Fatal("open time-series files: ", err)
+	}
+	defer closeStore("time-series files", ds.Close)
+
+	registry, err := initExchangeRegistry(relDB)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
+	bridges, err := initAssetBridges(relDB)
+	if err != nil {
+		log.Fatal("init asset bridges: ", err)
+	}
+	stablecoins, err := models.NewStablecoinChecker(*stablecoinsFile, bridges, tradesBlockService.DefaultStablecoinTolerance, false, ds, false)
+	if err != nil {
+		log.Fatal("init stablecoin checker: ", err)
+	}
+	var filtersConfig *filters.FiltersConfig
+	if *filtersConfigFile != "" {
+		filtersConfig, err = filters.LoadFiltersConfig(*filtersConfigFile)
+		if err != nil {
+			log.Fatal("load filters config: ", err)
+		}
+	}
+
+	// Subscriptions of the in-process bus only receive messages published after subscribing.
+	// Hence, services subscribe before any scraper is started.
+	bus := messageBus.NewMemoryBus(busBufferSize)
+	defer closeStore("message bus", bus.Close)
+	tradesSub, err := bus.Subscribe(kafkaHelper.TopicTrades, "")
+	if err != nil {
+		log.Fatal("subscribe to trades: ", err)
+	}
+	tradesBlockSub, err := bus.Subscribe(kafkaHelper.TopicTradesBlock, "")
+	if err != nil {
+		log.Fatal("subscribe to tradesBlocks: ", err)
+	}
+	filtersBlockSub, err := bus.Subscribe(kafkaHelper.TopicFiltersBlock, "")
+	if err != nil {
+		log.Fatal("subscribe to filtersBlocks: ", err)
+	}
+	hub := streamApi.NewHub()
+	go hub.Run(context.Background(), filtersBlockSub)
+
+	tbs := tradesBlockService.NewTradesBlockService(tradesBlockService.Options{
+		Datastore:     ds,
+		BlockDuration: dia.BlockSizeSeconds,
+		Registry:      registry,
+		Bridges:       bridges,
+		Stablecoins:   stablecoins,
+	})
+	go publishTradesBlocks(tbs, bus)
+	go processTrades(tradesSub, tbs)
+
+	filtersBlocks := make(chan *dia.FiltersBlock)
+	fbs := filters.NewFiltersBlockService(nil, ds, filtersBlocks, filtersConfig)
+	go publishFiltersBlocks(filtersBlocks, bus)
+	go processTradesBlocks(tradesBlockSub, fbs)
+
+	for _, exchange := range strings.Split(*exchanges, ",") {
+		exchange = strings.TrimSpace(exchange)
+		if _, ok := registry.Exchanges[exchange]; !ok {
+			log.Fatal("exchange not in registry: ", exchange)
+		}
+		err = startScraper(exchange, relDB, registry, bus)
+		if err != nil {
+			log.Fatalf("start scraper %s: %v", exchange, err)
+		}
+	}
+
+	go maintainFiles(ds)
+
+	engine := gin.Default()
+	env := nodeApi.Env{DataStore: ds, RelDB: relDB}
+	env.AddRoutes(engine.Group("/v1"))
+	hub.AddRoutes(engine.Group("/v1"))
+	server := &http.Server{Addr: *listen, Handler: engine}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Fatal("serve REST API: ", err)
+		}
+	}()
+	log.Infof("price node for %s serving on %s", *exchanges, *listen)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	log.Info("shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err = server.Shutdown(ctx)
+	if err != nil {
+		log.Error("shutdown REST API: ", err)
+	}
+}
+
+// initExchangeRegistry returns the registry from the registry file and stores its exchanges, blockchains and
+// chain configs in @relDB. If no file is given, the registry is loaded from @relDB.
+func initExchangeRegistry(relDB models.RelDatastore) (*scrapers.ExchangeRegistry, error) {
+	if *exchangeRegistry == "" {
+		return scrapers.LoadExchangeRegistry(relDB)
+	}
+	registry, err := scrapers.LoadExchangeRegistryFromFile(*exchangeRegistry)
+	if err != nil {
+		return nil, err
+	}
+	for _, blockchain := range registry.Blockchains {
+		if err := relDB.SetBlockchain(blockchain); err != nil {
+			return nil, err
+		}
+	}
+	for _, chainConfig := range registry.ChainConfigs {
+		if err := relDB.SetChainConfig(chainConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, exchange := range registry.Exchanges {
+		if err := relDB.SetExchange(exchange); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// initAssetBridges returns the bridging table from the bridges file and stores it in @relDB.
+// If no file is given, the bridging table is loaded from @relDB.
+func initAssetBridges(relDB models.RelDatastore) (*dia.AssetBridges, error) {
+	if *assetBridges == "" {
+		return models.LoadAssetBridges(relDB)
+	}
+	bridges, err := models.GetAssetBridgesFromConfig(*assetBridges)
+	if err != nil {
+		return nil, err
+	}
+	for _, bridge := range bridges {
+		if err := relDB.SetAssetBridge(bridge); err != nil {
+			return nil, err
+		}
+	}
+	return dia.NewAssetBridges(bridges), nil
+}
+
+// startScraper starts the scraper for @exchange and forwards its trades to @bus.
+// Centralized exchanges scrape the pairs of the exchange stored in @relDB or in the pairs file.
+func startScraper(exchange string, relDB models.RelDatastore, registry *scrapers.ExchangeRegistry, bus messageBus.MessageBus) error {
+	var pairs []dia.ExchangePair
+	if *pairsfile {
+		cc := configCollectors.NewConfigCollectors(exchange, ".json")
+		pairs = cc.AllPairs()
+		for _, pair := range pairs {
+			if err := relDB.SetExchangePair(exchange, pair, true); err != nil {
+				log.Error("store exchange pair: ", err)
+			}
+		}
+	} else {
+		var err error
+		pairs, err = relDB.GetExchangePairSymbols(exchange)
+		if err != nil {
+			return err
+		}
+	}
+
+	configApi, err := dia.GetConfig(exchange)
+	if err != nil {
+		log.Warning("no config for exchange's api ", err)
+	}
+	es := scrapers.NewAPIScraper(exchange, true, configApi.ApiKey, configApi.SecretKey, relDB, registry)
+	if registry.IsCentralized(exchange) || scrapers.ExchangeDuplicates[exchange].Centralized {
+		for _, pair := range pairs {
+			_, err := es.ScrapePair(dia.ExchangePair{Symbol: pair.Symbol, ForeignName: pair.ForeignName})
+			if err != nil {
+				log.Errorf("scrape pair %s on %s: %v", pair.ForeignName, exchange, err)
+			}
+		}
+		log.Infof("scraping %d pairs on %s", len(pairs), exchange)
+	}
+	go forwardTrades(es.Channel(), exchange, relDB, bus)
+	return nil
+}
+
+// forwardTrades publishes the trades of a scraper to @bus. Assets of trades are stored in @relDB,
+// such that they can be queried from the REST API.
+func forwardTrades(trades chan *dia.Trade, exchange string, relDB models.RelDatastore, bus messageBus.MessageBus) {
+	knownAssets := make(map[string]struct{})
+	for t := range trades {
+		for _, asset := range []dia.Asset{t.QuoteToken, t.BaseToken} {
+			key := asset.Blockchain + "-" + asset.Address
+			if _, ok := knownAssets[key]; ok || asset.Address == "" {
+				continue
+			}
+			if err := relDB.SetAsset(asset); err != nil {
+				log.Error("store asset: ", err)
+				continue
+			}
+			knownAssets[key] = struct{}{}
+		}
+		err := bus.Publish(kafkaHelper.TopicTrades, t)
+		if err == messageBus.ErrClosed {
+			return
+		}
+		if err != nil {
+			log.Error("publish trade: ", err)
+		}
+	}
+	log.Warn("trades channel closed for ", exchange)
+}
+
+func processTrades(sub messageBus.Subscription, tbs *tradesBlockService.TradesBlockService) {
+	for {
+		m, err := sub.Next(context.Background())
+		if err == messageBus.ErrClosed {
+			return
+		}
+		if err != nil {
+			log.Error("next trade: ", err)
+			continue
+		}
+		var t dia.Trade
+		err = kafkaHelper.UnmarshalMessage(m.Value, &t)
+		if err != nil {
+			log.Errorf("ignored trade at offset %d: %v", m.Offset, err)
+			continue
+		}
+		tbs.ProcessTrade(&t)
+	}
+}
+
+func publishTradesBlocks(tbs *tradesBlockService.TradesBlockService, bus messageBus.MessageBus) {
+	for tb := range tbs.Channel() {
+		err := bus.Publish(kafkaHelper.TopicTradesBlock, tb)
+		if err != nil {
+			log.Error("publish tradesBlock: ", err)
+		}
+	}
+}
+
+func processTradesBlocks(sub messageBus.Subscription, fbs *filters.FiltersBlockService) {
+	for {
+		m, err := sub.Next(context.Background())
+		if err == messageBus.ErrClosed {
+			return
+		}
+		if err != nil {
+			log.Error("next tradesBlock: ", err)
+			continue
+		}
+		var tb dia.TradesBlock
+		err = kafkaHelper.UnmarshalMessage(m.Value, &tb)
+		if err != nil {
+			log.Errorf("ignored tradesBlock at offset %d: %v", m.Offset, err)
+			continue
+		}
+		fbs.ProcessTradesBlockSync(&tb)
+		log.Infof("processed tradesBlock with %d trades", len(tb.TradesBlockData.Trades))
+	}
+}
+
+// publishFiltersBlocks publishes filtersBlocks to @bus for streaming. Filter values are stored by the filtersblock service itself.
+func publishFiltersBlocks(filtersBlocks chan *dia.FiltersBlock, bus messageBus.MessageBus) {
+	for fb := range filtersBlocks {
+		err := bus.Publish(kafkaHelper.TopicFiltersBlock, fb)
+		if err != nil {
+			log.Error("publish filtersBlock: ", err)
+		}
+	}
+}
+
+// maintainFiles regularly syncs the time-series files and removes records older than the retention period.
+func maintainFiles(ds *embedded.FileDB) {
+	flushTicker := time.NewTicker(flushInterval)
+	pruneTicker := time.NewTicker(pruneInterval)
+	for {
+		select {
+		case <-flushTicker.C:
+			if err := ds.Flush(); err != nil {
+				log.Error("flush time-series files: ", err)
+			}
+		case <-pruneTicker.C:
+			ds.Prune()
+		}
+	}
+}
+
+func closeStore(name string, close func() error) {
+	if err := close(); err != nil {
+		log.Errorf("close %s: %v", name, err)
+	}
+}
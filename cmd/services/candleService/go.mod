@@ -0,0 +1,8 @@
+module github.com/diadata-org/diadata/services/candleService
+
+go 1.17
+
+require (
+	github.com/diadata-org/diadata v1.4.152
+	github.com/sirupsen/logrus v1.8.1
+)
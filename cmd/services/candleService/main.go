@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	models "github.com/diadata-org/diadata/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// candleService aggregates trades into candles once their time range is over and stores them in influx,
+// such that candles over long time ranges are served from the stored candles.
+
+var (
+	resolutions   = flag.String("resolutions", "1h,1d", "comma separated resolutions of the stored candles, out of 1m, 5m, 1h and 1d.")
+	delay         = flag.Duration("delay", 2*time.Minute, "time waited after the end of a candle before it is computed, such that late trades are included.")
+	backfill      = flag.Int("backfill", 0, "number of past candles per resolution computed at startup.")
+	retryInterval = flag.Duration("retryInterval", time.Minute, "time waited before candles failed to be computed or stored are retried.")
+	maxAttempts   = flag.Int("maxAttempts", 10, "number of attempts to compute and store the candles of a time range before it is skipped.")
+)
+
+// candleRange is a time range whose candles are still to be stored.
+type candleRange struct {
+	starttime time.Time
+	endtime   time.Time
+	attempts  int
+}
+
+func main() {
+	flag.Parse()
+
+	ds, err := models.NewDataStore()
+	if err != nil {
+		log.Fatal("NewDataStore: ", err)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, resolution := range strings.Split(*resolutions, ",") {
+		duration, err := models.CandleResolution(resolution)
+		if err != nil {
+			log.Fatal(err)
+		}
+		wg.Add(1)
+		go func(resolution string, duration time.Duration) {
+			defer wg.Done()
+			runCandles(ds, resolution, duration)
+		}(resolution, duration)
+	}
+	wg.Wait()
+}
+
+// runCandles stores the candles with @resolution of each time range as soon as it is over.
+// Time ranges failing to be stored are retried every retryInterval, up to maxAttempts times.
+func runCandles(ds models.Datastore, resolution string, duration time.Duration) {
+	end := time.Now().Add(-*delay).Truncate(duration)
+	var pending []candleRange
+	for i := *backfill; i > 0; i-- {
+		pending = append(pending, candleRange{starttime: end.Add(-time.Duration(i) * duration), endtime: end.Add(-time.Duration(i-1) * duration)})
+	}
+
+	for {
+		pending = storePending(ds, resolution, pending)
+
+		next := end.Add(duration)
+		wait := time.Until(next.Add(*delay))
+		if len(pending) > 0 && *retryInterval < wait {
+			wait = *retryInterval
+		}
+		time.Sleep(wait)
+		for !time.Now().Before(next.Add(*delay)) {
+			pending = append(pending, candleRange{starttime: end, endtime: next})
+			end = next
+			next = end.Add(duration)
+		}
+	}
+}
+
+// storePending stores the candles of all @pending time ranges and returns the ranges to be retried.
+func storePending(ds models.Datastore, resolution string, pending []candleRange) []candleRange {
+	var failed []candleRange
+	for _, r := range pending {
+		err := storeCandles(ds, resolution, r.starttime, r.endtime)
+		if err == nil {
+			continue
+		}
+		r.attempts++
+		if r.attempts >= *maxAttempts {
+			log.Errorf("skip %s candles in [%v, %v) after %d attempts: %v", resolution, r.starttime, r.endtime, r.attempts, err)
+			continue
+		}
+		log.Warnf("retry %s candles in [%v, %v) after attempt %d: %v", resolution, r.starttime, r.endtime, r.attempts, err)
+		failed = append(failed, r)
+	}
+	return failed
+}
+
+// storeCandles computes and stores the candles of all assets with @resolution in [@starttime, @endtime).
+// Storing the candles of a time range again overwrites them, so failed time ranges can be retried as a whole.
+func storeCandles(ds models.Datastore, resolution string, starttime time.Time, endtime time.Time) error {
+	t0 := time.Now()
+	candles, err := ds.ComputeCandles(resolution, starttime, endtime)
+	if err != nil {
+		return fmt.Errorf("compute candles: %v", err)
+	}
+	for _, candle := range candles {
+		err = ds.SaveCandleInflux(candle)
+		if err != nil {
+			return fmt.Errorf("save candle: %v", err)
+		}
+	}
+	err = ds.Flush()
+	if err != nil {
+		return fmt.Errorf("flush candles: %v", err)
+	}
+	log.Infof("stored %d %s candles in [%v, %v) in %v", len(candles), resolution, starttime, endtime, time.Since(t0))
+	return nil
+}
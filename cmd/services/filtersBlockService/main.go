@@ -9,20 +9,26 @@ import (
 	filters "github.com/diadata-org/diadata/internal/pkg/filtersBlockService"
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
 	models "github.com/diadata-org/diadata/pkg/model"
-	"github.com/segmentio/kafka-go"
 	log "github.com/sirupsen/logrus"
 )
 
+// processedBlocksTTL is the time hashes of processed tradesBlocks are kept in the dedup store.
+const processedBlocksTTL = 24 * time.Hour
+
 var (
 	replayInflux          = flag.Bool("replayInflux", false, "replayInflux ?")
 	historical            = flag.Bool("historical", false, "digest historical or current trades")
 	testing               = flag.Bool("testing", false, "set true for testing environment")
 	replica               = flag.Bool("replica", false, "set true if tradesblocks should be fetched from and forwarded to replica topics.")
+	filtersConfigFile     = flag.String("filtersConfig", "", "json file in the config folder assigning filters to assets, such as filters/filters. Defaults to the built-in filters.")
+	consumerGroup         = flag.String("consumerGroup", "", "kafka consumer group for the tradesBlock topic. If set, offsets are committed once a tradesBlock is processed, so that restarts resume at the first unprocessed block.")
+	dedupStore            = flag.String("dedupStore", "", "store for hashes of processed tradesBlocks, either memory or redis. If set, tradesBlocks written more than once are processed only once.")
+	filtersConfig         *filters.FiltersConfig
 	filtersBlockTopic     int
 	tradesBlockTopic      int
 	filtersblockDoneTopic int
-	fbsDoneWriter         *kafka.Writer
 )
 
 func init() {
@@ -43,6 +49,14 @@ func init() {
 		filtersBlockTopic = kafkaHelper.TopicFiltersBlockReplica
 		tradesBlockTopic = kafkaHelper.TopicTradesBlockReplica
 	}
+	if *filtersConfigFile != "" {
+		var err error
+		filtersConfig, err = filters.LoadFiltersConfig(*filtersConfigFile)
+		if err != nil {
+			log.Fatal("load filters config: ", err)
+		}
+		log.Info("loaded filters config ", *filtersConfigFile)
+	}
 }
 
 func main() {
@@ -52,21 +66,24 @@ func main() {
 		if err != nil {
 			log.Errorln("NewDataStore", err)
 		}
-		f := filters.NewFiltersBlockService(nil, s, nil)
+		f := filters.NewFiltersBlockService(nil, s, nil, filtersConfig)
 		createTradeBlockFromInflux(s, f)
 	} else {
 		s, err := models.NewDataStore()
 		if err != nil {
 			log.Errorln("NewDataStore", err)
 		}
-		channel := make(chan *dia.FiltersBlock)
-
-		f := filters.NewFiltersBlockService(loadFilterPointsFromPreviousBlock(), s, channel)
+		// If tradesBlocks are committed or deduplicated, the filtersBlock of a tradesBlock is published before
+		// the tradesBlock is recorded as processed. Otherwise filtersBlocks are published by the handler.
+		synchronous := *consumerGroup != "" || *dedupStore != ""
+		// The filtersBlockService emits at most one filtersBlock per tradesBlock.
+		channel := make(chan *dia.FiltersBlock, 1)
 
-		w := kafkaHelper.NewSyncWriterWithCompression(filtersBlockTopic)
+		f := filters.NewFiltersBlockService(loadFilterPointsFromPreviousBlock(), s, channel, filtersConfig)
 
+		bus := messageBus.NewKafkaBus(kafkaHelper.NewSyncWriterWithCompression)
 		defer func() {
-			err := w.Close()
+			err := bus.Close()
 			if err != nil {
 				log.Error(err)
 			}
@@ -74,57 +91,88 @@ func main() {
 
 		wg := sync.WaitGroup{}
 
-		go handler(channel, &wg, w)
+		if !synchronous {
+			go handler(channel, &wg, bus)
+		}
 
-		r := kafkaHelper.NewReaderNextMessage(tradesBlockTopic)
-		defer func() {
-			err := r.Close()
-			if err != nil {
-				log.Error(err)
-			}
-		}()
+		// Hashes of processed tradesBlocks are shared by all instances consuming the same topic.
+		dedup, err := models.NewDedupStore(*dedupStore, "filtersBlockService_"+kafkaHelper.GetTopic(tradesBlockTopic), processedBlocksTTL)
+		if err != nil {
+			log.Fatal("init dedup store: ", err)
+		}
 
-		if *historical {
-			fbsDoneWriter = kafkaHelper.NewSyncWriter(filtersblockDoneTopic)
-			defer func() {
-				err := w.Close()
-				if err != nil {
-					log.Error(err)
-				}
-			}()
+		sub, err := bus.Subscribe(tradesBlockTopic, *consumerGroup)
+		if err != nil {
+			log.Fatal("subscribe to tradesBlocks: ", err)
 		}
 
 		for {
-			m, err := r.ReadMessage(context.Background())
+			m, err := sub.Next(context.Background())
 			if err != nil {
 				log.Printf(err.Error())
 			} else {
 				log.Info("get block from tradesBlock")
 				var tb dia.TradesBlock
-				err := tb.UnmarshalBinary(m.Value)
+				err := kafkaHelper.UnmarshalMessage(m.Value, &tb)
 				if err != nil {
 					log.Error("error unmarshalling trades block")
 				}
-				if err == nil {
+				if err == nil && processedBefore(dedup, tb.BlockHash) {
+					log.Info("skip tradesBlock processed before: ", tb.BlockHash)
+				} else if err == nil {
 					t0 := time.Now()
 					log.Info("number of trades in received tradesblock: ", len(tb.TradesBlockData.Trades))
-					f.ProcessTradesBlock(&tb)
+					if synchronous {
+						f.ProcessTradesBlockSync(&tb)
+						publishFiltersBlock(channel, bus)
+						recordProcessed(dedup, tb.BlockHash)
+					} else {
+						f.ProcessTradesBlock(&tb)
+					}
 					log.Info("time spent by filtersblockservice for processing tradesblock: ", time.Since(t0))
 					// In historical mode, send timestamp of last trade as soon as fbs is done.
 					if *historical {
 						lastTimestamp := tb.TradesBlockData.EndTime
-						err := kafkaHelper.WriteMessage(fbsDoneWriter, &lastTimestamp)
+						err := bus.Publish(filtersblockDoneTopic, &lastTimestamp)
 						if err != nil {
 							log.Error("kafka: fbs-done feedback: ", err)
 						}
 					}
 				}
+				if *consumerGroup != "" {
+					err = sub.Commit(context.Background(), m.Partition, m.Offset)
+					if err != nil {
+						log.Error("kafka: commit offset: ", err)
+					}
+				}
 			}
 		}
 	}
 }
 
-func handler(channel chan *dia.FiltersBlock, wg *sync.WaitGroup, w *kafka.Writer) {
+// processedBefore returns true if the tradesBlock with hash @blockHash was processed before according to @dedup.
+func processedBefore(dedup dia.DedupStore, blockHash string) bool {
+	if dedup == nil || blockHash == "" {
+		return false
+	}
+	seen, err := dedup.Seen(blockHash)
+	if err != nil {
+		log.Error("dedup store: ", err)
+	}
+	return seen
+}
+
+func recordProcessed(dedup dia.DedupStore, blockHash string) {
+	if dedup == nil || blockHash == "" {
+		return
+	}
+	err := dedup.Record(blockHash)
+	if err != nil {
+		log.Error("dedup store: ", err)
+	}
+}
+
+func handler(channel chan *dia.FiltersBlock, wg *sync.WaitGroup, bus messageBus.MessageBus) {
 	var block int
 	for {
 		filtersblock, ok := <-channel
@@ -135,13 +183,30 @@ func handler(channel chan *dia.FiltersBlock, wg *sync.WaitGroup, w *kafka.Writer
 		}
 		block++
 		log.Infoln("kafka: generated ", block, " blocks")
-		err := kafkaHelper.WriteMessage(w, filtersblock)
+		err := bus.Publish(filtersBlockTopic, filtersblock)
 		if err != nil {
 			log.Errorln("kafka: handleBlocks", err)
 		}
 	}
 }
 
+// publishFiltersBlock publishes the filtersBlock emitted for the last processed tradesBlock, if any.
+// Publishing is retried until it succeeds, such that the tradesBlock is not recorded as processed before.
+func publishFiltersBlock(channel chan *dia.FiltersBlock, bus messageBus.MessageBus) {
+	select {
+	case filtersblock := <-channel:
+		for {
+			err := bus.Publish(filtersBlockTopic, filtersblock)
+			if err == nil {
+				return
+			}
+			log.Errorln("kafka: publish filtersBlock", err)
+			time.Sleep(time.Second)
+		}
+	default:
+	}
+}
+
 func loadFilterPointsFromPreviousBlock() []dia.FilterPoint {
 	// load the previous block points so that we have a value even if
 	// there is no trades
@@ -0,0 +1,9 @@
+module github.com/diadata-org/diadata/services/filtersReplay
+
+go 1.17
+
+require (
+	github.com/diadata-org/diadata v1.4.152
+	github.com/mattn/go-sqlite3 v1.11.0
+	github.com/sirupsen/logrus v1.8.1
+)
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	filters "github.com/diadata-org/diadata/internal/pkg/filtersBlockService"
+	"github.com/diadata-org/diadata/internal/pkg/tradesBlockService"
+	"github.com/diadata-org/diadata/pkg/dia"
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/diadata-org/diadata/pkg/model/embedded"
+	"github.com/diadata-org/diadata/pkg/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// The filtersReplay tool re-runs the trades->filters pipeline on stored trades.
+// Trades are read from influx or from a json file, assembled into tradesBlocks as done by the
+// tradesBlockService and processed by a FiltersBlockService. All resulting filter values are
+// written to a csv file or to a separate influx measurement, such that they can be compared
+// with the values in production.
+//
+// Example:
+// filtersReplay -starttime=1672531200 -endtime=1672617600 -assets=Ethereum-0x0000000000000000000000000000000000000000 -output=csv -outputFile=replay.csv
+
+var (
+	starttimeFlag     = flag.String("starttime", "", "unix timestamp of the first trade. Defaults to 24h before endtime.")
+	endtimeFlag       = flag.String("endtime", "", "unix timestamp of the end of the replayed range. Defaults to now.")
+	assetsFlag        = flag.String("assets", "", "comma separated list of assets as Blockchain-Address. All assets are replayed if empty.")
+	tradesFile        = flag.String("tradesFile", "", "json file with a list of trades. If empty, trades are read from influx.")
+	tradesTable       = flag.String("tradesTable", "trades", "influx measurement trades are read from.")
+	queryWindow       = flag.Duration("queryWindow", time.Hour, "time range of a single influx query for trades.")
+	output            = flag.String("output", "csv", "output of the filter values: csv or influx.")
+	outputFile        = flag.String("outputFile", "filtersReplay.csv", "csv file filter values are written to.")
+	outputMeasurement = flag.String("outputMeasurement", "filtersReplay", "influx measurement filter values are written to.")
+	filtersConfigFile = flag.String("filtersConfig", "", "json file in the config folder assigning filters to assets. Defaults to the built-in filters.")
+	exchangeRegistry  = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from sqliteDB or postgres.")
+	sqliteDB          = flag.String("sqliteDB", "", "sqlite database of an all-in-one deployment to load exchanges and blockchains from, such as data/reldb.sqlite.")
+	stablecoinsFile   = flag.String("stablecoins", models.StablecoinsFile, "json file in the config folder with the stablecoin pegs.")
+	allowedLateness   = flag.Int("allowedLateness", 0, "seconds a tradesBlock is kept open for late trades after its end.")
+	correctionWindow  = flag.Int("correctionWindow", 0, "seconds a finalised tradesBlock can be corrected by late trades.")
+	depegMode         = flag.Bool("depegMode", false, "keep and flag stablecoin trades deviating from their peg instead of discarding them.")
+)
+
+func main() {
+	flag.Parse()
+
+	starttime, endtime, err := utils.MakeTimerange(*starttimeFlag, *endtimeFlag, 24*time.Hour)
+	if err != nil {
+		log.Fatal("parse time range: ", err)
+	}
+
+	var filtersConfig *filters.FiltersConfig
+	if *filtersConfigFile != "" {
+		filtersConfig, err = filters.LoadFiltersConfig(*filtersConfigFile)
+		if err != nil {
+			log.Fatal("load filters config: ", err)
+		}
+	}
+
+	registry, err := initExchangeRegistry()
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
+
+	// Influx is only needed if trades are read from or filters are written to influx.
+	var datastore *models.DB
+	if *tradesFile == "" || *output == "influx" {
+		datastore, err = models.NewInfluxDataStore()
+		if err != nil {
+			log.Fatal("NewInfluxDataStore: ", err)
+		}
+	}
+
+	pegs, err := models.GetStablecoinPegsFromConfig(*stablecoinsFile)
+	if err != nil {
+		log.Fatal("load stablecoin pegs: ", err)
+	}
+	// Prices of non-USD pegs are only available if influx is used.
+	var pegPrice dia.PegPriceFunc
+	if datastore != nil {
+		pegPrice = models.PegPrice(datastore, true)
+	}
+	stablecoins := dia.NewStablecoinChecker(pegs, tradesBlockService.DefaultStablecoinTolerance, *depegMode, pegPrice)
+
+	var writer filterWriter
+	switch *output {
+	case "csv":
+		writer, err = newCSVFilterWriter(*outputFile)
+		if err != nil {
+			log.Fatal("create csv file: ", err)
+		}
+	case "influx":
+		writer = newInfluxFilterWriter(datastore, *outputMeasurement)
+	default:
+		log.Fatalf("unknown output %s", *output)
+	}
+
+	var trades []dia.Trade
+	if *tradesFile != "" {
+		trades, err = readTradesFromFile(*tradesFile, starttime, endtime)
+	} else {
+		trades, err = readTradesFromInflux(datastore, *tradesTable, starttime, endtime, *queryWindow)
+	}
+	if err != nil {
+		log.Fatal("read trades: ", err)
+	}
+	trades = filterTradesByAssets(trades, parseAssets(*assetsFlag))
+	log.Infof("replaying %d trades in time range %v -- %v", len(trades), starttime, endtime)
+
+	ds := &replayDatastore{Datastore: models.NewMemoryDataStore(), writer: writer}
+	fbs := filters.NewFiltersBlockService(nil, ds, nil, filtersConfig)
+	builder := tradesBlockService.NewTradesBlockBuilder(
+		dia.BlockSizeSeconds,
+		time.Duration(*allowedLateness)*time.Second,
+		time.Duration(*correctionWindow)*time.Second,
+		registry.IsCentralized,
+		nil,
+	)
+
+	var numBlocks int
+	for _, trade := range trades {
+		if !tradesBlockService.ValidForBlock(&trade, stablecoins) {
+			continue
+		}
+		for _, tb := range builder.AddTrade(trade) {
+			fbs.ProcessTradesBlock(tb)
+			numBlocks++
+		}
+	}
+	for _, tb := range builder.Finalise() {
+		fbs.ProcessTradesBlock(tb)
+		numBlocks++
+	}
+
+	// Close waits for the last tradesBlock to be processed.
+	err = fbs.Close()
+	if err != nil {
+		log.Error("close filtersBlockService: ", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		log.Fatal("close output: ", err)
+	}
+	log.Infof("replayed %d tradesBlocks", numBlocks)
+}
+
+// initExchangeRegistry returns the registry from the registry file, from the sqlite database of an
+// all-in-one deployment or from postgres, in this order.
+func initExchangeRegistry() (*scrapers.ExchangeRegistry, error) {
+	if *exchangeRegistry != "" || *sqliteDB == "" {
+		return scrapers.InitExchangeRegistry(*exchangeRegistry)
+	}
+	// NewSQLiteRelDataStore creates missing databases, which would result in an empty registry.
+	if _, err := os.Stat(*sqliteDB); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("sqlite database " + *sqliteDB + " does not exist")
+		}
+		return nil, err
+	}
+	relDB, err := embedded.NewSQLiteRelDataStore(*sqliteDB)
+	if err != nil {
+		return nil, err
+	}
+	defer relDB.Close()
+	return scrapers.LoadExchangeRegistry(relDB)
+}
+
+// readTradesFromInflux returns all verified trades from @table in [@starttime, @endtime),
+// queried in consecutive windows of size @window.
+func readTradesFromInflux(datastore *models.DB, table string, starttime time.Time, endtime time.Time, window time.Duration) ([]dia.Trade, error) {
+	var trades []dia.Trade
+	for timeInit := starttime; timeInit.Before(endtime); timeInit = timeInit.Add(window) {
+		timeFinal := timeInit.Add(window)
+		if timeFinal.After(endtime) {
+			timeFinal = endtime
+		}
+		windowTrades, err := datastore.GetOldTradesFromInflux(table, "", true, timeInit, timeFinal)
+		if err != nil {
+			if err.Error() == "no trades in time range" {
+				continue
+			}
+			return trades, err
+		}
+		for i := range windowTrades {
+			windowTrades[i].QuoteToken.Symbol = windowTrades[i].Symbol
+		}
+		trades = append(trades, windowTrades...)
+		log.Infof("got %d trades in time range %v -- %v", len(windowTrades), timeInit, timeFinal)
+	}
+	return trades, nil
+}
+
+// readTradesFromFile returns the trades in [@starttime, @endtime) from the json file @filename, sorted by time.
+func readTradesFromFile(filename string, starttime time.Time, endtime time.Time) ([]dia.Trade, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return []dia.Trade{}, err
+	}
+	var allTrades []dia.Trade
+	err = json.Unmarshal(content, &allTrades)
+	if err != nil {
+		return []dia.Trade{}, err
+	}
+	var trades []dia.Trade
+	for _, trade := range allTrades {
+		if !trade.Time.Before(starttime) && trade.Time.Before(endtime) {
+			trades = append(trades, trade)
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.Before(trades[j].Time)
+	})
+	return trades, nil
+}
+
+// parseAssets parses a comma separated list of Blockchain-Address identifiers.
+func parseAssets(assetsString string) map[string]struct{} {
+	assets := make(map[string]struct{})
+	if assetsString == "" {
+		return assets
+	}
+	for _, identifier := range strings.Split(assetsString, ",") {
+		assets[strings.TrimSpace(identifier)] = struct{}{}
+	}
+	return assets
+}
+
+// filterTradesByAssets returns all trades with quote token in @assets. If @assets is empty, all trades are returned.
+func filterTradesByAssets(trades []dia.Trade, assets map[string]struct{}) []dia.Trade {
+	if len(assets) == 0 {
+		return trades
+	}
+	var filteredTrades []dia.Trade
+	for _, trade := range trades {
+		if _, ok := assets[trade.QuoteToken.Blockchain+"-"+trade.QuoteToken.Address]; ok {
+			filteredTrades = append(filteredTrades, trade)
+		}
+	}
+	return filteredTrades
+}
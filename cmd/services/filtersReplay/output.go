@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+// filterWriter is the output of replayed filter values.
+type filterWriter interface {
+	Write(filterName string, asset dia.Asset, exchange string, value float64, t time.Time) error
+	Flush() error
+	Close() error
+}
+
+// replayDatastore is handed to the FiltersBlockService instead of the production datastore.
+// Filter values are redirected to a filterWriter, all other writes are discarded such that
+// production quotations and caches remain untouched.
+// All remaining methods are served by an empty in-memory datastore.
+type replayDatastore struct {
+	models.Datastore
+	writer filterWriter
+}
+
+func (ds *replayDatastore) SetFilter(filterName string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return ds.writer.Write(filterName, asset, exchange, value, t)
+}
+
+func (ds *replayDatastore) SetRejectedSources(filterName string, asset dia.Asset, exchange string, sources []string, t time.Time) error {
+	return nil
+}
+
+func (ds *replayDatastore) SetAssetPriceUSD(asset dia.Asset, price float64, timestamp time.Time) error {
+	return nil
+}
+
+func (ds *replayDatastore) SetLastTradeTimeForExchange(asset dia.Asset, exchange string, t time.Time) error {
+	return nil
+}
+
+func (ds *replayDatastore) ExecuteRedisPipe() error {
+	return nil
+}
+
+func (ds *replayDatastore) FlushRedisPipe() error {
+	return nil
+}
+
+func (ds *replayDatastore) Flush() error {
+	return ds.writer.Flush()
+}
+
+// csvFilterWriter writes filter values as rows of a csv file.
+type csvFilterWriter struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newCSVFilterWriter(filename string) (*csvFilterWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	writer := csv.NewWriter(file)
+	err = writer.Write([]string{"time", "filter", "exchange", "blockchain", "address", "symbol", "value"})
+	if err != nil {
+		return nil, err
+	}
+	return &csvFilterWriter{file: file, writer: writer}, nil
+}
+
+func (w *csvFilterWriter) Write(filterName string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return w.writer.Write([]string{
+		t.UTC().Format(time.RFC3339),
+		filterName,
+		exchange,
+		asset.Blockchain,
+		asset.Address,
+		asset.Symbol,
+		strconv.FormatFloat(value, 'f', -1, 64),
+	})
+}
+
+func (w *csvFilterWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvFilterWriter) Close() error {
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+	return w.file.Close()
+}
+
+// influxFilterWriter writes filter values into a separate influx measurement.
+type influxFilterWriter struct {
+	datastore   *models.DB
+	measurement string
+}
+
+func newInfluxFilterWriter(datastore *models.DB, measurement string) *influxFilterWriter {
+	return &influxFilterWriter{datastore: datastore, measurement: measurement}
+}
+
+func (w *influxFilterWriter) Write(filterName string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return w.datastore.SaveFilterInfluxToTable(filterName, asset, exchange, value, t, w.measurement)
+}
+
+func (w *influxFilterWriter) Flush() error {
+	return w.datastore.Flush()
+}
+
+func (w *influxFilterWriter) Close() error {
+	return w.datastore.Flush()
+}
@@ -17,10 +17,12 @@ import (
 )
 
 var (
-	log      *logrus.Logger
-	exchange string
-	exch     = flag.String("exchange", "", "which exchange")
-	mode     = flag.String("mode", "verification", "verification or remoteFetch: fetching pairs from exchange's API.")
+	log              *logrus.Logger
+	exchange         string
+	exch             = flag.String("exchange", "", "which exchange")
+	mode             = flag.String("mode", "verification", "verification or remoteFetch: fetching pairs from exchange's API.")
+	exchangeRegistry = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from postgres.")
+	registry         *scrapers.ExchangeRegistry
 )
 
 func init() {
@@ -28,7 +30,12 @@ func init() {
 	flag.Parse()
 
 	exchange = *exch
-	exchangeStruct, ok := scrapers.Exchanges[exchange]
+	var err error
+	registry, err = scrapers.InitExchangeRegistry(*exchangeRegistry)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
+	exchangeStruct, ok := registry.Exchanges[exchange]
 	if (!exchangeStruct.Centralized || !ok) && *mode == "verification" {
 		log.Warnf("%s cannot be found in the list of centralized exchanges.", exchange)
 		exchangeStruct, ok := scrapers.ExchangeDuplicates[exchange]
@@ -175,11 +182,11 @@ func fetchFromExchangeAndStore(relDB *models.RelDB) error {
 	// Set up scraper.
 	config, err := dia.GetConfig(exchange)
 	if err == nil {
-		scraper = scrapers.NewAPIScraper(exchange, false, config.ApiKey, config.SecretKey, relDB)
+		scraper = scrapers.NewAPIScraper(exchange, false, config.ApiKey, config.SecretKey, relDB, registry)
 	} else {
 		log.Info("No valid API config for exchange: ", exchange, " Error: ", err.Error())
 		log.Info("Proceeding with no API secrets")
-		scraper = scrapers.NewAPIScraper(exchange, false, "", "", relDB)
+		scraper = scrapers.NewAPIScraper(exchange, false, "", "", relDB, registry)
 	}
 
 	// Fetch pairs from exchange's API.
@@ -3,17 +3,22 @@ package main
 import (
 	"context"
 	"flag"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/diadata-org/diadata/internal/pkg/tradesBlockService"
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
 	models "github.com/diadata-org/diadata/pkg/model"
-	"github.com/segmentio/kafka-go"
 	log "github.com/sirupsen/logrus"
 )
 
-func handleBlocks(blockMaker *tradesBlockService.TradesBlockService, wg *sync.WaitGroup, w *kafka.Writer) {
+// handleBlocks publishes tradesBlocks to @bus. If @sub belongs to a consumer group, the offset of the
+// trades topic up to which all trades are contained in published blocks is committed after each block.
+func handleBlocks(blockMaker *tradesBlockService.TradesBlockService, wg *sync.WaitGroup, bus messageBus.MessageBus, sub messageBus.Subscription) {
 	for {
 		t, ok := <-blockMaker.Channel()
 		if !ok {
@@ -21,9 +26,30 @@ func handleBlocks(blockMaker *tradesBlockService.TradesBlockService, wg *sync.Wa
 			wg.Done()
 			return
 		}
-		err := kafkaHelper.WriteMessage(w, t)
+		err := bus.Publish(tradesBlockTopic, t)
 		if err != nil {
 			log.Errorln("handleBlocks", err)
+			continue
+		}
+		if *consumerGroup == "" {
+			continue
+		}
+		for partition, offset := range blockMaker.CommittableOffsets(t.BlockHash) {
+			err = sub.Commit(context.Background(), partition, offset)
+			if err != nil {
+				log.Errorln("handleBlocks: commit offset", err)
+			}
+		}
+	}
+}
+
+// handleDepegEvents forwards depeg events of stablecoins to @bus.
+func handleDepegEvents(stablecoins *dia.StablecoinChecker, bus messageBus.MessageBus) {
+	for event := range stablecoins.Events() {
+		event := event
+		err := bus.Publish(kafkaHelper.TopicDepegEvents, &event)
+		if err != nil {
+			log.Errorln("handleDepegEvents", err)
 		}
 	}
 }
@@ -48,6 +74,18 @@ var (
 	historical       = flag.Bool("historical", false, "digest current or historical trades.")
 	testing          = flag.Bool("testing", false, "set true for testing environment.")
 	replica          = flag.Bool("replica", false, "set true if trades should be fetched from and forwarded to replica topics.")
+	exchangeRegistry = flag.String("exchangeRegistry", "", "json or yaml file with exchanges and blockchains. If empty, they are loaded from postgres.")
+	assetBridges     = flag.String("assetBridges", "", "json file in the config folder with the asset bridging table. If empty, it is loaded from postgres.")
+	stablecoinsFile  = flag.String("stablecoins", models.StablecoinsFile, "json file in the config folder with the stablecoin pegs.")
+	depegMode        = flag.Bool("depegMode", false, "keep and flag stablecoin trades deviating from their peg instead of discarding them.")
+	referenceSource  = flag.String("referenceSource", "", "foreign quotation source such as Coingecko to check trade prices against. If empty, no check is done.")
+	referenceFile    = flag.String("referenceAssets", models.ReferenceAssetsFile, "json file in the config folder mapping blockchain and address of the checked assets onto their foreign quotations.")
+	referenceDev     = flag.Float64("referenceDeviation", tradesBlockService.DefaultReferenceDeviation, "maximal relative deviation of trade prices from the foreign quotation.")
+	referenceTagOnly = flag.Bool("referenceTagOnly", false, "keep and flag trades deviating from the foreign quotation instead of discarding them.")
+	consumerGroup    = flag.String("consumerGroup", "", "kafka consumer group for the trades topic. If set, offsets are committed once tradesBlocks are written, so that restarts resume at the first unprocessed trade.")
+	dedupStore       = flag.String("dedupStore", "", "store for duplicate trades of centralized exchanges, either memory or redis. If empty, duplicates are identified within a block.")
+	dedupTTL         = flag.Int("dedupTTL", 600, "seconds trades are kept in the dedup store.")
+	metricsAddr      = flag.String("metricsAddr", "", "address such as :9090 serving the reference check counters per exchange under /debug/vars. If empty, they are not served.")
 	tradesBlockTopic int
 	tradesTopic      int
 )
@@ -57,43 +95,97 @@ func main() {
 		log.Info("run tradesblock service in historical mode")
 	}
 
-	kafkaWriter := kafkaHelper.NewSyncWriterWithCompression(tradesBlockTopic)
+	bus := messageBus.NewKafkaBus(kafkaHelper.NewSyncWriterWithCompression)
 	defer func() {
-		err := kafkaWriter.Close()
+		err := bus.Close()
 		if err != nil {
 			log.Error(err)
 		}
 	}()
 
-	kafkaReader := kafkaHelper.NewReaderNextMessage(tradesTopic)
-	defer func() {
-		err := kafkaReader.Close()
-		if err != nil {
-			log.Error(err)
-		}
-	}()
+	sub, err := bus.Subscribe(tradesTopic, *consumerGroup)
+	if err != nil {
+		log.Fatal("subscribe to trades: ", err)
+	}
 
 	s, err := models.NewDataStore()
 	if err != nil {
 		log.Errorln("NewDataStore", err)
 	}
 
-	service := tradesBlockService.NewTradesBlockService(s, dia.BlockSizeSeconds, *historical)
+	registry, err := scrapers.InitExchangeRegistry(*exchangeRegistry)
+	if err != nil {
+		log.Fatal("init exchange registry: ", err)
+	}
+
+	bridges, err := models.InitAssetBridges(*assetBridges)
+	if err != nil {
+		log.Fatal("init asset bridges: ", err)
+	}
+	// The API serves the bridging table actually used by the service.
+	err = s.SetActiveAssetBridges(bridges.List())
+	if err != nil {
+		log.Error("store active asset bridges: ", err)
+	}
+
+	stablecoins, err := models.NewStablecoinChecker(*stablecoinsFile, bridges, tradesBlockService.DefaultStablecoinTolerance, *depegMode, s, *historical)
+	if err != nil {
+		log.Fatal("init stablecoin checker: ", err)
+	}
+	if *depegMode {
+		go handleDepegEvents(stablecoins, bus)
+	}
+
+	var referenceAssets []models.ReferenceAsset
+	if *referenceSource != "" {
+		referenceAssets, err = models.GetReferenceAssetsFromConfig(*referenceFile)
+		if err != nil {
+			log.Fatal("load reference assets: ", err)
+		}
+	}
+	references := tradesBlockService.NewReferencePriceChecker(s, *referenceSource, referenceAssets, *referenceDev, *referenceTagOnly)
+	if *metricsAddr != "" {
+		go func() {
+			log.Error("serve metrics: ", http.ListenAndServe(*metricsAddr, nil))
+		}()
+	}
+
+	// Instances consuming the same topic share the dedup store in redis.
+	dedup, err := models.NewDedupStore(*dedupStore, "tradesBlockService_"+kafkaHelper.GetTopic(tradesTopic), time.Duration(*dedupTTL)*time.Second)
+	if err != nil {
+		log.Fatal("init dedup store: ", err)
+	}
+
+	service := tradesBlockService.NewTradesBlockService(tradesBlockService.Options{
+		Datastore:     s,
+		BlockDuration: dia.BlockSizeSeconds,
+		Historical:    *historical,
+		Registry:      registry,
+		Bridges:       bridges,
+		Stablecoins:   stablecoins,
+		References:    references,
+		Dedup:         dedup,
+	})
 
 	wg := sync.WaitGroup{}
-	go handleBlocks(service, &wg, kafkaWriter)
+	go handleBlocks(service, &wg, bus, sub)
 
 	log.Printf("starting...")
 
 	for {
-		m, err := kafkaReader.ReadMessage(context.Background())
+		// Offsets of consumer groups are committed in handleBlocks.
+		m, err := sub.Next(context.Background())
 		if err != nil {
 			log.Printf(err.Error())
 		} else {
 			var t dia.Trade
-			err := t.UnmarshalBinary(m.Value)
+			err := kafkaHelper.UnmarshalMessage(m.Value, &t)
 			if err == nil {
-				service.ProcessTrade(&t)
+				if *consumerGroup != "" {
+					service.ProcessTradeAt(&t, m.Partition, m.Offset)
+				} else {
+					service.ProcessTrade(&t)
+				}
 			} else {
 				log.Printf("ignored message at offset %d: %s = %s\n", m.Offset, string(m.Key), string(m.Value))
 			}
@@ -7,6 +7,7 @@ import (
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
 	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/diadata-org/diadata/pkg/utils"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,7 +26,29 @@ func main() {
 		log.Errorln("NewDataStore", err)
 	}
 
-	service := tradesEstimationService.NewTradesEstimationService(s)
+	bridges, err := models.InitAssetBridges(utils.Getenv("ASSET_BRIDGES_FILE", ""))
+	if err != nil {
+		log.Fatal("init asset bridges: ", err)
+	}
+
+	stablecoins, err := models.NewStablecoinChecker(
+		utils.Getenv("STABLECOINS_FILE", models.StablecoinsFile),
+		bridges,
+		tradesEstimationService.DefaultStablecoinTolerance,
+		utils.Getenv("DEPEG_MODE", "false") == "true",
+		s,
+		true,
+	)
+	if err != nil {
+		log.Fatal("init stablecoin checker: ", err)
+	}
+	go func() {
+		for event := range stablecoins.Events() {
+			log.Warnf("stablecoin %s depegged from %s: price %v, deviation %v on %s at %v", event.Asset.Symbol, event.Peg, event.Price, event.Deviation, event.Source, event.Time)
+		}
+	}()
+
+	service := tradesEstimationService.NewTradesEstimationService(s, bridges, stablecoins)
 
 	log.Printf("starting...")
 
@@ -35,7 +58,7 @@ func main() {
 			log.Printf(err.Error())
 		} else {
 			var t dia.Trade
-			err := t.UnmarshalBinary(m.Value)
+			err := kafkaHelper.UnmarshalMessage(m.Value, &t)
 			if err == nil {
 				service.ProcessTrade(&t)
 			} else {
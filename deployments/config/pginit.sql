@@ -225,6 +225,21 @@ CREATE TABLE assetpriceident (
     UNIQUE(group_id, rank_in_group)
 );
 
+-- assetbridge maps wrapped or bridged assets onto the asset whose price is used for them.
+-- If exchanges is not empty, the bridge only applies to trades from these exchanges.
+-- exchanges is stored in alphabetical order, such that a source asset has one bridge per set of exchanges.
+CREATE TABLE assetbridge (
+    assetbridge_id UUID DEFAULT gen_random_uuid(),
+    source_address text NOT NULL,
+    source_blockchain text NOT NULL,
+    destination_symbol text NOT NULL,
+    destination_address text NOT NULL,
+    destination_blockchain text NOT NULL,
+    exchanges text[] NOT NULL DEFAULT '{}',
+    UNIQUE(source_address, source_blockchain, exchanges),
+    UNIQUE(assetbridge_id)
+);
+
 CREATE TABLE synthassetdata (
     synthassetdata_id UUID DEFAULT gen_random_uuid(),
     synthasset_id UUID REFERENCES asset(asset_id),
@@ -285,3 +300,27 @@ CREATE TABLE feederresource (
 
 
 
+
+-- apikey holds the API keys of the REST server. Only the sha256 hash of a key is stored.
+-- rate_limit is in requests per minute, daily_quota in requests per day, where 0 means no limit.
+CREATE TABLE apikey (
+    apikey_id UUID DEFAULT gen_random_uuid(),
+    key_hash text NOT NULL,
+    key_prefix text NOT NULL,
+    owner text NOT NULL,
+    scopes text[] NOT NULL,
+    rate_limit integer NOT NULL DEFAULT 0,
+    daily_quota bigint NOT NULL DEFAULT 0,
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp NOT NULL DEFAULT now(),
+    valid_until timestamp,
+    UNIQUE(apikey_id),
+    UNIQUE(key_hash)
+);
+
+CREATE TABLE apikeyusage (
+    apikey_id UUID REFERENCES apikey(apikey_id),
+    day date NOT NULL,
+    requests bigint NOT NULL DEFAULT 0,
+    UNIQUE(apikey_id, day)
+);
@@ -11,11 +11,48 @@ import (
 )
 
 // Filter interface defines a filter's methods processing trades from the tradesBlockService.
+// Compute is called for each trade of a tradesBlock, FinalCompute once at the end of the block.
+// FilterPointForBlock returns the resulting point, or nil if the filter doesn't emit points.
+// Save persists the filter's value under its own name in the filters measurement.
 type Filter interface {
-	compute(trade dia.Trade)
-	finalCompute(t time.Time) float64
-	filterPointForBlock() *dia.FilterPoint
-	save(ds models.Datastore) error
+	Compute(trade dia.Trade)
+	FinalCompute(t time.Time) float64
+	FilterPointForBlock() *dia.FilterPoint
+	Save(ds models.Datastore) error
+}
+
+// seedable is implemented by moving averages, which carry the last trade and the value of a block
+// over to the next block. A new filter seeded with this state computes a block as if it had processed
+// all previous blocks.
+type seedable interface {
+	carriedTrade() dia.Trade
+	seed(lastTrade dia.Trade, value float64)
+}
+
+// OutlierConfig configures the outlier rejection of filters implementing OutlierConfigurer.
+// @IQRScale is the scale of the interquartile range used for rejection of single trades.
+// If @MaxSourceDeviation is positive, a price is first built for each source (exchange) and
+// all trades of sources whose median price deviates from the median across sources by more
+// than the relative bound @MaxSourceDeviation are rejected, e.g. 0.05 for 5%.
+type OutlierConfig struct {
+	IQRScale           float64 `json:"IQRScale"`
+	MaxSourceDeviation float64 `json:"MaxSourceDeviation"`
+}
+
+// OutlierConfigurer is implemented by filters with configurable outlier rejection.
+type OutlierConfigurer interface {
+	SetOutlierConfig(config OutlierConfig)
+}
+
+const (
+	defaultIQRScale = float64(1.5)
+	// minSourcesForRejection is the minimal number of sources needed for a meaningful cross-source median.
+	minSourcesForRejection = 3
+)
+
+// DefaultOutlierConfig returns the outlier rejection used if filters are not configured otherwise.
+func DefaultOutlierConfig() OutlierConfig {
+	return OutlierConfig{IQRScale: defaultIQRScale}
 }
 
 func RemoveOutliers(samples []float64, scale float64) ([]float64, []int) {
@@ -23,7 +60,7 @@ func RemoveOutliers(samples []float64, scale float64) ([]float64, []int) {
 }
 
 func removeOutliers(samples []float64) ([]float64, []int) {
-	return removeOutliersScaled(samples, float64(1.5))
+	return removeOutliersScaled(samples, defaultIQRScale)
 }
 
 // RemoveOutliersScaled Cleans a data set it accordance to the acceptable range within interquartile range.
@@ -52,6 +89,62 @@ func removeOutliersScaled(samples []float64, scale float64) ([]float64, []int) {
 	return samples[lowerIndex:upperIndex], indexBounds
 }
 
+// rejectOutlierSources groups @prices by @sources and returns the sorted list of sources whose median
+// price deviates from the median across all sources' medians by more than @maxDeviation (relative).
+// Nothing is rejected if less than minSourcesForRejection sources are present.
+func rejectOutlierSources(prices []float64, sources []string, maxDeviation float64) (rejected []string) {
+	if maxDeviation <= 0 || len(prices) != len(sources) {
+		return
+	}
+	pricesBySource := make(map[string][]float64)
+	for i, source := range sources {
+		pricesBySource[source] = append(pricesBySource[source], prices[i])
+	}
+	if len(pricesBySource) < minSourcesForRejection {
+		return
+	}
+
+	sourceMedians := make(map[string]float64)
+	var medians []float64
+	for source, sourcePrices := range pricesBySource {
+		median := computeMedian(sourcePrices)
+		sourceMedians[source] = median
+		medians = append(medians, median)
+	}
+	crossSourceMedian := computeMedian(medians)
+	if crossSourceMedian == 0 {
+		return
+	}
+
+	for source, median := range sourceMedians {
+		if math.Abs(median-crossSourceMedian)/math.Abs(crossSourceMedian) > maxDeviation {
+			rejected = append(rejected, source)
+		}
+	}
+	sort.Strings(rejected)
+	return
+}
+
+// removeSources returns copies of @prices and @volumes without the entries whose source is in @rejected.
+func removeSources(prices []float64, volumes []float64, sources []string, rejected []string) ([]float64, []float64) {
+	if len(rejected) == 0 {
+		return append([]float64{}, prices...), append([]float64{}, volumes...)
+	}
+	rejectedMap := make(map[string]struct{})
+	for _, source := range rejected {
+		rejectedMap[source] = struct{}{}
+	}
+	var cleanPrices, cleanVolumes []float64
+	for i := range prices {
+		if _, ok := rejectedMap[sources[i]]; ok {
+			continue
+		}
+		cleanPrices = append(cleanPrices, prices[i])
+		cleanVolumes = append(cleanVolumes, volumes[i])
+	}
+	return cleanPrices, cleanVolumes
+}
+
 // computeMean returns the weighted mean of @samples with @weights.
 // Special case of non-weighted mean is obtained by setting weights to constant 1-slice.
 func computeMean(samples []float64, weights []float64) (mean float64, err error) {
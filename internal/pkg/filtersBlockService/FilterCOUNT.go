@@ -31,29 +31,21 @@ func NewFilterCOUNT(asset dia.Asset, exchange string, memory int) *FilterCOUNT {
 	return filter
 }
 
+// Compute processes @trade.
 func (filter *FilterCOUNT) Compute(trade dia.Trade) {
-	filter.compute(trade)
-}
-func (filter *FilterCOUNT) FinalCompute(t time.Time) {
-	filter.finalCompute(t)
-}
-
-func (filter *FilterCOUNT) compute(trade dia.Trade) {
 	filter.modified = true
 	filter.numTrades += 1
 	filter.currentTime = trade.Time
 }
 
-func (filter *FilterCOUNT) finalCompute(t time.Time) float64 {
+// FinalCompute returns the value aggregated since the last call and resets the filter.
+func (filter *FilterCOUNT) FinalCompute(t time.Time) float64 {
 	filter.value = filter.numTrades
 	filter.numTrades = int64(0)
 	return float64(filter.value)
 }
 
-func (filter *FilterCOUNT) filterPointForBlock() *dia.FilterPoint {
-	return nil
-}
-
+// FilterPointForBlock returns the filter point of the last final computation.
 func (filter *FilterCOUNT) FilterPointForBlock() *dia.FilterPoint {
 	return &dia.FilterPoint{
 		Asset: filter.asset,
@@ -63,7 +55,8 @@ func (filter *FilterCOUNT) FilterPointForBlock() *dia.FilterPoint {
 	}
 }
 
-func (filter *FilterCOUNT) save(ds models.Datastore) error {
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterCOUNT) Save(ds models.Datastore) error {
 	if filter.modified {
 		filter.modified = false
 		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, float64(filter.value), filter.currentTime)
@@ -43,11 +43,8 @@ func NewFilterMA(asset dia.Asset, exchange string, currentTime time.Time, memory
 	return filter
 }
 
+// Compute processes @trade.
 func (filter *FilterMA) Compute(trade dia.Trade) {
-	filter.compute(trade)
-}
-
-func (filter *FilterMA) compute(trade dia.Trade) {
 	filter.modified = true
 	if filter.lastTrade != (dia.Trade{}) {
 		if trade.Time.Before(filter.currentTime) {
@@ -92,11 +89,8 @@ func (filter *FilterMA) processDataPoint(trade dia.Trade) {
 	filter.volumes = append([]float64{trade.Volume}, filter.volumes...)
 }
 
+// FinalCompute computes the moving average of all trades processed since the last call.
 func (filter *FilterMA) FinalCompute(t time.Time) float64 {
-	return filter.finalCompute(t)
-}
-
-func (filter *FilterMA) finalCompute(t time.Time) float64 {
 	if filter.lastTrade == (dia.Trade{}) {
 		return 0.0
 	}
@@ -124,19 +118,25 @@ func (filter *FilterMA) finalCompute(t time.Time) float64 {
 	return filter.value
 }
 
-func (filter *FilterMA) FilterPointForBlock() *dia.FilterPoint {
-	return &dia.FilterPoint{
-		Asset: filter.asset,
-		Value: filter.value,
-		Name:  "MA" + strconv.Itoa(filter.memory),
-		Time:  filter.currentTime,
-	}
+// carriedTrade returns the last trade carried over to the next block.
+func (filter *FilterMA) carriedTrade() dia.Trade {
+	return filter.lastTrade
 }
 
-func (filter *FilterMA) filterPointForBlock() *dia.FilterPoint {
-	if filter.exchange != "" || filter.filterName != dia.FilterKing {
-		return nil
+// seed sets the state of the filter to the state after a final computation with last trade @lastTrade and @value.
+func (filter *FilterMA) seed(lastTrade dia.Trade, value float64) {
+	filter.value = value
+	if lastTrade == (dia.Trade{}) {
+		return
 	}
+	filter.lastTrade = lastTrade
+	filter.currentTime = lastTrade.Time
+	filter.prices = []float64{lastTrade.EstimatedUSDPrice}
+	filter.volumes = []float64{lastTrade.Volume}
+}
+
+// FilterPointForBlock returns the filter point of the last final computation.
+func (filter *FilterMA) FilterPointForBlock() *dia.FilterPoint {
 	return &dia.FilterPoint{
 		Asset: filter.asset,
 		Value: filter.value,
@@ -145,7 +145,8 @@ func (filter *FilterMA) filterPointForBlock() *dia.FilterPoint {
 	}
 }
 
-func (filter *FilterMA) save(ds models.Datastore) error {
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterMA) Save(ds models.Datastore) error {
 	if filter.modified {
 		filter.modified = false
 		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
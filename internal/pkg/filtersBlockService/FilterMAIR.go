@@ -18,32 +18,43 @@ type FilterMAIR struct {
 	currentTime time.Time
 	prices      []float64
 	volumes     []float64
+	sources     []string
 	lastTrade   dia.Trade
 	memory      int
 	value       float64
 	filterName  string
 	modified    bool
+	// outlierConfig and the sources rejected in the last final computation.
+	outlierConfig   OutlierConfig
+	rejectedSources []string
 }
 
 // NewFilterMAIR returns a FilterMAIR
 func NewFilterMAIR(asset dia.Asset, exchange string, currentTime time.Time, memory int) *FilterMAIR {
 	filter := &FilterMAIR{
-		asset:       asset,
-		exchange:    exchange,
-		prices:      []float64{},
-		volumes:     []float64{},
-		currentTime: currentTime,
-		memory:      memory,
-		filterName:  "MAIR" + strconv.Itoa(memory),
+		asset:         asset,
+		exchange:      exchange,
+		prices:        []float64{},
+		volumes:       []float64{},
+		sources:       []string{},
+		currentTime:   currentTime,
+		memory:        memory,
+		filterName:    "MAIR" + strconv.Itoa(memory),
+		outlierConfig: DefaultOutlierConfig(),
 	}
 	return filter
 }
 
-func (filter *FilterMAIR) Compute(trade dia.Trade) {
-	filter.compute(trade)
+// SetOutlierConfig sets the outlier rejection of the filter.
+func (filter *FilterMAIR) SetOutlierConfig(config OutlierConfig) {
+	if config.IQRScale <= 0 {
+		config.IQRScale = defaultIQRScale
+	}
+	filter.outlierConfig = config
 }
 
-func (filter *FilterMAIR) compute(trade dia.Trade) {
+// Compute processes @trade.
+func (filter *FilterMAIR) Compute(trade dia.Trade) {
 	filter.modified = true
 	if filter.lastTrade != (dia.Trade{}) {
 		if trade.Time.Before(filter.currentTime) {
@@ -71,6 +82,7 @@ func (filter *FilterMAIR) fill(trade dia.Trade) {
 				/// Remove latest data point and update with newer
 				filter.prices = filter.prices[1:]
 				filter.volumes = filter.volumes[1:]
+				filter.sources = filter.sources[1:]
 			}
 		}
 		filter.processDataPoint(trade)
@@ -83,70 +95,97 @@ func (filter *FilterMAIR) processDataPoint(trade dia.Trade) {
 	if len(filter.prices) >= filter.memory {
 		filter.prices = filter.prices[0 : filter.memory-1]
 		filter.volumes = filter.volumes[0 : filter.memory-1]
+		filter.sources = filter.sources[0 : filter.memory-1]
 	}
 	filter.prices = append([]float64{trade.EstimatedUSDPrice}, filter.prices...)
 	filter.volumes = append([]float64{trade.Volume}, filter.volumes...)
+	filter.sources = append([]string{trade.Source}, filter.sources...)
 }
 
+// FinalCompute computes the trimmed moving average of all trades processed since the last call.
+// The buffer is reduced to the last trade for the next tradesblock, also if no value can be computed.
 func (filter *FilterMAIR) FinalCompute(t time.Time) float64 {
-	return filter.finalCompute(t)
-}
-
-func (filter *FilterMAIR) finalCompute(t time.Time) float64 {
+	filter.rejectedSources = nil
 	if filter.lastTrade == (dia.Trade{}) {
-		return 0.0
+		return filter.value
 	}
 
 	if len(filter.prices) < 2 {
 		filter.value = filter.prices[0]
-		return filter.prices[0]
+		return filter.value
 	}
 
 	// Add the last trade again to compensate for the delay since measurement to EOB
 	// adopted behaviour from FilterMA
 	filter.processDataPoint(filter.lastTrade)
-	cleanPrices, bounds := removeOutliers(filter.prices)
-	mean, err := computeMean(cleanPrices, filter.volumes[bounds[0]:bounds[1]])
+
+	// Reject whole sources deviating from the cross-source median before trimming single trades.
+	filter.rejectedSources = rejectOutlierSources(filter.prices, filter.sources, filter.outlierConfig.MaxSourceDeviation)
+	if len(filter.rejectedSources) > 0 {
+		log.Warnf("FilterMAIR: rejected sources %v for %s on blockchain %s", filter.rejectedSources, filter.asset.Symbol, filter.asset.Blockchain)
+	}
+	prices, volumes := removeSources(filter.prices, filter.volumes, filter.sources, filter.rejectedSources)
+	if len(prices) > 1 {
+		var bounds []int
+		prices, bounds = removeOutliersScaled(prices, filter.outlierConfig.IQRScale)
+		volumes = volumes[bounds[0]:bounds[1]]
+	}
+	mean, err := computeMean(prices, volumes)
 	if err != nil {
-		return 0.0
+		log.Errorln("FilterMAIR: ", err)
+	} else if len(prices) > 0 {
+		filter.value = mean
 	}
-	filter.value = mean
 	// Reduce the filter values to the last recorded value for the next tradesblock.
-	if len(filter.prices) > 0 && len(filter.volumes) > 0 {
-		filter.prices = []float64{filter.lastTrade.EstimatedUSDPrice}
-		filter.volumes = []float64{filter.lastTrade.Volume}
-	}
+	filter.prices = []float64{filter.lastTrade.EstimatedUSDPrice}
+	filter.volumes = []float64{filter.lastTrade.Volume}
+	filter.sources = []string{filter.lastTrade.Source}
 	return filter.value
 }
 
-func (filter *FilterMAIR) FilterPointForBlock() *dia.FilterPoint {
-	return &dia.FilterPoint{
-		Asset: filter.asset,
-		Value: filter.value,
-		Name:  filter.filterName,
-		Time:  filter.currentTime,
-	}
+// carriedTrade returns the last trade carried over to the next block.
+func (filter *FilterMAIR) carriedTrade() dia.Trade {
+	return filter.lastTrade
 }
 
-func (filter *FilterMAIR) filterPointForBlock() *dia.FilterPoint {
-	if filter.exchange != "" || filter.filterName != dia.FilterKing {
-		return nil
+// seed sets the state of the filter to the state after a final computation with last trade @lastTrade and @value.
+func (filter *FilterMAIR) seed(lastTrade dia.Trade, value float64) {
+	filter.value = value
+	if lastTrade == (dia.Trade{}) {
+		return
 	}
+	filter.lastTrade = lastTrade
+	filter.currentTime = lastTrade.Time
+	filter.prices = []float64{lastTrade.EstimatedUSDPrice}
+	filter.volumes = []float64{lastTrade.Volume}
+	filter.sources = []string{lastTrade.Source}
+}
+
+// FilterPointForBlock returns the filter point of the last final computation.
+func (filter *FilterMAIR) FilterPointForBlock() *dia.FilterPoint {
 	return &dia.FilterPoint{
-		Asset: filter.asset,
-		Value: filter.value,
-		Name:  filter.filterName,
-		Time:  filter.currentTime,
+		Asset:           filter.asset,
+		Value:           filter.value,
+		Name:            filter.filterName,
+		Time:            filter.currentTime,
+		RejectedSources: filter.rejectedSources,
 	}
 }
 
-func (filter *FilterMAIR) save(ds models.Datastore) error {
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterMAIR) Save(ds models.Datastore) error {
 	if filter.modified {
 		filter.modified = false
 		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
 		if err != nil {
 			log.Errorln("FilterMAIR: Error:", err)
 		}
+		if len(filter.rejectedSources) > 0 {
+			err = ds.SetRejectedSources(filter.filterName, filter.asset, filter.exchange, filter.rejectedSources, filter.currentTime)
+			if err != nil {
+				log.Errorln("FilterMAIR: Error:", err)
+			}
+		}
 
 		// Additionally, the price across exchanges is saved in influx as a quotation.
 		// This price is used for the estimation of quote tokens' prices in the tradesBlockService.
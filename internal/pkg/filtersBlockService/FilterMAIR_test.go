@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 )
 
 func TestFilterMAIRInternalMean(t *testing.T) {
@@ -117,11 +118,11 @@ func TestFilterMAIRIgnore(t *testing.T) {
 	p := firstPrice
 	priceIncrements := 1.0
 	for i := 0; i <= steps; i++ {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(-time.Second)
 		p += priceIncrements
 	}
-	v := f.finalCompute(d)
+	v := f.FinalCompute(d)
 	if v != firstPrice {
 		t.Errorf("error should be initial value:%f got:%f", firstPrice, v)
 	}
@@ -140,7 +141,7 @@ func TestFilterMAIRAverage(t *testing.T) {
 	priceIncrements := 1.0
 	samples := 15
 	for i := 0; i < samples; i++ {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(time.Second)
 		avg += p
 		p += priceIncrements
@@ -148,7 +149,7 @@ func TestFilterMAIRAverage(t *testing.T) {
 	// append last value twice. Same as filter
 	avg += p - priceIncrements
 	avg = avg / float64(samples+1)
-	v := f.finalCompute(d)
+	v := f.FinalCompute(d)
 	if v != avg {
 		t.Errorf("error should be average value:%f got:%f", avg, v)
 	}
@@ -167,7 +168,7 @@ func TestFilterMAIRAverageOutsideRange(t *testing.T) {
 	priceIncrements := 1.0
 	samples := 15
 	for i := 0; i < samples; i++ {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(time.Second)
 		if samples-i <= memory {
 			avg += p
@@ -176,7 +177,7 @@ func TestFilterMAIRAverageOutsideRange(t *testing.T) {
 	}
 	// append last value twice. Same as filter
 	avg = (avg + priceIncrements*float64(memory-1)) / float64(memory)
-	v := f.finalCompute(d)
+	v := f.FinalCompute(d)
 	if v != avg {
 		t.Errorf("error should be average value:%f got:%f", avg, v)
 	}
@@ -205,12 +206,71 @@ func TestFilterMAIRAverageCleanOutliers(t *testing.T) {
 		}
 		f := NewFilterMAIR(assetXRP, "", d, memory)
 		for _, p := range c.samples {
-			f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+			f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 			d = d.Add(time.Second)
 		}
-		v := f.finalCompute(d)
+		v := f.FinalCompute(d)
 		if math.Abs(float64(v-c.mean)) > 1e-4 {
 			t.Errorf("Mean was incorrect, got: %f, expected: %f for set:%d", v, c.mean, i)
 		}
 	}
 }
+
+func TestRejectOutlierSources(t *testing.T) {
+	prices := []float64{100, 101, 99, 100.5, 150, 151, 100.2}
+	sources := []string{"A", "A", "B", "B", "C", "C", "D"}
+	rejected := rejectOutlierSources(prices, sources, 0.05)
+	if len(rejected) != 1 || rejected[0] != "C" {
+		t.Errorf("expected source C to be rejected, got: %v", rejected)
+	}
+	if rejected = rejectOutlierSources(prices, sources, 0); len(rejected) != 0 {
+		t.Errorf("expected no rejection when disabled, got: %v", rejected)
+	}
+	// Two sources are not enough for a cross-source median.
+	if rejected = rejectOutlierSources(prices[:6], []string{"A", "A", "C", "C", "C", "C"}, 0.05); len(rejected) != 0 {
+		t.Errorf("expected no rejection for two sources, got: %v", rejected)
+	}
+}
+
+func TestFilterMAIRRejectSources(t *testing.T) {
+	d := time.Date(2016, time.August, 15, 0, 0, 0, 0, time.UTC)
+	f := NewFilterMAIR(dia.Asset{Symbol: "XRP"}, "", d, 120)
+	f.SetOutlierConfig(OutlierConfig{MaxSourceDeviation: 0.05})
+	trades := []dia.Trade{
+		{EstimatedUSDPrice: 10, Volume: 1, Source: "A", Time: d.Add(1 * time.Second)},
+		{EstimatedUSDPrice: 10, Volume: 1, Source: "B", Time: d.Add(3 * time.Second)},
+		{EstimatedUSDPrice: 20, Volume: 100, Source: "C", Time: d.Add(5 * time.Second)},
+		{EstimatedUSDPrice: 20, Volume: 100, Source: "C", Time: d.Add(7 * time.Second)},
+		{EstimatedUSDPrice: 10, Volume: 1, Source: "D", Time: d.Add(9 * time.Second)},
+	}
+	for _, trade := range trades {
+		f.Compute(trade)
+	}
+	v := f.FinalCompute(d.Add(120 * time.Second))
+	if math.Abs(v-10) > 1e-9 {
+		t.Errorf("error should be average value:%f got:%f", 10.0, v)
+	}
+	fp := f.FilterPointForBlock()
+	if len(fp.RejectedSources) != 1 || fp.RejectedSources[0] != "C" {
+		t.Errorf("expected source C in rejected sources, got: %v", fp.RejectedSources)
+	}
+	ds := models.NewMemoryDataStore()
+	if err := f.Save(ds); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ds.GetRejectedSources(f.filterName, f.asset, "", d, d.Add(120*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || len(stored[0].RejectedSources) != 1 || stored[0].RejectedSources[0] != "C" {
+		t.Errorf("expected stored rejected source C, got: %v", stored)
+	}
+
+	// The next block without trades keeps the value and rejects no sources.
+	if v = f.FinalCompute(d.Add(240 * time.Second)); math.Abs(v-10) > 1e-9 {
+		t.Errorf("expected value %f of the last block, got %f", 10.0, v)
+	}
+	if fp = f.FilterPointForBlock(); len(fp.RejectedSources) != 0 {
+		t.Errorf("expected no rejected sources in block without trades, got: %v", fp.RejectedSources)
+	}
+}
@@ -22,12 +22,12 @@ func TestFilterMa(t *testing.T) {
 	p := firstPrice
 	i := 0
 	for i <= steps {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(time.Second)
 		i++
 	}
-	f.finalCompute(d)
-	v := f.filterPointForBlock()
+	f.FinalCompute(d)
+	v := f.FilterPointForBlock()
 	if v.Value != p {
 		t.Errorf("error should be stable %v", v)
 	}
@@ -35,13 +35,13 @@ func TestFilterMa(t *testing.T) {
 	priceIncrements := 1.0
 	i = 0
 	for i <= steps {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		p = p + priceIncrements
 		d = d.Add(time.Second)
 		i++
 	}
-	f.finalCompute(d)
-	v = f.filterPointForBlock()
+	f.FinalCompute(d)
+	v = f.FilterPointForBlock()
 	if v.Value != 53.25 { //TODO formulas
 		t.Errorf("error should be, %v", v)
 	}
@@ -62,12 +62,12 @@ func TestFilterMa2(t *testing.T) {
 	p := firstPrice
 	i := 0
 	for i <= steps {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(time.Second)
 		d = d.Add(time.Second)
 		i++
 	}
-	v := f.finalCompute(d)
+	v := f.FinalCompute(d)
 	if v != p {
 		t.Errorf("error should be stable %v", v)
 	}
@@ -75,13 +75,13 @@ func TestFilterMa2(t *testing.T) {
 	priceIncrements := 1.0
 	i = 0
 	for i <= steps {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		p = p + priceIncrements
 		d = d.Add(time.Second)
 		d = d.Add(time.Second)
 		i++
 	}
-	v = f.finalCompute(d)
+	v = f.FinalCompute(d)
 	if v != 56.4 { //TODO formulas
 		t.Errorf("error shouldnt be 57.0 %v", v)
 	}
@@ -103,11 +103,11 @@ func TestFilterMaIgnore(t *testing.T) {
 	p := firstPrice
 	priceIncrements := 1.0
 	for i := 0; i <= steps; i++ {
-		f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+		f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 		d = d.Add(-time.Second)
 		p += priceIncrements
 	}
-	v := f.finalCompute(d)
+	v := f.FinalCompute(d)
 	if v != firstPrice {
 		t.Errorf("error should be initial value:%f got:%f", firstPrice, v)
 	}
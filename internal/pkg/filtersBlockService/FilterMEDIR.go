@@ -37,7 +37,8 @@ func NewFilterMEDIR(asset dia.Asset, exchange string, currentTime time.Time, mem
 	return filter
 }
 
-func (filter *FilterMEDIR) compute(trade dia.Trade) {
+// Compute processes @trade.
+func (filter *FilterMEDIR) Compute(trade dia.Trade) {
 	filter.modified = true
 	if filter.lastTrade != (dia.Trade{}) {
 		if trade.Time.Before(filter.currentTime) {
@@ -50,13 +51,6 @@ func (filter *FilterMEDIR) compute(trade dia.Trade) {
 	filter.currentTime = trade.Time
 }
 
-func (filter *FilterMEDIR) Compute(trade dia.Trade) {
-	filter.compute(trade)
-}
-func (filter *FilterMEDIR) FinalCompute(t time.Time) {
-	filter.finalCompute(t)
-}
-
 func (filter *FilterMEDIR) processDataPoint(trade dia.Trade) {
 	/// first remove extra value from buffer if already full
 	if len(filter.prices) >= filter.memory {
@@ -65,7 +59,8 @@ func (filter *FilterMEDIR) processDataPoint(trade dia.Trade) {
 	filter.prices = append([]float64{trade.EstimatedUSDPrice}, filter.prices...)
 }
 
-func (filter *FilterMEDIR) finalCompute(t time.Time) float64 {
+// FinalCompute computes the trimmed median of all trades processed since the last call.
+func (filter *FilterMEDIR) FinalCompute(t time.Time) float64 {
 	if filter.lastTrade == (dia.Trade{}) {
 		log.Info("last trade emtpy")
 		return 0.0
@@ -76,18 +71,7 @@ func (filter *FilterMEDIR) finalCompute(t time.Time) float64 {
 	return filter.value
 }
 
-func (filter *FilterMEDIR) filterPointForBlock() *dia.FilterPoint {
-	if filter.exchange != "" || filter.filterName != dia.FilterKing {
-		return nil
-	}
-	return &dia.FilterPoint{
-		Asset: filter.asset,
-		Value: filter.value,
-		Name:  filter.filterName,
-		Time:  filter.currentTime,
-	}
-}
-
+// FilterPointForBlock returns the filter point of the last final computation.
 func (filter *FilterMEDIR) FilterPointForBlock() *dia.FilterPoint {
 	return &dia.FilterPoint{
 		Asset: filter.asset,
@@ -96,7 +80,9 @@ func (filter *FilterMEDIR) FilterPointForBlock() *dia.FilterPoint {
 		Time:  filter.currentTime,
 	}
 }
-func (filter *FilterMEDIR) save(ds models.Datastore) error {
+
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterMEDIR) Save(ds models.Datastore) error {
 	if filter.modified {
 		filter.modified = false
 		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
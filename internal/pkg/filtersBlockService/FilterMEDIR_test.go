@@ -56,10 +56,10 @@ func TestFilterMEDIRMedianCleanOutliers(t *testing.T) {
 		}
 		f := NewFilterMEDIR(assetXRP, "", d, memory)
 		for _, p := range c.samples {
-			f.compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
+			f.Compute(dia.Trade{EstimatedUSDPrice: p, Time: d})
 			d = d.Add(time.Second)
 		}
-		v := f.finalCompute(d)
+		v := f.FinalCompute(d)
 		if math.Abs(float64(v-c.mean)) > 1e-4 {
 			t.Errorf("Median was incorrect, got: %f, expected: %f for set:%d", v, c.mean, i)
 		}
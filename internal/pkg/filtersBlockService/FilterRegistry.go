@@ -0,0 +1,265 @@
+package filters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+)
+
+// FilterFactory returns a new filter for @asset on @exchange. An empty @exchange
+// denotes the filter across all exchanges. @currentTime is the begin time of the
+// tradesBlock in which the asset first appears and @param is the filter's parameter,
+// usually its memory in seconds.
+type FilterFactory func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter
+
+var (
+	filterFactoriesMu sync.RWMutex
+	filterFactories   = make(map[string]FilterFactory)
+)
+
+func init() {
+	RegisterFilter("MA", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterMA(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("MAIR", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterMAIR(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("MEDIR", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterMEDIR(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("VWAP", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterVWAP(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("VWAPIR", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterVWAPIR(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("TWAP", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterTWAP(asset, exchange, currentTime, param)
+	})
+	RegisterFilter("VOL", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterVOL(asset, exchange, param)
+	})
+	RegisterFilter("COUNT", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterCOUNT(asset, exchange, param)
+	})
+	RegisterFilter("TLT", func(asset dia.Asset, exchange string, currentTime time.Time, param int) Filter {
+		return NewFilterTLT(asset, exchange)
+	})
+}
+
+// RegisterFilter makes a filter available to the FiltersBlockService under @filterType.
+// It is meant to be called from an init function and panics if @filterType is
+// registered twice or if @factory is nil.
+func RegisterFilter(filterType string, factory FilterFactory) {
+	filterFactoriesMu.Lock()
+	defer filterFactoriesMu.Unlock()
+	if factory == nil {
+		panic("filters: RegisterFilter factory is nil for " + filterType)
+	}
+	if _, dup := filterFactories[filterType]; dup {
+		panic("filters: RegisterFilter called twice for " + filterType)
+	}
+	filterFactories[filterType] = factory
+}
+
+// RegisteredFilters returns the sorted list of all registered filter types.
+func RegisteredFilters() []string {
+	filterFactoriesMu.RLock()
+	defer filterFactoriesMu.RUnlock()
+	var filterTypes []string
+	for filterType := range filterFactories {
+		filterTypes = append(filterTypes, filterType)
+	}
+	sort.Strings(filterTypes)
+	return filterTypes
+}
+
+// NewFilter returns a new filter of the registered type @filterType.
+func NewFilter(filterType string, asset dia.Asset, exchange string, currentTime time.Time, param int) (Filter, error) {
+	filterFactoriesMu.RLock()
+	factory, ok := filterFactories[filterType]
+	filterFactoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("filters: unknown filter type %s", filterType)
+	}
+	return factory(asset, exchange, currentTime, param), nil
+}
+
+// NewFilterFromSpec returns a new filter as specified by @spec.
+// If @spec.Window is set, the filter is computed over a sliding window of several blocks.
+func NewFilterFromSpec(spec FilterSpec, asset dia.Asset, exchange string, currentTime time.Time) (Filter, error) {
+	if spec.Window > 0 {
+		return NewFilterWindow(spec, asset, exchange, currentTime)
+	}
+	f, err := NewFilter(spec.Type, asset, exchange, currentTime, spec.Param)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Outliers != nil {
+		configurer, ok := f.(OutlierConfigurer)
+		if !ok {
+			return nil, fmt.Errorf("filters: filter type %s has no configurable outlier rejection", spec.Type)
+		}
+		configurer.SetOutlierConfig(*spec.Outliers)
+	}
+	return f, nil
+}
+
+// FilterSpec selects a registered filter by its type and parameter.
+// @Outliers optionally configures the outlier rejection of filters implementing OutlierConfigurer.
+// If @Window is positive, the filter is computed over the trades of the last @Window seconds
+// at the end of each block instead of over the trades of the block. @Window must be at least the block size.
+// The points of the @Primary filter across all exchanges are forwarded in the filtersBlock for all assets.
+// At most one filter per list is primary.
+type FilterSpec struct {
+	Type     string         `json:"Type"`
+	Param    int            `json:"Param"`
+	Outliers *OutlierConfig `json:"Outliers,omitempty"`
+	Window   int            `json:"Window,omitempty"`
+	Primary  bool           `json:"Primary,omitempty"`
+}
+
+// AssetFilters is the list of filters computed for a single asset.
+type AssetFilters struct {
+	Blockchain string       `json:"Blockchain"`
+	Address    string       `json:"Address"`
+	Filters    []FilterSpec `json:"Filters"`
+}
+
+// FiltersConfig determines which filters the FiltersBlockService computes per asset.
+// Assets without an entry in Assets are processed with the Default filters.
+// Besides the primary filter, the filtersBlock contains the points of the filters named in Publish,
+// such as VWAP600, for all assets traded in the block, across all exchanges and per exchange.
+type FiltersConfig struct {
+	Default []FilterSpec   `json:"Default"`
+	Assets  []AssetFilters `json:"Assets"`
+	Publish []string       `json:"Publish,omitempty"`
+
+	assetFilters map[string][]FilterSpec
+	publish      map[string]struct{}
+	// primary maps asset identifiers to the name of their primary filter.
+	primary map[string]string
+}
+
+// DefaultFiltersConfig returns the configuration of filters computed for all assets
+// if no configuration file is given.
+func DefaultFiltersConfig() *FiltersConfig {
+	return &FiltersConfig{
+		Default: []FilterSpec{
+			{Type: "MA", Param: dia.BlockSizeSeconds},
+			{Type: "MAIR", Param: dia.BlockSizeSeconds, Primary: true},
+			{Type: "MEDIR", Param: dia.BlockSizeSeconds},
+			{Type: "VOL", Param: dia.BlockSizeSeconds},
+			{Type: "COUNT", Param: dia.BlockSizeSeconds},
+			{Type: "TLT"},
+		},
+	}
+}
+
+// LoadFiltersConfig reads a filters configuration from the json file @filename in the config folder.
+func LoadFiltersConfig(filename string) (*FiltersConfig, error) {
+	content, err := configCollectors.ReadJSONFromConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	var config FiltersConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return nil, err
+	}
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks that all filters in @c are registered and support the given options
+// and that at most one filter per list is primary.
+func (c *FiltersConfig) Validate() error {
+	lists := [][]FilterSpec{c.Default}
+	for _, assetFilters := range c.Assets {
+		lists = append(lists, assetFilters.Filters)
+	}
+	for _, specs := range lists {
+		var primaries int
+		for _, spec := range specs {
+			if _, err := NewFilterFromSpec(spec, dia.Asset{}, "", time.Time{}); err != nil {
+				return err
+			}
+			if spec.Primary {
+				primaries++
+			}
+		}
+		if primaries > 1 {
+			return fmt.Errorf("filters: %d primary filters in %v", primaries, specs)
+		}
+	}
+	return nil
+}
+
+// FiltersForAsset returns the filter specs configured for @asset.
+func (c *FiltersConfig) FiltersForAsset(asset dia.Asset) []FilterSpec {
+	return c.filtersForIdentifier(getIdentifier(asset))
+}
+
+// IsPrimary returns true if the filter named @filterName is the primary filter of the asset with @identifier.
+func (c *FiltersConfig) IsPrimary(identifier string, filterName string) bool {
+	if c.primary == nil {
+		c.primary = make(map[string]string)
+	}
+	name, ok := c.primary[identifier]
+	if !ok {
+		name = primaryName(c.filtersForIdentifier(identifier))
+		c.primary[identifier] = name
+	}
+	return name != "" && name == filterName
+}
+
+// primaryName returns the name of the points of the primary filter in @specs, or an empty string if there is none.
+func primaryName(specs []FilterSpec) string {
+	for _, spec := range specs {
+		if !spec.Primary {
+			continue
+		}
+		f, err := NewFilterFromSpec(spec, dia.Asset{}, "", time.Time{})
+		if err != nil {
+			return ""
+		}
+		if fp := f.FilterPointForBlock(); fp != nil {
+			return fp.Name
+		}
+	}
+	return ""
+}
+
+func (c *FiltersConfig) filtersForIdentifier(identifier string) []FilterSpec {
+	if c.assetFilters == nil {
+		c.assetFilters = make(map[string][]FilterSpec)
+		for _, assetFilters := range c.Assets {
+			identifier := getIdentifier(dia.Asset{Blockchain: assetFilters.Blockchain, Address: assetFilters.Address})
+			c.assetFilters[identifier] = assetFilters.Filters
+		}
+	}
+	if specs, ok := c.assetFilters[identifier]; ok {
+		return specs
+	}
+	return c.Default
+}
+
+// Published returns true if points of the filter named @filterName are added to filtersBlocks.
+func (c *FiltersConfig) Published(filterName string) bool {
+	if c.publish == nil {
+		c.publish = make(map[string]struct{})
+		for _, name := range c.Publish {
+			c.publish[name] = struct{}{}
+		}
+	}
+	_, ok := c.publish[filterName]
+	return ok
+}
@@ -0,0 +1,79 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestNewFilterRegistered(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	for _, spec := range DefaultFiltersConfig().Default {
+		f, err := NewFilter(spec.Type, asset, "", time.Now(), spec.Param)
+		if err != nil {
+			t.Errorf("NewFilter %s: %v", spec.Type, err)
+		}
+		if f == nil {
+			t.Errorf("NewFilter %s returned nil filter", spec.Type)
+		}
+	}
+	_, err := NewFilter("UNKNOWN", asset, "", time.Now(), 0)
+	if err == nil {
+		t.Error("NewFilter should fail for unregistered filter type")
+	}
+}
+
+func TestFiltersForAsset(t *testing.T) {
+	eth := dia.Asset{Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	btc := dia.Asset{Blockchain: dia.BITCOIN, Address: "0x0000000000000000000000000000000000000000"}
+	config := DefaultFiltersConfig()
+	config.Assets = []AssetFilters{
+		{
+			Blockchain: eth.Blockchain,
+			Address:    eth.Address,
+			Filters:    []FilterSpec{{Type: "VWAP", Param: 120}},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if specs := config.FiltersForAsset(eth); len(specs) != 1 || specs[0].Type != "VWAP" {
+		t.Errorf("unexpected filters for %s: %v", eth.Blockchain, specs)
+	}
+	if specs := config.FiltersForAsset(btc); len(specs) != len(config.Default) {
+		t.Errorf("expected default filters for %s, got: %v", btc.Blockchain, specs)
+	}
+
+	config.Default = append(config.Default, FilterSpec{Type: "UNKNOWN"})
+	if err := config.Validate(); err == nil {
+		t.Error("Validate should fail for unregistered filter type")
+	}
+}
+
+func TestFiltersConfigPrimary(t *testing.T) {
+	eth := dia.Asset{Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	config := DefaultFiltersConfig()
+	config.Assets = []AssetFilters{
+		{
+			Blockchain: eth.Blockchain,
+			Address:    eth.Address,
+			Filters:    []FilterSpec{{Type: "MAIR", Param: 120}, {Type: "VWAP", Param: 120, Primary: true}},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !config.IsPrimary(getIdentifier(eth), "VWAP120") || config.IsPrimary(getIdentifier(eth), dia.FilterKing) {
+		t.Errorf("expected VWAP120 as primary filter of %s", eth.Blockchain)
+	}
+	btc := dia.Asset{Blockchain: dia.BITCOIN, Address: "0x0000000000000000000000000000000000000000"}
+	if !config.IsPrimary(getIdentifier(btc), dia.FilterKing) {
+		t.Errorf("expected %s as default primary filter", dia.FilterKing)
+	}
+
+	config.Default = append(config.Default, FilterSpec{Type: "MEDIR", Param: 120, Primary: true})
+	if err := config.Validate(); err == nil {
+		t.Error("Validate should fail for several primary filters")
+	}
+}
@@ -0,0 +1,108 @@
+package filters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// FilterTWAP implements a time-weighted average price.
+// Each trade's price is weighted by the time span until the next trade
+// or, for the last trade, until the end of the block.
+// The last price of a block is carried over into the next block.
+type FilterTWAP struct {
+	asset            dia.Asset
+	exchange         string
+	currentTime      time.Time
+	lastTrade        dia.Trade
+	param            int
+	weightedPriceSum float64
+	totalDuration    float64
+	value            float64
+	filterName       string
+	modified         bool
+}
+
+// NewFilterTWAP returns a time-weighted average price filter.
+// @currentTime is the begin time of the filtersBlock.
+func NewFilterTWAP(asset dia.Asset, exchange string, currentTime time.Time, param int) *FilterTWAP {
+	filter := &FilterTWAP{
+		asset:       asset,
+		exchange:    exchange,
+		currentTime: currentTime,
+		param:       param,
+		filterName:  "TWAP" + strconv.Itoa(param),
+	}
+	return filter
+}
+
+// Compute processes @trade.
+// A first trade before the filter's current time is taken as the price at begin of the block.
+func (filter *FilterTWAP) Compute(trade dia.Trade) {
+	if filter.lastTrade != (dia.Trade{}) {
+		if trade.Time.Before(filter.currentTime) {
+			log.Errorln("FilterTWAP: Ignoring Trade out of order ", filter.currentTime, trade.Time)
+			return
+		}
+		filter.addInterval(filter.lastTrade.EstimatedUSDPrice, trade.Time)
+	}
+	filter.modified = true
+	if trade.Time.After(filter.currentTime) {
+		filter.currentTime = trade.Time
+	}
+	filter.lastTrade = trade
+}
+
+// addInterval weights @price with the time span from the filter's current time until @t.
+func (filter *FilterTWAP) addInterval(price float64, t time.Time) {
+	duration := t.Sub(filter.currentTime).Seconds()
+	if duration > 0 {
+		filter.weightedPriceSum += price * duration
+		filter.totalDuration += duration
+	}
+}
+
+// FinalCompute computes the time-weighted average price until @t, usually the end of the block.
+func (filter *FilterTWAP) FinalCompute(t time.Time) float64 {
+	if filter.lastTrade == (dia.Trade{}) {
+		return 0.0
+	}
+	filter.addInterval(filter.lastTrade.EstimatedUSDPrice, t)
+	if filter.totalDuration > 0 {
+		filter.value = filter.weightedPriceSum / filter.totalDuration
+	} else {
+		filter.value = filter.lastTrade.EstimatedUSDPrice
+	}
+	filter.weightedPriceSum = 0
+	filter.totalDuration = 0
+	if t.After(filter.currentTime) {
+		filter.currentTime = t
+	}
+	return filter.value
+}
+
+// FilterPointForBlock returns the filter point of the last final computation.
+func (filter *FilterTWAP) FilterPointForBlock() *dia.FilterPoint {
+	return &dia.FilterPoint{
+		Asset: filter.asset,
+		Value: filter.value,
+		Name:  filter.filterName,
+		Time:  filter.currentTime,
+	}
+}
+
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterTWAP) Save(ds models.Datastore) error {
+	if filter.modified {
+		filter.modified = false
+		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
+		if err != nil {
+			log.Errorln("FilterTWAP: Error:", err)
+		}
+		return err
+	}
+	return nil
+}
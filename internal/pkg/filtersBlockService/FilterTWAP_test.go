@@ -0,0 +1,50 @@
+package filters
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestFilterTWAP(t *testing.T) {
+	d := time.Date(2016, time.August, 15, 0, 0, 0, 0, time.UTC)
+	assetXRP := dia.Asset{
+		Symbol: "XRP",
+		Name:   "XRP",
+	}
+	f := NewFilterTWAP(assetXRP, "", d, 120)
+
+	// Price 10 for 30s, price 20 for 90s.
+	f.Compute(dia.Trade{EstimatedUSDPrice: 10, Volume: 100, Time: d})
+	f.Compute(dia.Trade{EstimatedUSDPrice: 20, Volume: 0.1, Time: d.Add(30 * time.Second)})
+	v := f.FinalCompute(d.Add(120 * time.Second))
+	if math.Abs(v-17.5) > 1e-9 {
+		t.Errorf("TWAP was incorrect, got: %f, expected: %f", v, 17.5)
+	}
+
+	// Without trades in the next block the last price is carried over.
+	v = f.FinalCompute(d.Add(240 * time.Second))
+	if v != 20 {
+		t.Errorf("TWAP was incorrect, got: %f, expected: %f", v, 20.0)
+	}
+	fp := f.FilterPointForBlock()
+	if fp.Name != "TWAP120" || !fp.Time.Equal(d.Add(240*time.Second)) {
+		t.Errorf("unexpected filter point: %v", fp)
+	}
+}
+
+func TestFilterTWAPPreviousTrade(t *testing.T) {
+	d := time.Date(2016, time.August, 15, 0, 0, 0, 0, time.UTC)
+	f := NewFilterTWAP(dia.Asset{Symbol: "XRP"}, "", d, 120)
+
+	// A trade before the begin of the block sets the initial price.
+	f.Compute(dia.Trade{EstimatedUSDPrice: 30, Time: d.Add(-time.Hour)})
+	f.Compute(dia.Trade{EstimatedUSDPrice: 60, Time: d.Add(60 * time.Second)})
+	f.Compute(dia.Trade{EstimatedUSDPrice: 90, Time: d.Add(10 * time.Second)})
+	v := f.FinalCompute(d.Add(120 * time.Second))
+	if math.Abs(v-45) > 1e-9 {
+		t.Errorf("TWAP was incorrect, got: %f, expected: %f", v, 45.0)
+	}
+}
@@ -32,29 +32,21 @@ func NewFilterVOL(asset dia.Asset, exchange string, memory int) *FilterVOL {
 	return filter
 }
 
+// Compute processes @trade.
 func (filter *FilterVOL) Compute(trade dia.Trade) {
-	filter.compute(trade)
-}
-func (filter *FilterVOL) FinalCompute(t time.Time) {
-	filter.finalCompute(t)
-}
-
-func (filter *FilterVOL) compute(trade dia.Trade) {
 	filter.modified = true
 	filter.volumeUSD += trade.EstimatedUSDPrice * math.Abs(trade.Volume)
 	filter.currentTime = trade.Time
 }
 
-func (filter *FilterVOL) finalCompute(time time.Time) float64 {
+// FinalCompute returns the value aggregated since the last call and resets the filter.
+func (filter *FilterVOL) FinalCompute(t time.Time) float64 {
 	filter.value = filter.volumeUSD
 	filter.volumeUSD = 0.0
 	return filter.value
 }
 
-func (filter *FilterVOL) filterPointForBlock() *dia.FilterPoint {
-	return nil
-}
-
+// FilterPointForBlock returns the filter point of the last final computation.
 func (filter *FilterVOL) FilterPointForBlock() *dia.FilterPoint {
 	return &dia.FilterPoint{
 		Asset: filter.asset,
@@ -64,7 +56,8 @@ func (filter *FilterVOL) FilterPointForBlock() *dia.FilterPoint {
 	}
 }
 
-func (filter *FilterVOL) save(ds models.Datastore) error {
+// Save writes the filter value to @ds if it was modified.
+func (filter *FilterVOL) Save(ds models.Datastore) error {
 	if filter.modified {
 		filter.modified = false
 		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -38,11 +39,7 @@ func NewFilterVWAP(asset dia.Asset, exchange string, currentTime time.Time, para
 }
 
 // Compute ...
-func (s *FilterVWAP) Compute(trade dia.Trade) {
-	s.compute(trade)
-}
-
-func (filter *FilterVWAP) compute(trade dia.Trade) {
+func (filter *FilterVWAP) Compute(trade dia.Trade) {
 	filter.modified = true
 	if filter.lastTrade != (dia.Trade{}) {
 		if trade.Time.Before(filter.currentTime) {
@@ -68,10 +65,6 @@ func (filter *FilterVWAP) processDataPoint(trade dia.Trade) {
 
 // FinalCompute ...
 func (s *FilterVWAP) FinalCompute(t time.Time) float64 {
-	return s.finalCompute(t)
-}
-
-func (s *FilterVWAP) finalCompute(t time.Time) float64 {
 	log.Infof("computed value %v at time %v ", s.value, t)
 	if s.lastTrade == (dia.Trade{}) {
 		return 0.0
@@ -102,22 +95,23 @@ func (s *FilterVWAP) finalCompute(t time.Time) float64 {
 
 // FilterPointForBlock ...
 func (s *FilterVWAP) FilterPointForBlock() *dia.FilterPoint {
-	return s.filterPointForBlock()
+	return &dia.FilterPoint{
+		Value: s.value,
+		Name:  "VWAP" + strconv.Itoa(s.param),
+		Time:  s.currentTime,
+		Asset: s.asset,
+	}
 }
-func (s *FilterVWAP) filterPointForBlock() *dia.FilterPoint {
-	if s.exchange != "" {
-		return &dia.FilterPoint{
-			Value: s.value,
-			Name:  "VWAP" + strconv.Itoa(s.param),
-			Time:  s.currentTime,
-			Asset: s.asset,
-		}
-	} else {
-		return &dia.FilterPoint{
-			Value: s.value,
-			Name:  "VWAP" + strconv.Itoa(s.param),
-			Time:  s.currentTime,
-			Asset: s.asset,
+
+// Save ...
+func (s *FilterVWAP) Save(ds models.Datastore) error {
+	if s.modified {
+		s.modified = false
+		err := ds.SetFilter(s.filterName, s.asset, s.exchange, s.value, s.currentTime)
+		if err != nil {
+			log.Errorln("FilterVWAP: Error:", err)
 		}
+		return err
 	}
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -15,34 +16,44 @@ type FilterVWAPIR struct {
 	currentTime time.Time
 	prices      []float64
 	volumes     []float64
+	sources     []string
 	lastTrade   dia.Trade
 	param       int
 	value       float64
 	modified    bool
 	filterName  string
 	asset       dia.Asset
+	// outlierConfig and the sources rejected in the last final computation.
+	outlierConfig   OutlierConfig
+	rejectedSources []string
 }
 
 // NewFilterVWAP ...
 func NewFilterVWAPIR(asset dia.Asset, exchange string, currentTime time.Time, param int) *FilterVWAPIR {
 	s := &FilterVWAPIR{
-		asset:       asset,
-		exchange:    exchange,
-		prices:      []float64{},
-		volumes:     []float64{},
-		currentTime: currentTime,
-		param:       param,
-		filterName:  "VWAP" + strconv.Itoa(param),
+		asset:         asset,
+		exchange:      exchange,
+		prices:        []float64{},
+		volumes:       []float64{},
+		sources:       []string{},
+		currentTime:   currentTime,
+		param:         param,
+		filterName:    "VWAPIR" + strconv.Itoa(param),
+		outlierConfig: DefaultOutlierConfig(),
 	}
 	return s
 }
 
-// Compute ...
-func (s *FilterVWAPIR) Compute(trade dia.Trade) {
-	s.compute(trade)
+// SetOutlierConfig sets the outlier rejection of the filter.
+func (s *FilterVWAPIR) SetOutlierConfig(config OutlierConfig) {
+	if config.IQRScale <= 0 {
+		config.IQRScale = defaultIQRScale
+	}
+	s.outlierConfig = config
 }
 
-func (filter *FilterVWAPIR) compute(trade dia.Trade) {
+// Compute ...
+func (filter *FilterVWAPIR) Compute(trade dia.Trade) {
 	filter.modified = true
 	if filter.lastTrade != (dia.Trade{}) {
 		if trade.Time.Before(filter.currentTime) {
@@ -64,30 +75,36 @@ func (filter *FilterVWAPIR) fill(trade dia.Trade) {
 func (filter *FilterVWAPIR) processDataPoint(trade dia.Trade) {
 	filter.prices = append([]float64{trade.EstimatedUSDPrice}, filter.prices...)
 	filter.volumes = append([]float64{trade.Volume}, filter.volumes...)
+	filter.sources = append([]string{trade.Source}, filter.sources...)
 }
 
 // FinalCompute ...
 func (s *FilterVWAPIR) FinalCompute(t time.Time) float64 {
 	log.Info("final compute of time ", t)
-	return s.finalCompute()
-}
-
-func (s *FilterVWAPIR) finalCompute() float64 {
 	if s.lastTrade == (dia.Trade{}) {
 		return 0.0
 	}
 
-	// s.processDataPoint(*s.lastTrade)
-	cleanPrices, bounds := removeOutliers(s.prices)
-
-	priceVolume := []float64{}
+	// Reject whole sources deviating from the cross-source median before trimming single trades.
+	s.rejectedSources = rejectOutlierSources(s.prices, s.sources, s.outlierConfig.MaxSourceDeviation)
+	if len(s.rejectedSources) > 0 {
+		log.Warnf("FilterVWAPIR: rejected sources %v for %s on blockchain %s", s.rejectedSources, s.asset.Symbol, s.asset.Blockchain)
+	}
+	prices, volumes := removeSources(s.prices, s.volumes, s.sources, s.rejectedSources)
 
-	// TODO handle bounds
-	if len(bounds) == 0 {
-		return 0.0
+	// Too few trades for outlier removal. Keep the last value if there is no trade at all.
+	if len(prices) < 2 {
+		if len(prices) == 1 {
+			s.value = prices[0]
+		}
+		return s.value
 	}
 
-	cleanedVolumes := s.volumes[bounds[0]:bounds[1]]
+	cleanPrices, bounds := removeOutliersScaled(prices, s.outlierConfig.IQRScale)
+
+	priceVolume := []float64{}
+
+	cleanedVolumes := volumes[bounds[0]:bounds[1]]
 
 	for index, price := range cleanPrices {
 		priceVolume = append(priceVolume, price*math.Abs(cleanedVolumes[index]))
@@ -104,6 +121,9 @@ func (s *FilterVWAPIR) finalCompute() float64 {
 		total += v
 	}
 
+	if totalVolume == 0 {
+		return s.value
+	}
 	s.value = total / totalVolume
 
 	return s.value
@@ -111,22 +131,30 @@ func (s *FilterVWAPIR) finalCompute() float64 {
 
 // FilterPointForBlock ...
 func (s *FilterVWAPIR) FilterPointForBlock() *dia.FilterPoint {
-	return s.filterPointForBlock()
+	return &dia.FilterPoint{
+		Value:           s.value,
+		Name:            s.filterName,
+		Time:            s.currentTime,
+		Asset:           s.asset,
+		RejectedSources: s.rejectedSources,
+	}
 }
-func (s *FilterVWAPIR) filterPointForBlock() *dia.FilterPoint {
-	if s.exchange != "" {
-		return &dia.FilterPoint{
-			Value: s.value,
-			Name:  s.filterName,
-			Time:  s.currentTime,
-			Asset: s.asset,
+
+// Save ...
+func (s *FilterVWAPIR) Save(ds models.Datastore) error {
+	if s.modified {
+		s.modified = false
+		err := ds.SetFilter(s.filterName, s.asset, s.exchange, s.value, s.currentTime)
+		if err != nil {
+			log.Errorln("FilterVWAPIR: Error:", err)
 		}
-	} else {
-		return &dia.FilterPoint{
-			Value: s.value,
-			Name:  s.filterName,
-			Time:  s.currentTime,
-			Asset: s.asset,
+		if len(s.rejectedSources) > 0 {
+			err = ds.SetRejectedSources(s.filterName, s.asset, s.exchange, s.rejectedSources, s.currentTime)
+			if err != nil {
+				log.Errorln("FilterVWAPIR: Error:", err)
+			}
 		}
+		return err
 	}
+	return nil
 }
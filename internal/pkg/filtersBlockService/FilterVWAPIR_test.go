@@ -42,3 +42,20 @@ func BenchmarkVWAPIR(b *testing.B) {
 		maFilter.FinalCompute(trades[0].Time)
 	}
 }
+
+func TestVWAPIRSingleTrade(t *testing.T) {
+	trades := getTrades()
+	filter := NewFilterVWAPIR(dia.Asset{}, "Binance", trades[0].Time, dia.BlockSizeSeconds)
+
+	filter.Compute(trades[0])
+	filter.FinalCompute(trades[0].Time)
+	if value := filter.FilterPointForBlock().Value; value != trades[0].Price {
+		t.Errorf("Error vwap of single trade expected %v and got %v ", trades[0].Price, value)
+	}
+
+	// A block without trades keeps the last value.
+	filter.FinalCompute(trades[0].Time)
+	if value := filter.FilterPointForBlock().Value; value != trades[0].Price {
+		t.Errorf("Error vwap of empty block expected %v and got %v ", trades[0].Price, value)
+	}
+}
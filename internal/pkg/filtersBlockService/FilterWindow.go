@@ -0,0 +1,143 @@
+package filters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// FilterWindow computes a filter over a sliding window spanning several tradesBlocks,
+// such as a 10 minutes VWAP emitted at the end of each block.
+// It keeps the trades of all blocks ending within the window and recomputes the underlying
+// filter from these trades at the end of each block. Its name is the filter type followed by
+// the window in seconds, e.g. VWAP600.
+type FilterWindow struct {
+	spec        FilterSpec
+	asset       dia.Asset
+	exchange    string
+	window      time.Duration
+	blocks      []windowBlock
+	trades      []dia.Trade
+	blockBegin  time.Time
+	currentTime time.Time
+	value       float64
+	filterName  string
+	modified    bool
+}
+
+// windowBlock holds the trades of a processed tradesBlock.
+type windowBlock struct {
+	begin  time.Time
+	end    time.Time
+	trades []dia.Trade
+}
+
+// NewFilterWindow returns a filter computing the filter given by @spec over the last @spec.Window seconds.
+// @currentTime is the begin time of the tradesBlock in which the asset first appears.
+func NewFilterWindow(spec FilterSpec, asset dia.Asset, exchange string, currentTime time.Time) (*FilterWindow, error) {
+	if spec.Window < dia.BlockSizeSeconds {
+		return nil, fmt.Errorf("filters: window of %s must be at least the block size of %d seconds", spec.Type, dia.BlockSizeSeconds)
+	}
+	filter := &FilterWindow{
+		spec:        spec,
+		asset:       asset,
+		exchange:    exchange,
+		window:      time.Duration(spec.Window) * time.Second,
+		blockBegin:  currentTime,
+		currentTime: currentTime,
+		filterName:  spec.Type + strconv.Itoa(spec.Window),
+	}
+	filter.spec.Window = 0
+	if _, err := filter.newFilter(currentTime); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// newFilter returns the underlying filter for a window beginning at @begin.
+func (filter *FilterWindow) newFilter(begin time.Time) (Filter, error) {
+	return NewFilterFromSpec(filter.spec, filter.asset, filter.exchange, begin)
+}
+
+// Compute adds @trade to the current block.
+func (filter *FilterWindow) Compute(trade dia.Trade) {
+	filter.trades = append(filter.trades, trade)
+}
+
+// AddLateTrade adds @trade to the processed block it belongs to, if this block is still within the window.
+// Hence, late trades from correction blocks are part of the values computed at the end of subsequent blocks.
+func (filter *FilterWindow) AddLateTrade(trade dia.Trade) {
+	for i := range filter.blocks {
+		if !trade.Time.Before(filter.blocks[i].begin) && trade.Time.Before(filter.blocks[i].end) {
+			filter.blocks[i].trades = append(filter.blocks[i].trades, trade)
+			return
+		}
+	}
+}
+
+// FinalCompute closes the current block at @t, removes blocks ending before the window
+// and computes the underlying filter over the trades of all remaining blocks.
+// If there are no trades within the window, the previous value is kept.
+func (filter *FilterWindow) FinalCompute(t time.Time) float64 {
+	filter.blocks = append(filter.blocks, windowBlock{begin: filter.blockBegin, end: t, trades: filter.trades})
+	filter.trades = nil
+	filter.blockBegin = t
+	filter.currentTime = t
+
+	windowBegin := t.Add(-filter.window)
+	for len(filter.blocks) > 0 && !filter.blocks[0].end.After(windowBegin) {
+		filter.blocks = filter.blocks[1:]
+	}
+
+	var trades []dia.Trade
+	for _, block := range filter.blocks {
+		trades = append(trades, block.trades...)
+	}
+	if len(trades) == 0 {
+		filter.modified = false
+		return filter.value
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.Before(trades[j].Time)
+	})
+
+	f, err := filter.newFilter(windowBegin)
+	if err != nil {
+		log.Errorf("FilterWindow %s: %v", filter.filterName, err)
+		return filter.value
+	}
+	for _, trade := range trades {
+		f.Compute(trade)
+	}
+	filter.value = f.FinalCompute(t)
+	filter.modified = true
+	return filter.value
+}
+
+// FilterPointForBlock returns the filter point of the last final computation.
+func (filter *FilterWindow) FilterPointForBlock() *dia.FilterPoint {
+	return &dia.FilterPoint{
+		Asset: filter.asset,
+		Value: filter.value,
+		Name:  filter.filterName,
+		Time:  filter.currentTime,
+	}
+}
+
+// Save writes the filter value to @ds if there were trades within the window.
+func (filter *FilterWindow) Save(ds models.Datastore) error {
+	if filter.modified {
+		filter.modified = false
+		err := ds.SetFilter(filter.filterName, filter.asset, filter.exchange, filter.value, filter.currentTime)
+		if err != nil {
+			log.Errorln("FilterWindow: Error:", err)
+		}
+		return err
+	}
+	return nil
+}
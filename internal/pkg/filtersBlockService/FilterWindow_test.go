@@ -0,0 +1,60 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestFilterWindowVWAP(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	blockSize := time.Duration(dia.BlockSizeSeconds) * time.Second
+	beginTime := time.Unix(1672531200, 0)
+
+	filter, err := NewFilterFromSpec(FilterSpec{Type: "VWAP", Param: dia.BlockSizeSeconds, Window: 2 * dia.BlockSizeSeconds}, asset, "", beginTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One trade per block. The window spans the last two blocks.
+	blocks := []struct {
+		price    float64
+		volume   float64
+		expected float64
+	}{
+		{price: 100, volume: 1, expected: 100},
+		{price: 200, volume: 3, expected: 175},
+		{price: 300, volume: 1, expected: 225},
+	}
+	for i, block := range blocks {
+		blockBegin := beginTime.Add(time.Duration(i) * blockSize)
+		filter.Compute(dia.Trade{QuoteToken: asset, EstimatedUSDPrice: block.price, Volume: block.volume, Time: blockBegin.Add(time.Second)})
+		value := filter.FinalCompute(blockBegin.Add(blockSize))
+		if value != block.expected {
+			t.Errorf("block %d: expected %v, got %v", i, block.expected, value)
+		}
+		fp := filter.FilterPointForBlock()
+		if fp.Name != "VWAP240" || !fp.Time.Equal(blockBegin.Add(blockSize)) {
+			t.Errorf("block %d: unexpected filter point %s at %v", i, fp.Name, fp.Time)
+		}
+	}
+
+	// A late trade in the last block is part of the next window, a late trade in the first block is dropped.
+	filter.(*FilterWindow).AddLateTrade(dia.Trade{QuoteToken: asset, EstimatedUSDPrice: 400, Volume: 4, Time: beginTime.Add(2*blockSize + 2*time.Second)})
+	filter.(*FilterWindow).AddLateTrade(dia.Trade{QuoteToken: asset, EstimatedUSDPrice: 1000, Volume: 1, Time: beginTime.Add(2 * time.Second)})
+	if value := filter.FinalCompute(beginTime.Add(4 * blockSize)); value != 380 {
+		t.Errorf("expected 380 after late trade, got %v", value)
+	}
+	// Windows without trades keep the last value.
+	if value := filter.FinalCompute(beginTime.Add(6 * blockSize)); value != 380 {
+		t.Errorf("expected last value 380 for empty window, got %v", value)
+	}
+}
+
+func TestFilterWindowTooSmall(t *testing.T) {
+	_, err := NewFilterFromSpec(FilterSpec{Type: "VWAP", Param: dia.BlockSizeSeconds, Window: dia.BlockSizeSeconds - 1}, dia.Asset{}, "", time.Now())
+	if err == nil {
+		t.Error("expected error for window smaller than block size")
+	}
+}
@@ -34,12 +34,18 @@ type filtersAsset struct {
 	Source     string
 }
 
+// tradesBlockRequest is a tradesBlock to be processed. If @done is not nil, it is closed once the block is processed.
+type tradesBlockRequest struct {
+	tb   *dia.TradesBlock
+	done chan nothing
+}
+
 // FiltersBlockService is the data structure containing all objects
 // necessary for the processing of a tradesBlock.
 type FiltersBlockService struct {
 	shutdown         chan nothing
 	shutdownDone     chan nothing
-	chanTradesBlock  chan *dia.TradesBlock
+	chanTradesBlock  chan tradesBlockRequest
 	chanFiltersBlock chan *dia.FiltersBlock
 	errorLock        sync.RWMutex
 	error            error
@@ -51,15 +57,40 @@ type FiltersBlockService struct {
 	calculationValues    []int
 	previousBlockFilters []dia.FilterPoint
 	datastore            models.Datastore
+	filtersConfig        *FiltersConfig
+	// blockStates holds the filter states at the end of the last maxBlockStates blocks for their correction.
+	blockStates []blockState
+}
+
+// maxBlockStates is the number of processed blocks which can be corrected.
+const maxBlockStates = 30
+
+// blockState is the state of the filters at the end of a processed tradesBlock.
+type blockState struct {
+	endTime time.Time
+	filters map[filtersAsset][]filterState
+}
+
+// filterState is the state of a filter at the end of a block.
+// @time is the time of the point written for the block. It is before the block's begin time if the
+// asset was not traded in the block. @lastTrade and @value are carried over by seedable filters.
+type filterState struct {
+	time      time.Time
+	lastTrade dia.Trade
+	value     float64
 }
 
 // NewFiltersBlockService returns a new FiltersBlockService and
 // runs mainLoop() in a go routine.
-func NewFiltersBlockService(previousBlockFilters []dia.FilterPoint, datastore models.Datastore, chanFiltersBlock chan *dia.FiltersBlock) *FiltersBlockService {
+// @filtersConfig determines the filters computed per asset. If nil, DefaultFiltersConfig() is used.
+func NewFiltersBlockService(previousBlockFilters []dia.FilterPoint, datastore models.Datastore, chanFiltersBlock chan *dia.FiltersBlock, filtersConfig *FiltersConfig) *FiltersBlockService {
+	if filtersConfig == nil {
+		filtersConfig = DefaultFiltersConfig()
+	}
 	s := &FiltersBlockService{
 		shutdown:             make(chan nothing),
 		shutdownDone:         make(chan nothing),
-		chanTradesBlock:      make(chan *dia.TradesBlock),
+		chanTradesBlock:      make(chan tradesBlockRequest),
 		chanFiltersBlock:     chanFiltersBlock,
 		error:                nil,
 		started:              false,
@@ -68,6 +99,7 @@ func NewFiltersBlockService(previousBlockFilters []dia.FilterPoint, datastore mo
 		calculationValues:    make([]int, 0),
 		previousBlockFilters: previousBlockFilters,
 		datastore:            datastore,
+		filtersConfig:        filtersConfig,
 	}
 	s.calculationValues = append(s.calculationValues, dia.BlockSizeSeconds)
 
@@ -84,9 +116,12 @@ func (s *FiltersBlockService) mainLoop() {
 			log.Println("Filters shutting down")
 			s.cleanup(nil)
 			return
-		case tb, ok := <-s.chanTradesBlock:
+		case r, ok := <-s.chanTradesBlock:
 			log.Info("receive tradesBlock for further processing ok: ", ok)
-			s.processTradesBlock(tb)
+			s.processTradesBlock(r.tb)
+			if r.done != nil {
+				close(r.done)
+			}
 		}
 	}
 }
@@ -95,36 +130,71 @@ func (s *FiltersBlockService) mainLoop() {
 // computations are done here.
 func (s *FiltersBlockService) processTradesBlock(tb *dia.TradesBlock) {
 
+	if tb.Correction {
+		s.processCorrectionBlock(tb)
+		return
+	}
+
 	log.Infoln("processTradesBlock starting")
 	t0 := time.Now()
 
+	// Identifiers of stablecoins with depegged trades in the block.
+	depegged := make(map[string]struct{})
+	// Assets traded in the block, across all exchanges and per exchange.
+	traded := make(map[filtersAsset]struct{})
 	for _, trade := range tb.TradesBlockData.Trades {
-		s.createFilters(trade.QuoteToken, "", tb.TradesBlockData.BeginTime)
-		s.createFilters(trade.QuoteToken, trade.Source, tb.TradesBlockData.BeginTime)
-		s.computeFilters(trade, "")
-		s.computeFilters(trade, trade.Source)
+		// Trades deviating from their foreign reference price are kept in the tradesBlock for analysis,
+		// but do not enter the filters.
+		if trade.ReferenceDeviant {
+			continue
+		}
+		if trade.Depegged {
+			depegged[getIdentifier(trade.QuoteToken)] = struct{}{}
+		}
+		traded[filtersAsset{Identifier: getIdentifier(trade.QuoteToken)}] = struct{}{}
+		traded[filtersAsset{Identifier: getIdentifier(trade.QuoteToken), Source: trade.Source}] = struct{}{}
+		s.createFilters(s.filters, trade.QuoteToken, "", tb.TradesBlockData.BeginTime)
+		s.createFilters(s.filters, trade.QuoteToken, trade.Source, tb.TradesBlockData.BeginTime)
+		computeFilters(s.filters, trade, "")
+		computeFilters(s.filters, trade, trade.Source)
 	}
 
 	log.Info("time spent for create and compute filters: ", time.Since(t0))
 	log.Info("filter begin time: ", tb.TradesBlockData.BeginTime)
 	resultFilters := []dia.FilterPoint{}
+	publishedFilters := []dia.FilterPoint{}
 
 	t0 = time.Now()
 
-	for _, filters := range s.filters {
+	for fa, filters := range s.filters {
 		for _, f := range filters {
-			f.finalCompute(tb.TradesBlockData.EndTime)
-			fp := f.filterPointForBlock()
-			if fp != nil {
+			f.FinalCompute(tb.TradesBlockData.EndTime)
+			fp := f.FilterPointForBlock()
+			if fp == nil {
+				continue
+			}
+			// The primary filter across all exchanges is forwarded in the filtersBlock for all assets.
+			if fa.Source == "" && s.filtersConfig.IsPrimary(fa.Identifier, fp.Name) {
+				if _, ok := depegged[fa.Identifier]; ok {
+					fp.Depegged = true
+				}
 				resultFilters = append(resultFilters, *fp)
+				continue
+			}
+			// Published filters are forwarded for assets traded in the block.
+			if _, ok := traded[fa]; ok && s.filtersConfig.Published(fp.Name) {
+				fp.Source = fa.Source
+				publishedFilters = append(publishedFilters, *fp)
 			}
 		}
 	}
 	log.Info("time spent for final compute: ", time.Since(t0))
+	s.saveBlockState(tb.TradesBlockData.EndTime, traded)
 
 	resultFilters = addMissingPoints(s.previousBlockFilters, resultFilters)
 
 	s.previousBlockFilters = resultFilters
+	resultFilters = append(resultFilters, publishedFilters...)
 
 	fb := &dia.FiltersBlock{
 		FiltersBlockData: dia.FiltersBlockData{
@@ -151,7 +221,7 @@ func (s *FiltersBlockService) processTradesBlock(tb *dia.TradesBlock) {
 	t0 = time.Now()
 	for _, filters := range s.filters {
 		for _, f := range filters {
-			err = f.save(s.datastore)
+			err = f.Save(s.datastore)
 			if err != nil {
 				log.Error(err)
 			}
@@ -159,7 +229,112 @@ func (s *FiltersBlockService) processTradesBlock(tb *dia.TradesBlock) {
 	}
 	log.Info("time spent for save filters: ", time.Since(t0))
 
-	err = s.datastore.ExecuteRedisPipe()
+	s.flushDatastore()
+}
+
+// processCorrectionBlock recomputes the filters of a tradesBlock to which late trades were added
+// after it was processed and overwrites the filter points stored for the block.
+// The filters are computed from the trades of the block only, so that the filters of regular blocks are not affected.
+// Moving averages are seeded with their state at the end of the previous block.
+// Points are written to the filters table at the times of the original points. Quotations are not written,
+// as they hold the latest prices used by the tradesBlockService.
+// Filters over sliding windows are not recomputed. Instead, the late trades are added to their windows.
+func (s *FiltersBlockService) processCorrectionBlock(tb *dia.TradesBlock) {
+	log.Infof("recompute filters for correction of tradesBlock %v -- %v", tb.TradesBlockData.BeginTime, tb.TradesBlockData.EndTime)
+	previous := s.getBlockState(tb.TradesBlockData.BeginTime)
+	original := s.getBlockState(tb.TradesBlockData.EndTime)
+	if original == nil {
+		log.Warnf("no state of tradesBlock %v -- %v. Correction points are written at its end time.", tb.TradesBlockData.BeginTime, tb.TradesBlockData.EndTime)
+	}
+
+	filters := make(map[filtersAsset][]Filter)
+	for _, trade := range tb.TradesBlockData.Trades {
+		if trade.ReferenceDeviant {
+			continue
+		}
+		s.createSeededFilters(filters, trade.QuoteToken, "", tb.TradesBlockData.BeginTime, previous)
+		s.createSeededFilters(filters, trade.QuoteToken, trade.Source, tb.TradesBlockData.BeginTime, previous)
+		computeFilters(filters, trade, "")
+		computeFilters(filters, trade, trade.Source)
+		addLateTrade(s.filters, trade, "")
+		addLateTrade(s.filters, trade, trade.Source)
+	}
+	for fa, assetFilters := range filters {
+		for i, f := range assetFilters {
+			if _, ok := f.(*FilterWindow); ok {
+				continue
+			}
+			f.FinalCompute(tb.TradesBlockData.EndTime)
+			fp := f.FilterPointForBlock()
+			if fp == nil {
+				continue
+			}
+			t := tb.TradesBlockData.EndTime
+			if original != nil {
+				if states := original.filters[fa]; i < len(states) && !states[i].time.Before(tb.TradesBlockData.BeginTime) {
+					t = states[i].time
+				}
+			}
+			err := s.datastore.SetFilter(fp.Name, fp.Asset, fa.Source, fp.Value, t)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+	s.flushDatastore()
+}
+
+// saveBlockState keeps the state of the filters at the end of the block ending at @endTime.
+// The states of assets not @traded in the block are taken over from the previous block.
+func (s *FiltersBlockService) saveBlockState(endTime time.Time, traded map[filtersAsset]struct{}) {
+	state := blockState{
+		endTime: endTime,
+		filters: make(map[filtersAsset][]filterState),
+	}
+	var last *blockState
+	if len(s.blockStates) > 0 {
+		last = &s.blockStates[len(s.blockStates)-1]
+	}
+	for fa, filters := range s.filters {
+		if _, ok := traded[fa]; !ok && last != nil {
+			if states, ok := last.filters[fa]; ok {
+				state.filters[fa] = states
+				continue
+			}
+		}
+		states := make([]filterState, len(filters))
+		for i, f := range filters {
+			fp := f.FilterPointForBlock()
+			if fp == nil {
+				continue
+			}
+			states[i].time = fp.Time
+			if sf, ok := f.(seedable); ok {
+				states[i].lastTrade = sf.carriedTrade()
+				states[i].value = fp.Value
+			}
+		}
+		state.filters[fa] = states
+	}
+	s.blockStates = append(s.blockStates, state)
+	if len(s.blockStates) > maxBlockStates {
+		s.blockStates = s.blockStates[len(s.blockStates)-maxBlockStates:]
+	}
+}
+
+// getBlockState returns the state of the filters at the end of the block ending at @endTime,
+// or nil if the block is not retained.
+func (s *FiltersBlockService) getBlockState(endTime time.Time) *blockState {
+	for i := range s.blockStates {
+		if s.blockStates[i].endTime.Equal(endTime) {
+			return &s.blockStates[i]
+		}
+	}
+	return nil
+}
+
+func (s *FiltersBlockService) flushDatastore() {
+	err := s.datastore.ExecuteRedisPipe()
 	if err != nil {
 		log.Error("execute redis pipe: ", err)
 	}
@@ -173,34 +348,71 @@ func (s *FiltersBlockService) processTradesBlock(tb *dia.TradesBlock) {
 	if err != nil {
 		log.Error("flush influx batch: ", err)
 	}
-
 }
 
-func (s *FiltersBlockService) createFilters(asset dia.Asset, exchange string, BeginTime time.Time) {
+// createFilters adds the filters for @asset on @exchange to @filters if not present yet.
+func (s *FiltersBlockService) createFilters(filters map[filtersAsset][]Filter, asset dia.Asset, exchange string, BeginTime time.Time) {
 	fa := filtersAsset{
 		Identifier: getIdentifier(asset),
 		Source:     exchange,
 	}
-	_, ok := s.filters[fa]
+	_, ok := filters[fa]
 	if !ok {
-		s.filters[fa] = []Filter{
-			NewFilterMA(asset, exchange, BeginTime, dia.BlockSizeSeconds),
-			NewFilterMAIR(asset, exchange, BeginTime, dia.BlockSizeSeconds),
-			NewFilterMEDIR(asset, exchange, BeginTime, dia.BlockSizeSeconds),
-			NewFilterVOL(asset, exchange, dia.BlockSizeSeconds),
-			NewFilterCOUNT(asset, exchange, dia.BlockSizeSeconds),
-			NewFilterTLT(asset, exchange),
+		var assetFilters []Filter
+		for _, spec := range s.filtersConfig.FiltersForAsset(asset) {
+			f, err := NewFilterFromSpec(spec, asset, exchange, BeginTime)
+			if err != nil {
+				log.Error("create filter: ", err)
+				continue
+			}
+			assetFilters = append(assetFilters, f)
 		}
+		filters[fa] = assetFilters
 	}
 }
 
-func (s *FiltersBlockService) computeFilters(t dia.Trade, exchange string) {
+// createSeededFilters adds the filters for @asset on @exchange to @filters if not present yet
+// and seeds them with their state in @previous.
+func (s *FiltersBlockService) createSeededFilters(filters map[filtersAsset][]Filter, asset dia.Asset, exchange string, BeginTime time.Time, previous *blockState) {
+	fa := filtersAsset{
+		Identifier: getIdentifier(asset),
+		Source:     exchange,
+	}
+	if _, ok := filters[fa]; ok {
+		return
+	}
+	s.createFilters(filters, asset, exchange, BeginTime)
+	if previous == nil {
+		return
+	}
+	states := previous.filters[fa]
+	for i, f := range filters[fa] {
+		if sf, ok := f.(seedable); ok && i < len(states) {
+			sf.seed(states[i].lastTrade, states[i].value)
+		}
+	}
+}
+
+func computeFilters(filters map[filtersAsset][]Filter, t dia.Trade, exchange string) {
 	fa := filtersAsset{
 		Identifier: getIdentifier(t.QuoteToken),
 		Source:     exchange,
 	}
-	for _, f := range s.filters[fa] {
-		f.compute(t)
+	for _, f := range filters[fa] {
+		f.Compute(t)
+	}
+}
+
+// addLateTrade adds the late trade @t to the sliding windows of the filters for its asset on @exchange.
+func addLateTrade(filters map[filtersAsset][]Filter, t dia.Trade, exchange string) {
+	fa := filtersAsset{
+		Identifier: getIdentifier(t.QuoteToken),
+		Source:     exchange,
+	}
+	for _, f := range filters[fa] {
+		if w, ok := f.(*FilterWindow); ok {
+			w.AddLateTrade(t)
+		}
 	}
 }
 
@@ -246,10 +458,18 @@ func addMissingPoints(previousBlockFilters []dia.FilterPoint, newFilters []dia.F
 
 // ProcessTradesBlock sends a filled tradesBlock into the filtersBlock channel.
 func (s *FiltersBlockService) ProcessTradesBlock(tradesBlock *dia.TradesBlock) {
-	s.chanTradesBlock <- tradesBlock
+	s.chanTradesBlock <- tradesBlockRequest{tb: tradesBlock}
 	log.Info("Processing TradesBlock done.")
 }
 
+// ProcessTradesBlockSync processes @tradesBlock and returns once the filters are stored
+// and the resulting filtersBlock is handed on.
+func (s *FiltersBlockService) ProcessTradesBlockSync(tradesBlock *dia.TradesBlock) {
+	done := make(chan nothing)
+	s.chanTradesBlock <- tradesBlockRequest{tb: tradesBlock, done: done}
+	<-done
+}
+
 // Close gracefully closes the Filtersblockservice
 func (s *FiltersBlockService) Close() error {
 	if s.closed {
@@ -0,0 +1,211 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+func TestFiltersBlockServiceMemoryDatastore(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	beginTime := time.Unix(1672531200, 0)
+	endTime := beginTime.Add(time.Duration(dia.BlockSizeSeconds) * time.Second)
+
+	var trades []dia.Trade
+	for i, price := range []float64{99, 100, 101} {
+		trades = append(trades, dia.Trade{
+			QuoteToken:        asset,
+			Symbol:            asset.Symbol,
+			Price:             price,
+			EstimatedUSDPrice: price,
+			Volume:            1,
+			Source:            dia.BinanceExchange,
+			Time:              beginTime.Add(time.Duration(i+1) * time.Second),
+		})
+	}
+	tb := &dia.TradesBlock{
+		TradesBlockData: dia.TradesBlockData{
+			Trades:       trades,
+			TradesNumber: len(trades),
+			BeginTime:    beginTime,
+			EndTime:      endTime,
+		},
+	}
+
+	ds := models.NewMemoryDataStore()
+	fbs := NewFiltersBlockService(nil, ds, nil, nil)
+	fbs.ProcessTradesBlock(tb)
+	// Close returns after the tradesBlock is processed and all filters are saved.
+	if err := fbs.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	price, err := ds.GetAssetPriceUSDLatest(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price < 99 || price > 101 {
+		t.Errorf("expected price in range of trades, got %v", price)
+	}
+
+	points, err := ds.GetFilterPointsAsset(dia.FilterKing, dia.BinanceExchange, asset.Address, asset.Blockchain, beginTime, endTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points.DataPoints) == 0 || len(points.DataPoints[0].Series) == 0 || len(points.DataPoints[0].Series[0].Values) == 0 {
+		t.Errorf("no %s filter points stored for exchange %s", dia.FilterKing, dia.BinanceExchange)
+	}
+}
+
+func TestFiltersBlockServicePublishedFilters(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	beginTime := time.Unix(1672531200, 0)
+	tb := &dia.TradesBlock{
+		TradesBlockData: dia.TradesBlockData{
+			Trades: []dia.Trade{{
+				QuoteToken:        asset,
+				Symbol:            asset.Symbol,
+				Price:             100,
+				EstimatedUSDPrice: 100,
+				Volume:            1,
+				Source:            dia.BinanceExchange,
+				Time:              beginTime.Add(time.Second),
+			}},
+			TradesNumber: 1,
+			BeginTime:    beginTime,
+			EndTime:      beginTime.Add(time.Duration(dia.BlockSizeSeconds) * time.Second),
+		},
+	}
+
+	config := DefaultFiltersConfig()
+	config.Default = append(config.Default, FilterSpec{Type: "VWAP", Param: dia.BlockSizeSeconds, Window: 5 * dia.BlockSizeSeconds})
+	config.Publish = []string{"VWAP600"}
+	channel := make(chan *dia.FiltersBlock, 1)
+	fbs := NewFiltersBlockService(nil, models.NewMemoryDataStore(), channel, config)
+	fbs.ProcessTradesBlockSync(tb)
+	fb := <-channel
+
+	sources := make(map[string]bool)
+	for _, fp := range fb.FiltersBlockData.FilterPoints {
+		if fp.Name == "VWAP600" {
+			sources[fp.Source] = true
+		}
+	}
+	if !sources[""] || !sources[dia.BinanceExchange] || len(sources) != 2 {
+		t.Errorf("expected VWAP600 across all exchanges and on %s, got sources %v", dia.BinanceExchange, sources)
+	}
+}
+
+func TestFiltersBlockServiceReferenceDeviant(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	beginTime := time.Unix(1672531200, 0)
+	var trades []dia.Trade
+	for i, price := range []float64{100, 100, 1000} {
+		trades = append(trades, dia.Trade{
+			QuoteToken:        asset,
+			Symbol:            asset.Symbol,
+			Price:             price,
+			EstimatedUSDPrice: price,
+			Volume:            1,
+			Source:            dia.BinanceExchange,
+			Time:              beginTime.Add(time.Duration(i+1) * time.Second),
+			ReferenceDeviant:  price == 1000,
+		})
+	}
+	tb := &dia.TradesBlock{
+		TradesBlockData: dia.TradesBlockData{
+			Trades:       trades,
+			TradesNumber: len(trades),
+			BeginTime:    beginTime,
+			EndTime:      beginTime.Add(time.Duration(dia.BlockSizeSeconds) * time.Second),
+		},
+	}
+
+	channel := make(chan *dia.FiltersBlock, 1)
+	fbs := NewFiltersBlockService(nil, models.NewMemoryDataStore(), channel, nil)
+	fbs.ProcessTradesBlockSync(tb)
+	fb := <-channel
+
+	for _, fp := range fb.FiltersBlockData.FilterPoints {
+		if fp.Name == dia.FilterKing && fp.Value != 100 {
+			t.Errorf("expected %s of 100 without the deviant trade, got %v", dia.FilterKing, fp.Value)
+		}
+	}
+}
+
+func TestFiltersBlockServiceCorrection(t *testing.T) {
+	asset := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	blockSize := time.Duration(dia.BlockSizeSeconds) * time.Second
+	beginTime := time.Unix(1672531200, 0)
+	newBlock := func(begin time.Time, prices []float64, correction bool) *dia.TradesBlock {
+		var trades []dia.Trade
+		for i, price := range prices {
+			trades = append(trades, dia.Trade{
+				QuoteToken:        asset,
+				Symbol:            asset.Symbol,
+				Price:             price,
+				EstimatedUSDPrice: price,
+				Volume:            1,
+				Source:            dia.BinanceExchange,
+				Time:              begin.Add(time.Duration(10*(i+1)) * time.Second),
+			})
+		}
+		return &dia.TradesBlock{
+			TradesBlockData: dia.TradesBlockData{
+				Trades:       trades,
+				TradesNumber: len(trades),
+				BeginTime:    begin,
+				EndTime:      begin.Add(blockSize),
+			},
+			Correction: correction,
+		}
+	}
+
+	ds := models.NewMemoryDataStore()
+	fbs := NewFiltersBlockService(nil, ds, nil, nil)
+	fbs.ProcessTradesBlockSync(newBlock(beginTime, []float64{100}, false))
+	fbs.ProcessTradesBlockSync(newBlock(beginTime.Add(blockSize), []float64{110}, false))
+	fbs.ProcessTradesBlockSync(newBlock(beginTime.Add(2*blockSize), []float64{120}, false))
+
+	points := func() [][]interface{} {
+		p, err := ds.GetFilterPointsAsset(dia.FilterKing, "", asset.Address, asset.Blockchain, beginTime.Add(blockSize), beginTime.Add(2*blockSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.DataPoints) == 0 || len(p.DataPoints[0].Series) == 0 {
+			return nil
+		}
+		return p.DataPoints[0].Series[0].Values
+	}
+	original := points()
+	if len(original) != 1 {
+		t.Fatalf("expected one %s point for the second block, got %v", dia.FilterKing, original)
+	}
+
+	// The second block is corrected by a late trade at its begin.
+	fbs.ProcessTradesBlockSync(newBlock(beginTime.Add(blockSize), []float64{90, 110}, true))
+	if err := fbs.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	corrected := points()
+	if len(corrected) != 1 {
+		t.Fatalf("expected the correction to overwrite the %s point of the second block, got %v", dia.FilterKing, corrected)
+	}
+	if corrected[0][0] != original[0][0] {
+		t.Errorf("expected the corrected point at %v, got %v", original[0][0], corrected[0][0])
+	}
+	if corrected[0][6] == original[0][6] {
+		t.Errorf("expected the late trade to change the value %v", original[0][6])
+	}
+
+	price, err := ds.GetAssetPriceUSDLatest(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != 120 {
+		t.Errorf("expected the correction to leave the latest price of 120, got %v", price)
+	}
+}
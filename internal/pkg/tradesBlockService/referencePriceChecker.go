@@ -0,0 +1,116 @@
+package tradesBlockService
+
+import (
+	"expvar"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+const (
+	// DefaultReferenceDeviation is the default maximal relative deviation of a trade's estimated USD price
+	// from the foreign quotation of its quote token.
+	DefaultReferenceDeviation = float64(0.2)
+	// referenceRefresh is the time a foreign quotation is reused before it is fetched again.
+	referenceRefresh = 2 * time.Minute
+	// referenceMaxAge is the maximal age of a foreign quotation w.r.t. the trade time to be used as reference.
+	referenceMaxAge = time.Hour
+)
+
+// Counters of trades checked against foreign quotations and of trades deviating from them, keyed by exchange.
+// They are published by expvar under /debug/vars.
+var (
+	referenceCheckedTrades   = expvar.NewMap("referenceCheckedTrades")
+	referenceDeviatingTrades = expvar.NewMap("referenceDeviatingTrades")
+)
+
+// ReferencePriceChecker compares estimated USD prices of trades with the latest foreign quotation,
+// such as from Coingecko or CoinMarketCap, of the trade's quote token.
+// Trades deviating by more than maxDeviation are rejected or, in tag mode, flagged as ReferenceDeviant.
+// Only quote tokens mapped onto a foreign ID by blockchain and address are checked, as symbols are ambiguous.
+type ReferencePriceChecker struct {
+	datastore    models.Datastore
+	source       string
+	foreignIDs   map[string]string
+	maxDeviation float64
+	tagOnly      bool
+	quotations   map[string]models.ForeignQuotation
+	fetched      map[string]time.Time
+	mu           sync.Mutex
+}
+
+// NewReferencePriceChecker returns a checker using foreign quotations from @source stored in @datastore
+// for the assets in @assets. If @source is empty, nil is returned and no trade is checked.
+func NewReferencePriceChecker(datastore models.Datastore, source string, assets []models.ReferenceAsset, maxDeviation float64, tagOnly bool) *ReferencePriceChecker {
+	if source == "" {
+		return nil
+	}
+	foreignIDs := make(map[string]string)
+	for _, asset := range assets {
+		foreignIDs[asset.Asset.Identifier()] = asset.ForeignID
+	}
+	return &ReferencePriceChecker{
+		datastore:    datastore,
+		source:       source,
+		foreignIDs:   foreignIDs,
+		maxDeviation: maxDeviation,
+		tagOnly:      tagOnly,
+		quotations:   make(map[string]models.ForeignQuotation),
+		fetched:      make(map[string]time.Time),
+	}
+}
+
+// Check returns false if the estimated USD price of @t deviates from the foreign quotation
+// of its quote token by more than maxDeviation and @t should be discarded.
+// Trades of unmapped quote tokens or without recent foreign quotation pass the check.
+func (c *ReferencePriceChecker) Check(t *dia.Trade) bool {
+	if c == nil {
+		return true
+	}
+	foreignID, ok := c.foreignIDs[t.QuoteToken.Identifier()]
+	if !ok {
+		return true
+	}
+	quotation, ok := c.getQuotation(foreignID, t.Time)
+	if !ok {
+		return true
+	}
+	referenceCheckedTrades.Add(t.Source, 1)
+
+	deviation := math.Abs(t.EstimatedUSDPrice-quotation.Price) / quotation.Price
+	if deviation <= c.maxDeviation {
+		return true
+	}
+	referenceDeviatingTrades.Add(t.Source, 1)
+	log.Warnf("price of %s on %s deviates from %s quotation by %v: %v vs. %v", t.Pair, t.Source, c.source, deviation, t.EstimatedUSDPrice, quotation.Price)
+	if c.tagOnly {
+		t.ReferenceDeviant = true
+		return true
+	}
+	return false
+}
+
+// getQuotation returns the latest foreign quotation of @symbol before @timestamp.
+// Quotations are reused for referenceRefresh and only returned if not older than referenceMaxAge.
+func (c *ReferencePriceChecker) getQuotation(symbol string, timestamp time.Time) (models.ForeignQuotation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fetched, ok := c.fetched[symbol]
+	if !ok || timestamp.Sub(fetched) > referenceRefresh || fetched.Sub(timestamp) > referenceRefresh {
+		quotation, err := c.datastore.GetForeignQuotationInflux(symbol, c.source, timestamp)
+		if err != nil {
+			log.Debugf("no %s quotation for %s: %v", c.source, symbol, err)
+		}
+		// Failed lookups are cached as well in order not to query the datastore for each trade.
+		c.quotations[symbol] = quotation
+		c.fetched[symbol] = timestamp
+	}
+	quotation := c.quotations[symbol]
+	if quotation.Price <= 0 || timestamp.Sub(quotation.Time) > referenceMaxAge {
+		return models.ForeignQuotation{}, false
+	}
+	return quotation, true
+}
@@ -0,0 +1,71 @@
+package tradesBlockService
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+func TestReferencePriceChecker(t *testing.T) {
+	now := time.Now()
+	datastore := models.NewMemoryDataStore()
+	err := datastore.SaveForeignQuotationInflux(models.ForeignQuotation{Symbol: "ETH", Source: "Coingecko", Price: 1000, Time: now.Add(-time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = datastore.SaveForeignQuotationInflux(models.ForeignQuotation{Symbol: "BTC", Source: "Coingecko", Price: 20000, Time: now.Add(-2 * referenceMaxAge)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eth := dia.Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: dia.ETHEREUM}
+	btc := dia.Asset{Symbol: "BTC", Address: "0x0000000000000000000000000000000000000000", Blockchain: dia.BITCOIN}
+	// Same symbol as ETH, but a different asset without foreign quotation.
+	fakeETH := dia.Asset{Symbol: "ETH", Address: "0x1111111111111111111111111111111111111111", Blockchain: dia.ETHEREUM}
+	assets := []models.ReferenceAsset{{Asset: eth, ForeignID: "ETH"}, {Asset: btc, ForeignID: "BTC"}}
+
+	cases := []struct {
+		asset    dia.Asset
+		price    float64
+		tagOnly  bool
+		valid    bool
+		deviant  bool
+		exchange string
+	}{
+		{asset: eth, price: 1100, valid: true, exchange: "Binance"},
+		{asset: eth, price: 1500, valid: false, exchange: "BrokenExchange"},
+		{asset: eth, price: 1500, tagOnly: true, valid: true, deviant: true, exchange: "BrokenExchange"},
+		// Outdated foreign quotation.
+		{asset: btc, price: 1, valid: true, exchange: "Binance"},
+		// Unmapped asset.
+		{asset: fakeETH, price: 1, valid: true, exchange: "Binance"},
+	}
+
+	for i, c := range cases {
+		checker := NewReferencePriceChecker(datastore, "Coingecko", assets, DefaultReferenceDeviation, c.tagOnly)
+		trade := dia.Trade{
+			QuoteToken:        c.asset,
+			EstimatedUSDPrice: c.price,
+			Source:            c.exchange,
+			Time:              now,
+		}
+		if valid := checker.Check(&trade); valid != c.valid {
+			t.Errorf("case %d: expected valid %v, got %v", i, c.valid, valid)
+		}
+		if trade.ReferenceDeviant != c.deviant {
+			t.Errorf("case %d: expected deviant %v, got %v", i, c.deviant, trade.ReferenceDeviant)
+		}
+	}
+
+	if deviating := referenceDeviatingTrades.Get("BrokenExchange"); deviating == nil || deviating.String() != "2" {
+		t.Errorf("expected 2 deviating trades for BrokenExchange, got %v", deviating)
+	}
+	if deviating := referenceDeviatingTrades.Get("Binance"); deviating != nil {
+		t.Errorf("expected no deviating trades for Binance, got %v", deviating)
+	}
+	if NewReferencePriceChecker(datastore, "", assets, DefaultReferenceDeviation, false).Check(&dia.Trade{}) != true {
+		t.Error("expected disabled checker to accept trades")
+	}
+}
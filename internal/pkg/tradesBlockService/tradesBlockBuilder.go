@@ -0,0 +1,253 @@
+package tradesBlockService
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cnf/structhash"
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+// TradesBlockBuilder assembles trades with estimated USD price into consecutive tradesBlocks
+// of fixed duration. It is used by the TradesBlockService and by tools replaying stored trades.
+//
+// Blocks are kept open until the watermark, i.e. the latest trade time seen minus the allowed lateness,
+// passes their end time. Hence, trades arriving late by at most the allowed lateness are merged into their block.
+// Finalised blocks are retained for the correction window. Later trades falling into a retained block
+// are added to it and the block is emitted again as correction block. All other late trades are ignored.
+type TradesBlockBuilder struct {
+	blockDuration    int64
+	allowedLateness  time.Duration
+	correctionWindow time.Duration
+	// isCentralized returns true if trades from @source must be checked for duplicates.
+	isCentralized func(source string) bool
+	// dedup records trades of centralized exchanges of emitted blocks. If nil, duplicates are only detected within a block.
+	// Trades are only recorded once their block is emitted, so that trades of blocks lost in a restart can be reprocessed.
+	dedup dia.DedupStore
+	// openBlocks and retainedBlocks are keyed by the begin time of the block in unix seconds.
+	openBlocks     map[int64]*blockInProgress
+	retainedBlocks map[int64]*blockInProgress
+	// finalisedUntil is the end time in unix seconds of the latest finalised block.
+	finalisedUntil int64
+	latestTime     time.Time
+}
+
+type blockInProgress struct {
+	block           *dia.TradesBlock
+	tradeDuplicates map[string]struct{}
+	// corrected is true if late trades were added to the block after it was finalised.
+	corrected bool
+}
+
+// NewTradesBlockBuilder returns a builder for tradesBlocks of @blockDuration seconds.
+// Blocks are finalised once the latest trade time exceeds their end by @allowedLateness
+// and finalised blocks can be corrected by late trades during @correctionWindow.
+// Trades from sources for which @isCentralized returns true are deduplicated using @dedup, or within a block if @dedup is nil.
+func NewTradesBlockBuilder(blockDuration int64, allowedLateness time.Duration, correctionWindow time.Duration, isCentralized func(source string) bool, dedup dia.DedupStore) *TradesBlockBuilder {
+	return &TradesBlockBuilder{
+		blockDuration:    blockDuration,
+		allowedLateness:  allowedLateness,
+		correctionWindow: correctionWindow,
+		isCentralized:    isCentralized,
+		dedup:            dedup,
+		openBlocks:       make(map[int64]*blockInProgress),
+		retainedBlocks:   make(map[int64]*blockInProgress),
+	}
+}
+
+// Watermark returns the time up to which blocks are finalised.
+func (b *TradesBlockBuilder) Watermark() time.Time {
+	return b.latestTime.Add(-b.allowedLateness)
+}
+
+// IsLate returns true if @t belongs to a block which is already finalised.
+func (b *TradesBlockBuilder) IsLate(t dia.Trade) bool {
+	return b.blockBegin(t) < b.finalisedUntil
+}
+
+// AddTrade adds @t to its block and returns the blocks finalised by the resulting watermark,
+// followed by the correction blocks of retained blocks to which late trades were added.
+// Trades too late for the correction window are ignored.
+func (b *TradesBlockBuilder) AddTrade(t dia.Trade) (finalised []*dia.TradesBlock) {
+	begin := b.blockBegin(t)
+	if !b.IsLate(t) {
+		bp, ok := b.openBlocks[begin]
+		if !ok {
+			bp = b.newBlock(begin)
+			b.openBlocks[begin] = bp
+		}
+		b.add(bp, t)
+	} else if time.Unix(begin+b.blockDuration, 0).After(b.Watermark().Add(-b.correctionWindow)) {
+		bp, ok := b.retainedBlocks[begin]
+		if !ok {
+			bp = b.newBlock(begin)
+			b.retainedBlocks[begin] = bp
+		}
+		if b.add(bp, t) {
+			bp.corrected = true
+			log.Debugf("late trade added to finalised block %v: %v", bp.block.TradesBlockData.BeginTime, t)
+		}
+	} else {
+		log.Debugf("ignore trade too late for its block %v", t)
+	}
+
+	if t.Time.After(b.latestTime) {
+		b.latestTime = t.Time
+	}
+	return b.advance(false)
+}
+
+// Finalise finalises all open blocks and returns them, followed by pending correction blocks.
+// Returns nil if there are no such blocks.
+func (b *TradesBlockBuilder) Finalise() []*dia.TradesBlock {
+	return b.advance(true)
+}
+
+// advance finalises all open blocks whose end time is passed by the watermark, or all open blocks if @all is true.
+// Retained blocks outside the correction window are dropped.
+func (b *TradesBlockBuilder) advance(all bool) (finalised []*dia.TradesBlock) {
+	watermark := b.Watermark()
+	for _, begin := range sortedBegins(b.openBlocks) {
+		bp := b.openBlocks[begin]
+		end := begin + b.blockDuration
+		if !all && time.Unix(end, 0).After(watermark) {
+			break
+		}
+		delete(b.openBlocks, begin)
+		finalised = append(finalised, bp.finalise())
+		b.record(bp)
+		log.Info("finalised block beginTime: ", bp.block.TradesBlockData.BeginTime, " nb trades: ", len(bp.block.TradesBlockData.Trades))
+		if end > b.finalisedUntil {
+			b.finalisedUntil = end
+		}
+		if b.correctionWindow > 0 {
+			b.retainedBlocks[begin] = bp
+		}
+	}
+
+	for _, begin := range sortedBegins(b.retainedBlocks) {
+		bp := b.retainedBlocks[begin]
+		if bp.corrected {
+			bp.corrected = false
+			correction := bp.finalise()
+			correction.Correction = true
+			finalised = append(finalised, correction)
+			b.record(bp)
+			log.Info("correction of block beginTime: ", bp.block.TradesBlockData.BeginTime, " nb trades: ", len(correction.TradesBlockData.Trades))
+		}
+		if all || !time.Unix(begin+b.blockDuration, 0).After(watermark.Add(-b.correctionWindow)) {
+			delete(b.retainedBlocks, begin)
+		}
+	}
+	return
+}
+
+func (b *TradesBlockBuilder) blockBegin(t dia.Trade) int64 {
+	return (t.Time.Unix() / b.blockDuration) * b.blockDuration
+}
+
+func (b *TradesBlockBuilder) newBlock(begin int64) *blockInProgress {
+	return &blockInProgress{
+		block: &dia.TradesBlock{
+			TradesBlockData: dia.TradesBlockData{
+				Trades:    []dia.Trade{},
+				BeginTime: time.Unix(begin, 0),
+				EndTime:   time.Unix(begin+b.blockDuration, 0),
+			},
+		},
+		tradeDuplicates: make(map[string]struct{}),
+	}
+}
+
+// add adds @t to @bp and returns true if it was not a duplicate.
+func (b *TradesBlockBuilder) add(bp *blockInProgress, t dia.Trade) bool {
+	// For centralized exchanges check if trade was not seen yet
+	// (we have observed ws APIs sending identical trades).
+	if b.isCentralized != nil && b.isCentralized(t.Source) {
+		if b.isDuplicate(bp, t) {
+			if t.Source != dia.BitforexExchange {
+				log.Warn("duplicate trade: ", t)
+			}
+			return false
+		}
+	}
+	bp.block.TradesBlockData.Trades = append(bp.block.TradesBlockData.Trades, t)
+	return true
+}
+
+func (b *TradesBlockBuilder) isDuplicate(bp *blockInProgress, t dia.Trade) bool {
+	key := t.TradeIdentifierFull()
+	if _, ok := bp.tradeDuplicates[key]; ok {
+		return true
+	}
+	if b.dedup != nil {
+		seen, err := b.dedup.Seen(key)
+		if err != nil {
+			log.Error("dedup store: ", err)
+		}
+		if seen {
+			return true
+		}
+	}
+	bp.tradeDuplicates[key] = struct{}{}
+	return false
+}
+
+// record records the trades of centralized exchanges in @bp in the dedup store.
+func (b *TradesBlockBuilder) record(bp *blockInProgress) {
+	if b.dedup == nil || len(bp.tradeDuplicates) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(bp.tradeDuplicates))
+	for key := range bp.tradeDuplicates {
+		keys = append(keys, key)
+	}
+	err := b.dedup.Record(keys...)
+	if err != nil {
+		log.Error("dedup store: ", err)
+	}
+}
+
+// finalise sorts the trades of the block by time and returns a copy of the block with hash set.
+// A copy is returned, as the block may still be corrected by late trades.
+func (bp *blockInProgress) finalise() *dia.TradesBlock {
+	trades := bp.block.TradesBlockData.Trades
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.Before(trades[j].Time)
+	})
+
+	block := &dia.TradesBlock{
+		TradesBlockData: dia.TradesBlockData{
+			BeginTime:    bp.block.TradesBlockData.BeginTime,
+			EndTime:      bp.block.TradesBlockData.EndTime,
+			TradesNumber: len(trades),
+			Trades:       append([]dia.Trade{}, trades...),
+		},
+	}
+	hash, err := structhash.Hash(block.TradesBlockData, 1)
+	if err != nil {
+		log.Printf("error on hash")
+		hash = "hashError"
+	}
+	block.BlockHash = hash
+	return block
+}
+
+func sortedBegins(blocks map[int64]*blockInProgress) []int64 {
+	begins := make([]int64, 0, len(blocks))
+	for begin := range blocks {
+		begins = append(begins, begin)
+	}
+	sort.Slice(begins, func(i, j int) bool {
+		return begins[i] < begins[j]
+	})
+	return begins
+}
+
+// ValidForBlock returns true if @t, with already estimated USD price, qualifies for a tradesBlock.
+// This is the case for trades of verified pairs with sufficient volume and positive price,
+// except for stablecoin trades whose price diverges too much from their peg according to @stablecoins.
+// In depeg mode, such trades qualify and are flagged as depegged.
+func ValidForBlock(t *dia.Trade, stablecoins *dia.StablecoinChecker) bool {
+	return t.VerifiedPair && checkTrade(*t) && t.EstimatedUSDPrice > 0 && stablecoins.Check(t)
+}
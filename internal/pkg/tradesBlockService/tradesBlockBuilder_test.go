@@ -0,0 +1,67 @@
+package tradesBlockService
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestTradesBlockBuilderLateTrades(t *testing.T) {
+	type emitted struct {
+		begin      int64
+		trades     int
+		correction bool
+	}
+	cases := []struct {
+		name             string
+		allowedLateness  time.Duration
+		correctionWindow time.Duration
+		tradeTimes       []int64
+		// expected contains the blocks emitted after each trade and, as last entry, by Finalise.
+		expected [][]emitted
+	}{
+		{
+			name:       "late trades are dropped",
+			tradeTimes: []int64{10, 130, 50, 250},
+			expected:   [][]emitted{{}, {{0, 1, false}}, {}, {{120, 1, false}}, {{240, 1, false}}},
+		},
+		{
+			name:            "late trades within allowed lateness are merged",
+			allowedLateness: time.Minute,
+			tradeTimes:      []int64{10, 130, 50, 190},
+			expected:        [][]emitted{{}, {}, {}, {{0, 2, false}}, {{120, 2, false}}},
+		},
+		{
+			name:             "late trades within correction window are emitted as correction",
+			correctionWindow: 10 * time.Minute,
+			tradeTimes:       []int64{10, 130, 50, 60, 1000},
+			expected:         [][]emitted{{}, {{0, 1, false}}, {{0, 2, true}}, {{0, 3, true}}, {{120, 1, false}}, {{960, 1, false}}},
+		},
+	}
+
+	for _, c := range cases {
+		builder := NewTradesBlockBuilder(120, c.allowedLateness, c.correctionWindow, nil, nil)
+		for i := 0; i <= len(c.tradeTimes); i++ {
+			var blocks []*dia.TradesBlock
+			if i < len(c.tradeTimes) {
+				blocks = builder.AddTrade(dia.Trade{Time: time.Unix(c.tradeTimes[i], 0), Price: 1})
+			} else {
+				blocks = builder.Finalise()
+			}
+			if len(blocks) != len(c.expected[i]) {
+				t.Errorf("%s: step %d: expected %d blocks, got %d", c.name, i, len(c.expected[i]), len(blocks))
+				continue
+			}
+			for j, block := range blocks {
+				expected := c.expected[i][j]
+				if block.TradesBlockData.BeginTime.Unix() != expected.begin ||
+					block.TradesBlockData.TradesNumber != expected.trades ||
+					block.Correction != expected.correction {
+					t.Errorf("%s: step %d: expected block %v, got begin %d, trades %d, correction %v",
+						c.name, i, expected, block.TradesBlockData.BeginTime.Unix(), block.TradesBlockData.TradesNumber, block.Correction)
+				}
+			}
+		}
+	}
+}
@@ -3,17 +3,14 @@ package tradesBlockService
 import (
 	"errors"
 	"math"
-	"sort"
 	"strconv"
 	"sync"
 	"time"
 
-	"github.com/cnf/structhash"
 	"github.com/diadata-org/diadata/pkg/dia"
 	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
 	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/diadata-org/diadata/pkg/utils"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,63 +29,112 @@ func init() {
 		log.Error("Parse TRADE_VOLUME_THRESHOLD_EXPONENT: ", err)
 	}
 	tradeVolumeThreshold = math.Pow(10, -tradeVolumeThresholdExponent)
+	allowedLatenessSeconds, err = strconv.Atoi(utils.Getenv("ALLOWED_LATENESS_SECONDS", "0"))
+	if err != nil {
+		log.Error("parse ALLOWED_LATENESS_SECONDS: ", err)
+	}
+	correctionWindowSeconds, err = strconv.Atoi(utils.Getenv("CORRECTION_WINDOW_SECONDS", "0"))
+	if err != nil {
+		log.Error("parse CORRECTION_WINDOW_SECONDS: ", err)
+	}
 }
 
 var (
-	stablecoins = map[string]interface{}{
-		"USDC": "",
-		"USDT": "",
-		"TUSD": "",
-		"DAI":  "",
-		"PAX":  "",
-		"BUSD": "",
-	}
-	tol                  = float64(0.04)
 	log                  *logrus.Logger
 	batchTimeSeconds     int
 	tradeVolumeThreshold float64
-	checkTradesDuplicate = make(map[string]struct{})
+	// allowedLatenessSeconds is the time a tradesBlock is kept open for late trades after its end.
+	allowedLatenessSeconds int
+	// correctionWindowSeconds is the time a finalised tradesBlock can be corrected by late trades.
+	correctionWindowSeconds int
 )
 
+// DefaultStablecoinTolerance is the maximal relative deviation of a stablecoin from its peg
+// for stablecoins without configured tolerance.
+const DefaultStablecoinTolerance = float64(0.04)
+
+// tradeMessage is a trade read at @offset of @partition of the trades topic. The offset is negative if it is not tracked.
+type tradeMessage struct {
+	trade     *dia.Trade
+	partition int
+	offset    int64
+}
+
 type TradesBlockService struct {
 	shutdown         chan nothing
 	shutdownDone     chan nothing
-	chanTrades       chan *dia.Trade
+	chanTrades       chan tradeMessage
 	chanTradesBlock  chan *dia.TradesBlock
 	errorLock        sync.RWMutex
 	error            error
 	closed           bool
 	started          bool
 	BlockDuration    int64
-	currentBlock     *dia.TradesBlock
+	blockBuilder     *TradesBlockBuilder
 	priceCache       map[dia.Asset]float64
+	bridges          *dia.AssetBridges
+	stablecoins      *dia.StablecoinChecker
+	references       *ReferencePriceChecker
 	datastore        models.Datastore
 	historical       bool
 	writeMeasurement string
 	batchTicker      *time.Ticker
+	// lastOffsets maps partitions onto the offset of the latest trade processed and blockOffsets maps the begin time
+	// of open blocks onto the smallest offset of their trades per partition. They determine the offsets to be committed
+	// once blocks are persisted.
+	lastOffsets  map[int]int64
+	blockOffsets map[int64]map[int]int64
+	committable  map[string]map[int]int64
+	offsetsLock  sync.Mutex
 }
 
-func NewTradesBlockService(datastore models.Datastore, blockDuration int64, historical bool) *TradesBlockService {
+// Options configures a TradesBlockService. Datastore and Registry are required, all other checks are optional.
+type Options struct {
+	Datastore     models.Datastore
+	BlockDuration int64
+	// Historical trades are written to the measurement INFLUX_MEASUREMENT_WRITE.
+	Historical bool
+	// Trades from exchanges marked as centralized in Registry are deduplicated.
+	Registry *scrapers.ExchangeRegistry
+	// Base tokens are priced using the bridging table Bridges.
+	Bridges *dia.AssetBridges
+	// Prices of stablecoins are checked against their peg by Stablecoins.
+	Stablecoins *dia.StablecoinChecker
+	// If References is not nil, prices are checked against foreign quotations.
+	References *ReferencePriceChecker
+	// Duplicate trades from centralized exchanges are identified using Dedup, or within a block if Dedup is nil.
+	Dedup dia.DedupStore
+}
+
+// NewTradesBlockService returns a new TradesBlockService configured by @opts and runs mainLoop() in a go routine.
+func NewTradesBlockService(opts Options) *TradesBlockService {
 	s := &TradesBlockService{
 		shutdown:        make(chan nothing),
 		shutdownDone:    make(chan nothing),
-		chanTrades:      make(chan *dia.Trade),
+		chanTrades:      make(chan tradeMessage),
 		chanTradesBlock: make(chan *dia.TradesBlock),
 		error:           nil,
 		started:         false,
-		currentBlock:    nil,
-		BlockDuration:   blockDuration,
+		BlockDuration:   opts.BlockDuration,
+		blockBuilder:    NewTradesBlockBuilder(opts.BlockDuration, time.Duration(allowedLatenessSeconds)*time.Second, time.Duration(correctionWindowSeconds)*time.Second, opts.Registry.IsCentralized, opts.Dedup),
 		priceCache:      make(map[dia.Asset]float64),
-		datastore:       datastore,
-		historical:      historical,
+		bridges:         opts.Bridges,
+		stablecoins:     opts.Stablecoins,
+		references:      opts.References,
+		datastore:       opts.Datastore,
+		historical:      opts.Historical,
 		batchTicker:     time.NewTicker(time.Duration(batchTimeSeconds) * time.Second),
+		lastOffsets:     make(map[int]int64),
+		blockOffsets:    make(map[int64]map[int]int64),
+		committable:     make(map[string]map[int]int64),
 	}
-	if historical {
+	if opts.Historical {
 		s.writeMeasurement = utils.Getenv("INFLUX_MEASUREMENT_WRITE", "tradesTmp")
 	}
 	log.Info("write measurement: ", s.writeMeasurement)
 	log.Info("historical: ", s.historical)
 	log.Info("batch ticker time: ", batchTimeSeconds)
+	log.Infof("allowed lateness: %ds, correction window: %ds", allowedLatenessSeconds, correctionWindowSeconds)
 	go s.mainLoop()
 	return s
 }
@@ -101,8 +147,8 @@ func (s *TradesBlockService) mainLoop() {
 			log.Println("TradesBlockService shutting down")
 			s.cleanup(nil)
 			return
-		case t := <-s.chanTrades:
-			s.process(*t)
+		case m := <-s.chanTrades:
+			s.process(*m.trade, m.partition, m.offset)
 		case <-s.batchTicker.C:
 			err := s.datastore.Flush()
 			if err != nil {
@@ -112,13 +158,13 @@ func (s *TradesBlockService) mainLoop() {
 	}
 }
 
-func (s *TradesBlockService) process(t dia.Trade) {
+func (s *TradesBlockService) process(t dia.Trade, partition int, offset int64) {
 
 	var verifiedTrade bool
 
 	// Price estimation can only be done for verified pairs.
 	// Trades with unverified pairs are still saved, but not sent to the filtersBlockService.
-	if t.VerifiedPair && s.checkTrade(t) {
+	if t.VerifiedPair && checkTrade(t) {
 		if t.BaseToken.Address == "840" && t.BaseToken.Blockchain == dia.FIAT {
 			// All prices are measured in US-Dollar, so just price for base token == USD
 			t.EstimatedUSDPrice = t.Price
@@ -132,7 +178,7 @@ func (s *TradesBlockService) process(t dia.Trade) {
 			if !s.historical {
 
 				// Bridge basetoken if necessary.
-				basetoken := buildBridge(t)
+				basetoken := s.bridges.Bridge(t)
 
 				// Get latest price from cache.
 				if _, ok = s.priceCache[basetoken]; ok {
@@ -181,15 +227,14 @@ func (s *TradesBlockService) process(t dia.Trade) {
 		}
 	}
 
-	// // If estimated price for stablecoin diverges too much ignore trade
-	if _, ok := stablecoins[t.Symbol]; ok {
-		if math.Abs(t.EstimatedUSDPrice-1) > tol {
-			log.Errorf("price for stablecoin %s diverges by %v", t.Symbol, math.Abs(t.EstimatedUSDPrice-1))
-			verifiedTrade = false
-		}
+	// If estimated price for stablecoin diverges too much from its peg, ignore trade or flag it in depeg mode.
+	if verifiedTrade && !s.stablecoins.Check(&t) {
+		verifiedTrade = false
+	}
+	// If estimated price diverges too much from the foreign quotation, ignore trade or flag it in tag mode.
+	if verifiedTrade && !s.references.Check(&t) {
+		verifiedTrade = false
 	}
-	// Comment Philipp: We could make another check here. Store CG and/or CMC quotation in redis cache
-	// and compare with estimatedUSDPrice. If deviation is too large ignore trade.
 	var err error
 	if !s.historical {
 		err = s.datastore.SaveTradeInflux(&t)
@@ -203,74 +248,85 @@ func (s *TradesBlockService) process(t dia.Trade) {
 		}
 	}
 
-	if s.currentBlock != nil && s.currentBlock.TradesBlockData.BeginTime.After(t.Time) {
-		log.Debugf("ignore trade should be in previous block %v", t)
-		verifiedTrade = false
-	}
-
 	// Only verified trades of verified pairs with nonzero price are added to the tradesBlock
+	if offset >= 0 {
+		s.lastOffsets[partition] = offset
+	}
 	if verifiedTrade && t.EstimatedUSDPrice > 0 {
-		if s.currentBlock == nil || s.currentBlock.TradesBlockData.EndTime.Before(t.Time) {
-			if s.currentBlock != nil {
-				s.finaliseCurrentBlock()
-				s.priceCache = make(map[dia.Asset]float64)
+		if offset >= 0 && !s.blockBuilder.IsLate(t) {
+			begin := s.blockBuilder.blockBegin(t)
+			if _, ok := s.blockOffsets[begin]; !ok {
+				s.blockOffsets[begin] = make(map[int]int64)
 			}
-
-			b := &dia.TradesBlock{
-				TradesBlockData: dia.TradesBlockData{
-					Trades:    []dia.Trade{},
-					EndTime:   time.Unix((t.Time.Unix()/s.BlockDuration)*s.BlockDuration+s.BlockDuration, 0),
-					BeginTime: time.Unix((t.Time.Unix()/s.BlockDuration)*s.BlockDuration, 0),
-				},
+			if o, ok := s.blockOffsets[begin][partition]; !ok || offset < o {
+				s.blockOffsets[begin][partition] = offset
+			}
+		}
+		if finalisedBlocks := s.blockBuilder.AddTrade(t); len(finalisedBlocks) > 0 {
+			if offset >= 0 {
+				s.setCommittable(finalisedBlocks[len(finalisedBlocks)-1].BlockHash)
 			}
-			if s.currentBlock != nil {
-				log.Info("created new block beginTime:", b.TradesBlockData.BeginTime, "previous block nb trades:", len(s.currentBlock.TradesBlockData.Trades))
+			for _, finalisedBlock := range finalisedBlocks {
+				s.chanTradesBlock <- finalisedBlock
 			}
-			s.currentBlock = b
+			s.priceCache = make(map[dia.Asset]float64)
 			err = s.datastore.Flush()
 			if err != nil {
 				log.Error(err)
 			}
 		}
-		// For centralized exchanges check if trade is not in the block yet
-		// (we have observed ws APIs sending identical trades).
-		if scrapers.Exchanges[t.Source].Centralized {
-			if _, ok := checkTradesDuplicate[t.TradeIdentifierFull()]; !ok {
-				s.currentBlock.TradesBlockData.Trades = append(s.currentBlock.TradesBlockData.Trades, t)
-				checkTradesDuplicate[t.TradeIdentifierFull()] = struct{}{}
-			} else {
-				if scrapers.Exchanges[t.Source].Name != dia.BitforexExchange {
-					log.Warn("duplicate trade within one tradesblock: ", t)
-				}
-			}
-		} else {
-			s.currentBlock.TradesBlockData.Trades = append(s.currentBlock.TradesBlockData.Trades, t)
-		}
 	} else {
 		log.Debugf("ignore trade  %v", t)
 	}
 }
 
-func (s *TradesBlockService) finaliseCurrentBlock() {
+func (s *TradesBlockService) ProcessTrade(trade *dia.Trade) {
+	s.chanTrades <- tradeMessage{trade: trade, offset: -1}
+}
 
-	sort.Slice(s.currentBlock.TradesBlockData.Trades, func(i, j int) bool {
-		return s.currentBlock.TradesBlockData.Trades[i].Time.Before(s.currentBlock.TradesBlockData.Trades[j].Time)
-	})
+// ProcessTradeAt processes @trade read at @offset of @partition of the trades topic.
+// Once a tradesBlock is persisted, CommittableOffsets returns the offsets up to which all trades are processed.
+func (s *TradesBlockService) ProcessTradeAt(trade *dia.Trade, partition int, offset int64) {
+	s.chanTrades <- tradeMessage{trade: trade, partition: partition, offset: offset}
+}
 
-	hash, err := structhash.Hash(s.currentBlock.TradesBlockData, 1)
-	if err != nil {
-		log.Printf("error on hash")
-		hash = "hashError"
+// CommittableOffsets returns the offsets of the trades topic per partition which can be committed once the
+// tradesBlock with hash @blockHash is persisted. All trades of a partition up to its offset are either discarded
+// or contained in this or previous tradesBlocks. The map is empty if no offset is available for the block.
+func (s *TradesBlockService) CommittableOffsets(blockHash string) map[int]int64 {
+	s.offsetsLock.Lock()
+	defer s.offsetsLock.Unlock()
+	offsets := make(map[int]int64)
+	for partition, offset := range s.committable[blockHash] {
+		if offset >= 0 {
+			offsets[partition] = offset
+		}
 	}
-	s.currentBlock.BlockHash = hash
-	s.currentBlock.TradesBlockData.TradesNumber = len(s.currentBlock.TradesBlockData.Trades)
-	// Reset duplicate trades identifier.
-	checkTradesDuplicate = make(map[string]struct{})
-	s.chanTradesBlock <- s.currentBlock
+	delete(s.committable, blockHash)
+	return offsets
 }
 
-func (s *TradesBlockService) ProcessTrade(trade *dia.Trade) {
-	s.chanTrades <- trade
+// setCommittable stores the committable offsets for the tradesBlock with hash @blockHash,
+// i.e. per partition the offset preceding the first trade of all open blocks.
+func (s *TradesBlockService) setCommittable(blockHash string) {
+	offsets := make(map[int]int64)
+	for partition, offset := range s.lastOffsets {
+		offsets[partition] = offset
+	}
+	for begin, blockOffsets := range s.blockOffsets {
+		if _, open := s.blockBuilder.openBlocks[begin]; !open {
+			delete(s.blockOffsets, begin)
+			continue
+		}
+		for partition, o := range blockOffsets {
+			if o-1 < offsets[partition] {
+				offsets[partition] = o - 1
+			}
+		}
+	}
+	s.offsetsLock.Lock()
+	s.committable[blockHash] = offsets
+	s.offsetsLock.Unlock()
 }
 
 func (s *TradesBlockService) Close() error {
@@ -297,145 +353,10 @@ func (s *TradesBlockService) Channel() chan *dia.TradesBlock {
 	return s.chanTradesBlock
 }
 
-func (s *TradesBlockService) checkTrade(t dia.Trade) bool {
+func checkTrade(t dia.Trade) bool {
 	if math.Abs(t.Volume) < tradeVolumeThreshold {
 		log.Info("low volume trade: ", t)
 		return false
 	}
 	return true
 }
-
-func buildBridge(t dia.Trade) dia.Asset {
-
-	basetoken := t.BaseToken
-
-	if basetoken.Blockchain == dia.ETHEREUM && basetoken.Address == "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" {
-		basetoken = dia.Asset{
-			Symbol:     "ETH",
-			Address:    "0x0000000000000000000000000000000000000000",
-			Blockchain: dia.ETHEREUM,
-		}
-	}
-	if basetoken.Blockchain == dia.SOLANA && t.Source == dia.OrcaExchange {
-		if basetoken.Address == "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v" {
-			basetoken = dia.Asset{
-				Symbol:     "USDC",
-				Address:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-		if basetoken.Address == "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB" {
-			basetoken = dia.Asset{
-				Symbol:     "USDT",
-				Address:    "0xdAC17F958D2ee523a2206206994597C13D831ec7",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-	}
-	if basetoken.Blockchain == dia.METIS && (t.Source == dia.NetswapExchange || t.Source == dia.TethysExchange || t.Source == dia.HermesExchange) && basetoken.Address == "0xEA32A96608495e54156Ae48931A7c20f0dcc1a21" {
-		basetoken = dia.Asset{
-			Symbol:     "USDC",
-			Address:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-			Blockchain: dia.ETHEREUM,
-		}
-	}
-	if basetoken.Blockchain == dia.FANTOM && (t.Source == dia.SpookyswapExchange || t.Source == dia.SpiritswapExchange || t.Source == dia.BeetsExchange) {
-		if basetoken.Address == "0x21be370D5312f44cB42ce377BC9b8a0cEF1A4C83" {
-			basetoken = dia.Asset{
-				Symbol:     "FTM",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.FANTOM,
-			}
-		}
-		if basetoken.Address == "0x04068DA6C83AFCFA0e13ba15A6696662335D5B75" {
-			basetoken = dia.Asset{
-				Symbol:     "USDC",
-				Address:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-	}
-	if basetoken.Blockchain == dia.TELOS && (t.Source == dia.OmniDexExchange) && basetoken.Address == common.HexToAddress("0xd102ce6a4db07d247fcc28f366a623df0938ca9e").Hex() {
-		basetoken = dia.Asset{
-			Symbol:     "TLOS",
-			Address:    "0x0000000000000000000000000000000000000000",
-			Blockchain: dia.TELOS,
-		}
-	}
-	if basetoken.Blockchain == dia.EVMOS && t.Source == dia.DiffusionExchange {
-		if basetoken.Address == common.HexToAddress("0xD4949664cD82660AaE99bEdc034a0deA8A0bd517").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "EVMOS",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.EVMOS,
-			}
-		}
-	}
-	if t.Source == dia.StellaswapExchange && basetoken.Blockchain == dia.MOONBEAM && basetoken.Address == common.HexToAddress("0xAcc15dC74880C9944775448304B263D191c6077F").Hex() {
-		basetoken = dia.Asset{
-			Symbol:     "GLMR",
-			Address:    "0x0000000000000000000000000000000000000000",
-			Blockchain: dia.MOONBEAM,
-		}
-	}
-	if (t.Source == dia.UniswapExchangeV3Polygon || t.Source == dia.QuickswapExchange || t.Source == dia.SushiSwapExchangePolygon || t.Source == dia.DfynNetwork) && basetoken.Blockchain == dia.POLYGON {
-		if basetoken.Address == common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "USDC",
-				Address:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-		if basetoken.Address == common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "MATIC",
-				Address:    "0x0000000000000000000000000000000000001010",
-				Blockchain: dia.POLYGON,
-			}
-		}
-	}
-	if t.Source == dia.ArthswapExchange && basetoken.Blockchain == dia.ASTAR {
-		if basetoken.Address == common.HexToAddress("0x6a2d262D56735DbA19Dd70682B39F6bE9a931D98").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "USDC",
-				Address:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-		if basetoken.Address == common.HexToAddress("0xAeaaf0e2c81Af264101B9129C00F4440cCF0F720").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "ASTR",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.ASTAR,
-			}
-		}
-	}
-	if basetoken.Blockchain == dia.AVALANCHE && (t.Source == dia.TraderJoeExchange || t.Source == dia.PangolinExchange) {
-		if basetoken.Address == common.HexToAddress("0xB31f66AA3C1e785363F0875A1B74E27b85FD66c7").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "AVAX",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.AVALANCHE,
-			}
-		}
-	}
-	if basetoken.Blockchain == dia.WANCHAIN && t.Source == dia.WanswapExchange {
-		if basetoken.Address == common.HexToAddress("0xdabD997aE5E4799BE47d6E69D9431615CBa28f48").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "WAN",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.WANCHAIN,
-			}
-		}
-	}
-	if basetoken.Blockchain == dia.ARBITRUM && (t.Source == dia.UniswapExchangeV3Arbitrum || t.Source == dia.SushiSwapExchangeArbitrum || t.Source == dia.CamelotExchange) {
-		if basetoken.Address == common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1").Hex() {
-			basetoken = dia.Asset{
-				Symbol:     "ETH",
-				Address:    "0x0000000000000000000000000000000000000000",
-				Blockchain: dia.ETHEREUM,
-			}
-		}
-	}
-	return basetoken
-}
@@ -0,0 +1,48 @@
+package tradesBlockService
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+func TestCommittableOffset(t *testing.T) {
+	service := NewTradesBlockService(Options{
+		Datastore:     models.NewMemoryDataStore(),
+		BlockDuration: 120,
+		Registry:      &scrapers.ExchangeRegistry{},
+	})
+	defer service.Close()
+
+	newTrade := func(timestamp int64) *dia.Trade {
+		return &dia.Trade{
+			BaseToken:    dia.Asset{Symbol: "USD", Address: "840", Blockchain: dia.FIAT},
+			QuoteToken:   dia.Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: dia.ETHEREUM},
+			Price:        1000,
+			Volume:       10,
+			Time:         time.Unix(timestamp, 0),
+			VerifiedPair: true,
+		}
+	}
+
+	service.ProcessTradeAt(newTrade(10), 0, 0)
+	service.ProcessTradeAt(newTrade(20), 1, 0)
+	service.ProcessTradeAt(newTrade(50), 0, 1)
+	go service.ProcessTradeAt(newTrade(140), 1, 1)
+	block := <-service.Channel()
+	if block.TradesBlockData.TradesNumber != 3 {
+		t.Errorf("expected 3 trades in block, got %d", block.TradesBlockData.TradesNumber)
+	}
+	// The trade at offset 1 of partition 1 is in the open block, so only offsets up to 0 of partition 1 can be committed.
+	expected := map[int]int64{0: 1, 1: 0}
+	if offsets := service.CommittableOffsets(block.BlockHash); !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("expected committable offsets %v, got %v", expected, offsets)
+	}
+	if offsets := service.CommittableOffsets(block.BlockHash); len(offsets) != 0 {
+		t.Errorf("committable offsets returned twice: %v", offsets)
+	}
+}
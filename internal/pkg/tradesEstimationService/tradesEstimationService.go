@@ -2,7 +2,6 @@ package tradesEstimationService
 
 import (
 	"errors"
-	"math"
 	"sync"
 	"time"
 
@@ -19,20 +18,10 @@ func init() {
 	log = logrus.New()
 }
 
-var (
-	stablecoins = map[string]interface{}{
-		"USDC": "",
-		"USDT": "",
-		"TUSD": "",
-		"DAI":  "",
-		"PAX":  "",
-		"BUSD": "",
-	}
-	tol = float64(0.1)
-)
-
 const (
 	priceFrame = 1000 * 120
+	// DefaultStablecoinTolerance is the maximal relative deviation of stablecoins without configured tolerance from their peg.
+	DefaultStablecoinTolerance = float64(0.1)
 )
 
 type pricetime struct {
@@ -49,10 +38,14 @@ type TradesEstimationService struct {
 	closed       bool
 	started      bool
 	priceCache   map[dia.Asset]pricetime
+	bridges      *dia.AssetBridges
+	stablecoins  *dia.StablecoinChecker
 	datastore    models.Datastore
 }
 
-func NewTradesEstimationService(datastore models.Datastore) *TradesEstimationService {
+// NewTradesEstimationService returns a new TradesEstimationService and runs mainLoop() in a go routine.
+// Base tokens are priced using the bridging table @bridges and stablecoin trades are checked against their peg by @stablecoins.
+func NewTradesEstimationService(datastore models.Datastore, bridges *dia.AssetBridges, stablecoins *dia.StablecoinChecker) *TradesEstimationService {
 	s := &TradesEstimationService{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
@@ -60,6 +53,8 @@ func NewTradesEstimationService(datastore models.Datastore) *TradesEstimationSer
 		error:        nil,
 		started:      false,
 		priceCache:   make(map[dia.Asset]pricetime),
+		bridges:      bridges,
+		stablecoins:  stablecoins,
 		datastore:    datastore,
 	}
 	go s.mainLoop()
@@ -93,14 +88,17 @@ func (s *TradesEstimationService) process(t dia.Trade) {
 			t.EstimatedUSDPrice = t.Price
 			verifiedTrade = true
 		} else {
+			// Bridge basetoken if necessary.
+			basetoken := s.bridges.Bridge(t)
+
 			// Check if price cache is still valid:
-			_, ok := s.priceCache[t.BaseToken]
-			if ok && t.Time.Sub(s.priceCache[t.BaseToken].Timestamp) < time.Duration(priceFrame*time.Millisecond) {
-				price = s.priceCache[t.BaseToken].Price
+			_, ok := s.priceCache[basetoken]
+			if ok && t.Time.Sub(s.priceCache[basetoken].Timestamp) < time.Duration(priceFrame*time.Millisecond) {
+				price = s.priceCache[basetoken].Price
 			} else {
 				// Look for historic price of base token at trade time...
-				price, err = s.datastore.GetAssetPriceUSD(t.BaseToken, t.Time)
-				s.priceCache[t.BaseToken] = pricetime{
+				price, err = s.datastore.GetAssetPriceUSD(basetoken, t.Time)
+				s.priceCache[basetoken] = pricetime{
 					Price:     price,
 					Timestamp: t.Time,
 				}
@@ -118,12 +116,9 @@ func (s *TradesEstimationService) process(t dia.Trade) {
 		}
 	}
 
-	// If estimated price for stablecoin diverges too much ignore trade.
-	if _, ok := stablecoins[t.Symbol]; ok {
-		if math.Abs(t.EstimatedUSDPrice-1) > tol {
-			log.Errorf("price for stablecoin %s diverges by %v", t.Symbol, math.Abs(t.EstimatedUSDPrice-1))
-			verifiedTrade = false
-		}
+	// If estimated price for stablecoin diverges too much from its peg ignore trade.
+	if verifiedTrade && !s.stablecoins.Check(&t) {
+		verifiedTrade = false
 	}
 
 	if verifiedTrade {
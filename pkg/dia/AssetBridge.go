@@ -0,0 +1,111 @@
+package dia
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AssetBridge maps a wrapped or bridged asset onto the asset whose price is used for it,
+// such as WETH onto ETH or a bridged stablecoin onto the stablecoin on Ethereum.
+// If Exchanges is non-empty, the bridge only applies to trades from these exchanges.
+type AssetBridge struct {
+	Source      Asset    `json:"Source"`
+	Destination Asset    `json:"Destination"`
+	Exchanges   []string `json:"Exchanges"`
+}
+
+// AssetBridges is a bridging table used for pricing base tokens of trades.
+type AssetBridges struct {
+	bridges []AssetBridge
+	// bridgesByAsset maps blockchain and address of a source asset onto the bridges applying to it.
+	bridgesByAsset map[string][]AssetBridge
+}
+
+// NewAssetBridges returns the bridging table consisting of @bridges.
+func NewAssetBridges(bridges []AssetBridge) *AssetBridges {
+	b := &AssetBridges{
+		bridgesByAsset: make(map[string][]AssetBridge),
+	}
+	for _, bridge := range bridges {
+		bridge.Source.Address = normalizeAddress(bridge.Source.Address)
+		b.bridges = append(b.bridges, bridge)
+		key := assetKey(bridge.Source.Blockchain, bridge.Source.Address)
+		b.bridgesByAsset[key] = append(b.bridgesByAsset[key], bridge)
+	}
+	return b
+}
+
+// Bridge returns the asset whose price is used for the base token of @t.
+// A bridge restricted to the exchange of @t takes precedence over an unrestricted bridge
+// of the same asset, independent of the order of the bridges in the table.
+// If no bridge applies, the base token itself is returned.
+func (b *AssetBridges) Bridge(t Trade) Asset {
+	if b == nil {
+		return t.BaseToken
+	}
+	key := assetKey(t.BaseToken.Blockchain, normalizeAddress(t.BaseToken.Address))
+	var unrestricted *AssetBridge
+	for i, bridge := range b.bridgesByAsset[key] {
+		if len(bridge.Exchanges) == 0 {
+			if unrestricted == nil {
+				unrestricted = &b.bridgesByAsset[key][i]
+			}
+			continue
+		}
+		if bridge.appliesTo(t.Source) {
+			return bridge.Destination
+		}
+	}
+	if unrestricted != nil {
+		return unrestricted.Destination
+	}
+	return t.BaseToken
+}
+
+// List returns all bridges of the table.
+func (b *AssetBridges) List() []AssetBridge {
+	if b == nil {
+		return []AssetBridge{}
+	}
+	return append([]AssetBridge{}, b.bridges...)
+}
+
+// ID identifies @bridge by its source asset and exchanges. A source asset can have several bridges
+// applying to different exchanges.
+func (bridge AssetBridge) ID() string {
+	return assetKey(bridge.Source.Blockchain, normalizeAddress(bridge.Source.Address)) + "-" + strings.Join(bridge.SortedExchanges(), ",")
+}
+
+// SortedExchanges returns the exchanges of @bridge in alphabetical order.
+func (bridge AssetBridge) SortedExchanges() []string {
+	exchanges := append([]string{}, bridge.Exchanges...)
+	sort.Strings(exchanges)
+	return exchanges
+}
+
+func (bridge AssetBridge) appliesTo(exchange string) bool {
+	if len(bridge.Exchanges) == 0 {
+		return true
+	}
+	for _, e := range bridge.Exchanges {
+		if e == exchange {
+			return true
+		}
+	}
+	return false
+}
+
+func assetKey(blockchain string, address string) string {
+	return blockchain + "-" + address
+}
+
+// normalizeAddress returns EVM addresses in checksum format and all other addresses unchanged,
+// as addresses of non-EVM chains can be case sensitive.
+func normalizeAddress(address string) string {
+	if common.IsHexAddress(address) {
+		return common.HexToAddress(address).Hex()
+	}
+	return address
+}
@@ -0,0 +1,87 @@
+package dia
+
+import "testing"
+
+func TestAssetBridges(t *testing.T) {
+	usdc := Asset{Symbol: "USDC", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Blockchain: ETHEREUM}
+	eth := Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: ETHEREUM}
+	bridges := NewAssetBridges([]AssetBridge{
+		{
+			Source:      Asset{Address: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Blockchain: ETHEREUM},
+			Destination: eth,
+		},
+		{
+			Source:      Asset{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Blockchain: SOLANA},
+			Destination: usdc,
+			Exchanges:   []string{OrcaExchange},
+		},
+		{
+			Source:      Asset{Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Blockchain: ETHEREUM},
+			Destination: usdc,
+		},
+		{
+			Source:      Asset{Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Blockchain: ETHEREUM},
+			Destination: eth,
+			Exchanges:   []string{CurveFIExchange},
+		},
+	})
+
+	cases := []struct {
+		name  string
+		trade Trade
+		want  Asset
+	}{
+		{
+			name:  "unrestricted bridge with checksummed address",
+			trade: Trade{BaseToken: Asset{Address: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Blockchain: ETHEREUM}, Source: UniswapExchange},
+			want:  eth,
+		},
+		{
+			name:  "bridge restricted to exchange",
+			trade: Trade{BaseToken: Asset{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Blockchain: SOLANA}, Source: OrcaExchange},
+			want:  usdc,
+		},
+		{
+			name:  "other exchange",
+			trade: Trade{BaseToken: Asset{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Blockchain: SOLANA}, Source: "Raydium"},
+			want:  Asset{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Blockchain: SOLANA},
+		},
+		{
+			name:  "restricted bridge takes precedence over unrestricted bridge",
+			trade: Trade{BaseToken: Asset{Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Blockchain: ETHEREUM}, Source: CurveFIExchange},
+			want:  eth,
+		},
+		{
+			name:  "unrestricted bridge for other exchanges",
+			trade: Trade{BaseToken: Asset{Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Blockchain: ETHEREUM}, Source: UniswapExchange},
+			want:  usdc,
+		},
+		{
+			name:  "no bridge",
+			trade: Trade{BaseToken: usdc, Source: UniswapExchange},
+			want:  usdc,
+		},
+	}
+	for _, c := range cases {
+		if got := bridges.Bridge(c.trade); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	var empty *AssetBridges
+	if got := empty.Bridge(cases[0].trade); got != cases[0].trade.BaseToken {
+		t.Errorf("nil table: got %v, want base token", got)
+	}
+}
+
+func TestAssetBridgeID(t *testing.T) {
+	a := AssetBridge{Source: Asset{Address: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Blockchain: ETHEREUM}, Exchanges: []string{UniswapExchange, CurveFIExchange}}
+	b := AssetBridge{Source: Asset{Address: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Blockchain: ETHEREUM}, Exchanges: []string{CurveFIExchange, UniswapExchange}}
+	c := AssetBridge{Source: b.Source, Exchanges: []string{UniswapExchange}}
+	if a.ID() != b.ID() {
+		t.Errorf("same source and exchanges: got %s and %s", a.ID(), b.ID())
+	}
+	if b.ID() == c.ID() {
+		t.Errorf("different exchanges: got %s for both", b.ID())
+	}
+}
@@ -0,0 +1,59 @@
+package dia
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zekroTJA/timedmap"
+)
+
+// DedupStore records keys of processed messages for a limited time in order to identify duplicates.
+type DedupStore interface {
+	// SeenBefore records @key and returns true if @key was already recorded.
+	SeenBefore(key string) (bool, error)
+	// Seen returns true if @key was recorded, without recording it.
+	Seen(key string) (bool, error)
+	// Record records @keys.
+	Record(keys ...string) error
+}
+
+// MemoryDedupStore is a DedupStore local to the process. Keys are lost on restart.
+type MemoryDedupStore struct {
+	keys *timedmap.TimedMap
+	ttl  time.Duration
+	mu   sync.Mutex
+}
+
+// NewMemoryDedupStore returns an in-memory store keeping keys for @ttl.
+func NewMemoryDedupStore(ttl time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{
+		keys: timedmap.New(ttl),
+		ttl:  ttl,
+	}
+}
+
+// SeenBefore records @key for the store's ttl and returns true if @key was already recorded.
+func (s *MemoryDedupStore) SeenBefore(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys.Contains(key) {
+		return true, nil
+	}
+	s.keys.Set(key, struct{}{}, s.ttl)
+	return false, nil
+}
+
+// Seen returns true if @key was recorded within the store's ttl.
+func (s *MemoryDedupStore) Seen(key string) (bool, error) {
+	return s.keys.Contains(key), nil
+}
+
+// Record records @keys for the store's ttl.
+func (s *MemoryDedupStore) Record(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		s.keys.Set(key, struct{}{}, s.ttl)
+	}
+	return nil
+}
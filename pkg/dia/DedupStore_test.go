@@ -0,0 +1,42 @@
+package dia
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	store := NewMemoryDedupStore(50 * time.Millisecond)
+	trade := Trade{Source: BinanceExchange, Price: 1, Volume: 2, ForeignTradeID: "1", Time: time.Unix(1, 0)}
+	resent := trade
+	other := trade
+	other.ForeignTradeID = "2"
+
+	if trade.IsDuplicate(store) {
+		t.Error("first trade identified as duplicate")
+	}
+	if resent.IsDuplicate(store) {
+		t.Error("trade identified as duplicate before being recorded")
+	}
+	trade.RecordProcessed(store)
+	if !resent.IsDuplicate(store) {
+		t.Error("resent trade not identified as duplicate")
+	}
+	if other.IsDuplicate(store) {
+		t.Error("different trade identified as duplicate")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if trade.IsDuplicate(store) {
+		t.Error("trade identified as duplicate after ttl")
+	}
+
+	seen, _ := store.Seen("block")
+	if seen {
+		t.Error("unrecorded key seen")
+	}
+	_ = store.Record("block")
+	seen, _ = store.Seen("block")
+	if !seen {
+		t.Error("recorded key not seen")
+	}
+}
@@ -374,6 +374,12 @@ func (bc *BlockChain) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// Identifier returns blockchain and address of @a. EVM addresses are given in checksum format,
+// such that assets are identified independent of the case of their address.
+func (a *Asset) Identifier() string {
+	return assetKey(a.Blockchain, normalizeAddress(a.Address))
+}
+
 // MarshalBinary is a custom marshaller for Asset type
 func (a *Asset) MarshalBinary() ([]byte, error) {
 	return json.Marshal(a)
@@ -427,6 +433,11 @@ type Trade struct {
 	EstimatedUSDPrice float64   `json:"EstimatedUSDPrice"` // will be filled by the TradesBlockService
 	Source            string    `json:"Source"`
 	VerifiedPair      bool      `json:"VerifiedPair"` // will be filled by the pairDiscoveryService
+	// Depegged is set by the TradesBlockService for stablecoin trades deviating from their peg in depeg mode.
+	Depegged bool `json:"Depegged,omitempty"`
+	// ReferenceDeviant is set by the TradesBlockService for trades deviating from the foreign quotation of the quote token in tag mode.
+	// Such trades are not used by the filtersBlockService.
+	ReferenceDeviant bool `json:"ReferenceDeviant,omitempty"`
 }
 
 // SynthAssetSupply is a container for data on synthetic assets such as aUSDC.
@@ -455,6 +466,8 @@ type TradesBlockData struct {
 type TradesBlock struct {
 	BlockHash       string
 	TradesBlockData TradesBlockData
+	// Correction is true if the block replaces a previously emitted block after late trades were added.
+	Correction bool `json:",omitempty"`
 }
 
 type FiltersBlock struct {
@@ -480,6 +493,30 @@ type FilterPoint struct {
 	Min        float64
 	FirstTrade Trade
 	LastTrade  Trade
+	// RejectedSources are the sources discarded by cross-source outlier rejection.
+	RejectedSources []string `json:",omitempty"`
+	// Depegged is true if the block contained trades of the asset deviating from its peg.
+	Depegged bool `json:",omitempty"`
+	// Source is the exchange the filter is computed on. It is empty for filters across all exchanges.
+	Source string `json:",omitempty"`
+}
+
+// Candle contains open, high, low and close price and the volume of the trades of an asset
+// in the time range [Time, Time+Resolution).
+type Candle struct {
+	Asset Asset
+	// Exchange is empty for candles across all exchanges.
+	Exchange   string `json:",omitempty"`
+	Resolution string
+	Time       time.Time
+	Open       float64
+	High       float64
+	Low        float64
+	Close      float64
+	// Volume is denominated in the asset and VolumeUSD in US dollar.
+	Volume    float64
+	VolumeUSD float64
+	NumTrades int64
 }
 
 type IndexBlock struct {
@@ -546,6 +583,10 @@ func (fp *FilterPointMetadata) AddPoint(value float64) {
 	}
 }
 
+func (e *FiltersBlock) Hash() string {
+	return e.BlockHash
+}
+
 // MarshalBinary -
 func (e *FiltersBlock) MarshalBinary() ([]byte, error) {
 	return json.Marshal(e)
@@ -572,6 +613,23 @@ func (e *Trade) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// MarshalBinary -
+func (e *DepegEvent) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// UnmarshalBinary -
+func (e *DepegEvent) UnmarshalBinary(data []byte) error {
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *TradesBlock) Hash() string {
+	return e.BlockHash
+}
+
 // MarshalBinary -
 func (e *TradesBlock) MarshalBinary() ([]byte, error) {
 	return json.Marshal(e)
@@ -641,6 +699,56 @@ func (e *SuppliesBlock) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// Scopes of API keys.
+const (
+	// ScopeRead grants access to the public endpoints.
+	ScopeRead = "read"
+	// ScopeWrite grants access to the endpoints writing supplies and quotations.
+	ScopeWrite = "write"
+	// ScopeKafka grants access to the kafka endpoints.
+	ScopeKafka = "kafka"
+)
+
+// APIKey grants access to the REST API within its scopes, rate limit and daily quota.
+// The key itself is not stored. It is only known to its owner.
+type APIKey struct {
+	ID string
+	// Prefix are the first characters of the key, such that owners can identify it.
+	Prefix string
+	Owner  string
+	Scopes []string
+	// RateLimit is the maximal number of requests per minute and DailyQuota the maximal number
+	// of requests per day. Zero means no limit.
+	RateLimit  int
+	DailyQuota int64
+	Active     bool
+	CreatedAt  time.Time
+	// ValidUntil is zero for keys without expiry.
+	ValidUntil time.Time `json:",omitempty"`
+}
+
+// HasScope returns true if @k grants @scope.
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid returns true if @k is active and not expired at @t.
+func (k APIKey) Valid(t time.Time) bool {
+	return k.Active && (k.ValidUntil.IsZero() || t.Before(k.ValidUntil))
+}
+
+// APIKeyUsage is the number of requests made with an API key on a day.
+type APIKeyUsage struct {
+	KeyID    string
+	Day      time.Time
+	Requests int64
+}
+
 type OracleConfig struct {
 	Symbols           []string
 	FeederID          string
@@ -0,0 +1,213 @@
+package dia
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	PegUSD = "USD"
+	PegEUR = "EUR"
+	PegXAU = "XAU"
+
+	// pegPriceValidity is the time a peg price is reused by StablecoinChecker.
+	pegPriceValidity  = time.Minute
+	depegEventsBuffer = 1000
+)
+
+// pegAssets are the fiat assets stablecoins can be pegged to, identified by their ISO 4217 code.
+var pegAssets = map[string]Asset{
+	PegUSD: {Symbol: "USD", Address: "840", Blockchain: FIAT},
+	PegEUR: {Symbol: "EUR", Address: "978", Blockchain: FIAT},
+	PegXAU: {Symbol: "XAU", Address: "959", Blockchain: FIAT},
+}
+
+// StablecoinPeg is the peg of a stablecoin identified by blockchain and address.
+type StablecoinPeg struct {
+	Asset Asset `json:"Asset"`
+	// Peg is one of PegUSD, PegEUR and PegXAU. Defaults to PegUSD.
+	Peg string `json:"Peg"`
+	// Tolerance is the maximal relative deviation from the peg. If zero, the checker's default tolerance is used.
+	Tolerance float64 `json:"Tolerance"`
+}
+
+// DepegEvent is emitted when the estimated price of a stablecoin deviates from its peg by more than the tolerance.
+type DepegEvent struct {
+	Asset     Asset     `json:"Asset"`
+	Peg       string    `json:"Peg"`
+	PegPrice  float64   `json:"PegPrice"`
+	Price     float64   `json:"Price"`
+	Deviation float64   `json:"Deviation"`
+	Source    string    `json:"Source"`
+	Time      time.Time `json:"Time"`
+}
+
+// PegPriceFunc returns the USD price of @peg at @timestamp.
+type PegPriceFunc func(peg Asset, timestamp time.Time) (float64, error)
+
+// PegAsset returns the fiat asset corresponding to @peg.
+func PegAsset(peg string) (Asset, bool) {
+	if peg == "" {
+		peg = PegUSD
+	}
+	asset, ok := pegAssets[peg]
+	return asset, ok
+}
+
+// Validate returns an error if the stablecoin or its peg are unknown.
+func (p StablecoinPeg) Validate() error {
+	if p.Asset.Blockchain == "" || p.Asset.Address == "" {
+		return errors.New("stablecoin peg needs blockchain and address of the asset")
+	}
+	if _, ok := PegAsset(p.Peg); !ok {
+		return errors.New("unknown peg " + p.Peg + " for stablecoin " + p.Asset.Blockchain + "-" + p.Asset.Address)
+	}
+	if p.Tolerance < 0 {
+		return errors.New("negative tolerance for stablecoin " + p.Asset.Blockchain + "-" + p.Asset.Address)
+	}
+	return nil
+}
+
+// BridgedStablecoinPegs returns @pegs together with the pegs of the source assets of @bridges whose
+// destination is a stablecoin in @pegs, such that bridged stablecoins on other chains are checked as well.
+// Stablecoins listed in @pegs keep their own peg.
+func BridgedStablecoinPegs(pegs []StablecoinPeg, bridges *AssetBridges) []StablecoinPeg {
+	pegsByAsset := make(map[string]StablecoinPeg)
+	for _, peg := range pegs {
+		pegsByAsset[assetKey(peg.Asset.Blockchain, normalizeAddress(peg.Asset.Address))] = peg
+	}
+	result := append([]StablecoinPeg{}, pegs...)
+	for _, bridge := range bridges.List() {
+		peg, ok := pegsByAsset[assetKey(bridge.Destination.Blockchain, normalizeAddress(bridge.Destination.Address))]
+		if !ok {
+			continue
+		}
+		key := assetKey(bridge.Source.Blockchain, normalizeAddress(bridge.Source.Address))
+		if _, ok := pegsByAsset[key]; ok {
+			continue
+		}
+		bridged := peg
+		bridged.Asset = bridge.Source
+		if bridged.Asset.Symbol == "" {
+			bridged.Asset.Symbol = peg.Asset.Symbol
+		}
+		pegsByAsset[key] = bridged
+		result = append(result, bridged)
+	}
+	return result
+}
+
+// StablecoinChecker checks the estimated USD price of stablecoin trades against the stablecoin's peg.
+// By default, trades deviating from the peg are discarded. In depeg mode, they are kept and flagged
+// as depegged instead, and a DepegEvent is emitted.
+type StablecoinChecker struct {
+	pegs             map[string]StablecoinPeg
+	defaultTolerance float64
+	depegMode        bool
+	pegPrice         PegPriceFunc
+	pegPrices        map[string]cachedPegPrice
+	events           chan DepegEvent
+	mu               sync.Mutex
+}
+
+type cachedPegPrice struct {
+	price     float64
+	timestamp time.Time
+}
+
+// NewStablecoinChecker returns a checker for the stablecoins in @pegs.
+// @defaultTolerance is used for stablecoins without tolerance and @pegPrice returns prices of non-USD pegs.
+func NewStablecoinChecker(pegs []StablecoinPeg, defaultTolerance float64, depegMode bool, pegPrice PegPriceFunc) *StablecoinChecker {
+	c := &StablecoinChecker{
+		pegs:             make(map[string]StablecoinPeg),
+		defaultTolerance: defaultTolerance,
+		depegMode:        depegMode,
+		pegPrice:         pegPrice,
+		pegPrices:        make(map[string]cachedPegPrice),
+		events:           make(chan DepegEvent, depegEventsBuffer),
+	}
+	for _, peg := range pegs {
+		peg.Asset.Address = normalizeAddress(peg.Asset.Address)
+		if peg.Peg == "" {
+			peg.Peg = PegUSD
+		}
+		if peg.Tolerance == 0 {
+			peg.Tolerance = defaultTolerance
+		}
+		c.pegs[assetKey(peg.Asset.Blockchain, peg.Asset.Address)] = peg
+	}
+	return c
+}
+
+// Events returns the channel of depeg events. Events are dropped if the channel is full.
+func (c *StablecoinChecker) Events() <-chan DepegEvent {
+	return c.events
+}
+
+// Check returns false if @t is a stablecoin trade whose estimated USD price deviates from the peg
+// by more than the tolerance and should be discarded. In depeg mode, such a trade is flagged instead.
+func (c *StablecoinChecker) Check(t *Trade) bool {
+	if c == nil {
+		return true
+	}
+	peg, ok := c.pegs[assetKey(t.QuoteToken.Blockchain, normalizeAddress(t.QuoteToken.Address))]
+	if !ok {
+		return true
+	}
+	target, err := c.getPegPrice(peg.Peg, t.Time)
+	if err != nil || target == 0 {
+		log.Errorf("price of peg %s for stablecoin %s: %v", peg.Peg, t.QuoteToken.Symbol, err)
+		return true
+	}
+	deviation := math.Abs(t.EstimatedUSDPrice-target) / target
+	if deviation <= peg.Tolerance {
+		return true
+	}
+
+	log.Errorf("price for stablecoin %s diverges from %s peg by %v", t.QuoteToken.Symbol, peg.Peg, deviation)
+	if !c.depegMode {
+		return false
+	}
+	t.Depegged = true
+	event := DepegEvent{
+		Asset:     t.QuoteToken,
+		Peg:       peg.Peg,
+		PegPrice:  target,
+		Price:     t.EstimatedUSDPrice,
+		Deviation: deviation,
+		Source:    t.Source,
+		Time:      t.Time,
+	}
+	select {
+	case c.events <- event:
+	default:
+		log.Warn("depeg events channel full, dropping event for ", t.QuoteToken.Symbol)
+	}
+	return true
+}
+
+// getPegPrice returns the USD price of @peg at @timestamp. Prices are reused for pegPriceValidity.
+func (c *StablecoinChecker) getPegPrice(peg string, timestamp time.Time) (float64, error) {
+	if peg == PegUSD {
+		return 1, nil
+	}
+	if c.pegPrice == nil {
+		return 0, errors.New("no peg price source")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.pegPrices[peg]; ok && math.Abs(float64(timestamp.Sub(cached.timestamp))) < float64(pegPriceValidity) {
+		return cached.price, nil
+	}
+	asset, _ := PegAsset(peg)
+	price, err := c.pegPrice(asset, timestamp)
+	if err != nil {
+		return 0, err
+	}
+	c.pegPrices[peg] = cachedPegPrice{price: price, timestamp: timestamp}
+	return price, nil
+}
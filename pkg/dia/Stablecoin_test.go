@@ -0,0 +1,82 @@
+package dia
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStablecoinChecker(t *testing.T) {
+	usdc := Asset{Symbol: "USDC", Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Blockchain: ETHEREUM}
+	eurs := Asset{Symbol: "EURS", Address: "0xdb25f211ab05b1c97d595516f45794528a807ad8", Blockchain: ETHEREUM}
+	pegs := []StablecoinPeg{
+		{Asset: usdc},
+		{Asset: eurs, Peg: PegEUR, Tolerance: 0.02},
+	}
+	pegPrice := func(peg Asset, timestamp time.Time) (float64, error) {
+		if peg.Symbol == "EUR" {
+			return 1.1, nil
+		}
+		return 0, errors.New("unknown peg")
+	}
+
+	cases := []struct {
+		asset     Asset
+		price     float64
+		depegMode bool
+		valid     bool
+		depegged  bool
+	}{
+		{asset: usdc, price: 0.97, valid: true},
+		{asset: usdc, price: 0.9, valid: false},
+		{asset: usdc, price: 0.9, depegMode: true, valid: true, depegged: true},
+		{asset: eurs, price: 1.11, valid: true},
+		{asset: eurs, price: 1.0, valid: false},
+		{asset: Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: ETHEREUM}, price: 1000, valid: true},
+	}
+
+	for i, c := range cases {
+		checker := NewStablecoinChecker(pegs, 0.04, c.depegMode, pegPrice)
+		trade := Trade{QuoteToken: c.asset, EstimatedUSDPrice: c.price, Time: time.Now()}
+		if valid := checker.Check(&trade); valid != c.valid {
+			t.Errorf("case %d: expected valid %v, got %v", i, c.valid, valid)
+		}
+		if trade.Depegged != c.depegged {
+			t.Errorf("case %d: expected depegged %v, got %v", i, c.depegged, trade.Depegged)
+		}
+		if c.depegged {
+			select {
+			case event := <-checker.Events():
+				if event.Asset.Symbol != c.asset.Symbol || event.Peg != PegUSD {
+					t.Errorf("case %d: unexpected depeg event %v", i, event)
+				}
+			default:
+				t.Errorf("case %d: expected depeg event", i)
+			}
+		}
+	}
+}
+
+func TestBridgedStablecoinPegs(t *testing.T) {
+	usdc := Asset{Symbol: "USDC", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Blockchain: ETHEREUM}
+	solanaUSDC := Asset{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Blockchain: SOLANA}
+	weth := Asset{Address: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Blockchain: ETHEREUM}
+	bridges := NewAssetBridges([]AssetBridge{
+		{Source: solanaUSDC, Destination: usdc, Exchanges: []string{OrcaExchange}},
+		{Source: weth, Destination: Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: ETHEREUM}},
+	})
+
+	pegs := BridgedStablecoinPegs([]StablecoinPeg{{Asset: usdc, Tolerance: 0.01}}, bridges)
+	if len(pegs) != 2 {
+		t.Fatalf("expected 2 pegs, got %v", pegs)
+	}
+	if pegs[1].Asset.Address != solanaUSDC.Address || pegs[1].Asset.Symbol != "USDC" || pegs[1].Tolerance != 0.01 {
+		t.Errorf("unexpected bridged peg %v", pegs[1])
+	}
+
+	checker := NewStablecoinChecker(pegs, 0.04, false, nil)
+	trade := Trade{QuoteToken: solanaUSDC, EstimatedUSDPrice: 0.9, Time: time.Now()}
+	if checker.Check(&trade) {
+		t.Error("depegged trade of bridged stablecoin passed the check")
+	}
+}
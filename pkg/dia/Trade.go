@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/zekroTJA/timedmap"
 )
 
@@ -66,6 +67,25 @@ func SwapTrade(t Trade) (Trade, error) {
 	return t, nil
 }
 
+// IsDuplicate returns true in case a trade fully identical to @t was already recorded in @store.
+// @t itself is not recorded, such that it is not dropped on retry if it fails to be processed.
+// If @store is not available, @t is not considered a duplicate.
+func (t *Trade) IsDuplicate(store DedupStore) bool {
+	seen, err := store.Seen(t.TradeIdentifierFull())
+	if err != nil {
+		log.Error("dedup store: ", err)
+		return false
+	}
+	return seen
+}
+
+// RecordProcessed records @t in @store once it is processed, such that identical trades are identified as duplicates.
+func (t *Trade) RecordProcessed(store DedupStore) {
+	if err := store.Record(t.TradeIdentifierFull()); err != nil {
+		log.Error("dedup store: ", err)
+	}
+}
+
 // IdentifyDuplicateFull returns true in case a trade is fully identical to one stored in the timed map @falseDuplicateTrades.
 func (t *Trade) IdentifyDuplicateFull(falseDuplicateTrades *timedmap.TimedMap, memory time.Duration) (discardTrade bool) {
 	if _, ok := falseDuplicateTrades.GetValue(t.TradeIdentifierFull()).(int); !ok {
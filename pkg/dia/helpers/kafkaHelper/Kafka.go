@@ -48,6 +48,7 @@ const (
 	TopicTradesBlockTest  = 23
 	TopicNFTTrades        = 24
 	TopicNFTTradesTest    = 25
+	TopicDepegEvents      = 26
 
 	retryDelay = 2 * time.Second
 )
@@ -80,6 +81,7 @@ func getTopic(topic int) string {
 		23: "tradesblocktest",
 		24: "nfttrades",
 		25: "nfttradestest",
+		26: "depegevents",
 	}
 	result, ok := topicMap[topic]
 	if !ok {
@@ -189,9 +191,27 @@ func NewReader(topic int) *kafka.Reader {
 	return r
 }
 
+// NewConsumerGroupReader returns a reader of @topic in the consumer group @groupID.
+// Offsets are not committed automatically, but by CommitMessages once a message is processed,
+// such that a restarted consumer resumes at the first unprocessed message.
+// A new consumer group starts reading at the last offset.
+func NewConsumerGroupReader(topic int, groupID string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        KafkaConfig.KafkaUrl,
+		GroupID:        groupID,
+		Topic:          getTopic(topic),
+		MinBytes:       0,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: 0,
+		StartOffset:    kafka.LastOffset,
+	})
+}
+
+// WriteMessage writes @m to @w. Messages with a hash, such as blocks, are keyed by their hash,
+// so that consumers can identify messages written more than once.
 func WriteMessage(w *kafka.Writer, m KafkaMessage) error {
-	key := []byte("helloKafka")
-	value, err := m.MarshalBinary()
+	key := MessageKey(m)
+	value, err := MarshalMessage(w.Topic, m)
 	if err == nil && value != nil {
 		err = w.WriteMessages(context.Background(),
 			kafka.Message{
@@ -208,6 +228,14 @@ func WriteMessage(w *kafka.Writer, m KafkaMessage) error {
 	return err
 }
 
+// MessageKey returns the key of @m, i.e. its hash if it has one.
+func MessageKey(m KafkaMessage) []byte {
+	if h, ok := m.(KafkaMessageWithAHash); ok && h.Hash() != "" {
+		return []byte(h.Hash())
+	}
+	return []byte("helloKafka")
+}
+
 func NewReaderXElementsBeforeLastMessage(topic int, x int64) *kafka.Reader {
 
 	var offset int64
@@ -321,19 +349,19 @@ func GetElements(topic int, offset int64, nbElements int) ([]interface{}, error)
 			switch topic {
 			case TopicFiltersBlock:
 				var e dia.FiltersBlock
-				err = e.UnmarshalBinary(b2)
+				err = UnmarshalMessage(b2, &e)
 				if err == nil {
 					result = append(result, e)
 				}
 			case TopicTrades:
 				var e dia.Trade
-				err = e.UnmarshalBinary(b2)
+				err = UnmarshalMessage(b2, &e)
 				if err == nil {
 					result = append(result, e)
 				}
 			case TopicTradesBlock:
 				var e dia.TradesBlock
-				err = e.UnmarshalBinary(b2)
+				err = UnmarshalMessage(b2, &e)
 				if err == nil {
 					result = append(result, e)
 				}
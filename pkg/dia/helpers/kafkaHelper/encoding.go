@@ -0,0 +1,383 @@
+package kafkaHelper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	kafkamessages "github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper/protoc"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Messages are either plain JSON as produced by MarshalBinary, or wrapped in a versioned envelope
+// consisting of envelopeMagic, the envelope version and the encoding of the payload, followed by the payload.
+// As JSON messages start with '{', readers detect the format from the first byte.
+const (
+	envelopeMagic    = byte(0)
+	envelopeVersion1 = byte(1)
+	encodingProtobuf = byte(1)
+	envelopeHeader   = 3
+)
+
+// protobufTopics are the names of the topics written in the protobuf envelope. All other topics are written in JSON.
+// Topics can be migrated one at a time by adding them to the comma separated env var KAFKA_PROTOBUF_TOPICS
+// once all readers of the topic use UnmarshalMessage.
+var protobufTopics map[string]bool
+
+func init() {
+	protobufTopics = make(map[string]bool)
+	for _, topic := range strings.Split(os.Getenv("KAFKA_PROTOBUF_TOPICS"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			protobufTopics[topic] = true
+		}
+	}
+}
+
+// KafkaMessageUnmarshaler is a message which can be read from kafka.
+type KafkaMessageUnmarshaler interface {
+	UnmarshalBinary(data []byte) error
+}
+
+// MarshalMessage returns @m encoded for @topic, i.e. in the protobuf envelope if @topic is migrated
+// to protobuf and a schema for @m exists, and as JSON otherwise.
+func MarshalMessage(topic string, m KafkaMessage) ([]byte, error) {
+	if protobufTopics[topic] {
+		if pm, ok := toProto(m); ok {
+			return MarshalProtobufEnvelope(pm)
+		}
+	}
+	return m.MarshalBinary()
+}
+
+// MarshalProtobufEnvelope returns @pm in the protobuf envelope.
+func MarshalProtobufEnvelope(pm proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(pm)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{envelopeMagic, envelopeVersion1, encodingProtobuf}, payload...), nil
+}
+
+// UnmarshalMessage decodes @data written by WriteMessage into @m. JSON and the protobuf envelope are detected automatically.
+func UnmarshalMessage(data []byte, m KafkaMessageUnmarshaler) error {
+	if len(data) == 0 || data[0] != envelopeMagic {
+		return m.UnmarshalBinary(data)
+	}
+	if len(data) < envelopeHeader {
+		return errors.New("truncated message envelope")
+	}
+	if data[1] != envelopeVersion1 {
+		return fmt.Errorf("unknown message envelope version %d", data[1])
+	}
+	if data[2] != encodingProtobuf {
+		return fmt.Errorf("unknown message encoding %d", data[2])
+	}
+	return fromProto(data[envelopeHeader:], m)
+}
+
+// toProto returns the protobuf message corresponding to @m. Returns false if there is no schema for @m.
+func toProto(m KafkaMessage) (proto.Message, bool) {
+	switch v := m.(type) {
+	case *dia.Trade:
+		return tradeToProto(*v), true
+	case *dia.TradesBlock:
+		return tradesBlockToProto(*v), true
+	case *dia.FiltersBlock:
+		return filtersBlockToProto(*v), true
+	case *dia.NFTTrade:
+		return nftTradeToProto(*v), true
+	default:
+		return nil, false
+	}
+}
+
+// fromProto decodes the protobuf @payload into @m.
+func fromProto(payload []byte, m KafkaMessageUnmarshaler) error {
+	switch v := m.(type) {
+	case *dia.Trade:
+		var pt kafkamessages.Trade
+		if err := proto.Unmarshal(payload, &pt); err != nil {
+			return err
+		}
+		*v = tradeFromProto(&pt)
+	case *dia.TradesBlock:
+		var ptb kafkamessages.TradesBlock
+		if err := proto.Unmarshal(payload, &ptb); err != nil {
+			return err
+		}
+		*v = tradesBlockFromProto(&ptb)
+	case *dia.FiltersBlock:
+		var pfb kafkamessages.FiltersBlock
+		if err := proto.Unmarshal(payload, &pfb); err != nil {
+			return err
+		}
+		*v = filtersBlockFromProto(&pfb)
+	case *dia.NFTTrade:
+		var pnt kafkamessages.NFTTrade
+		if err := proto.Unmarshal(payload, &pnt); err != nil {
+			return err
+		}
+		nftTrade, err := nftTradeFromProto(&pnt)
+		if err != nil {
+			return err
+		}
+		*v = nftTrade
+	default:
+		return fmt.Errorf("no protobuf schema for message of type %T", m)
+	}
+	return nil
+}
+
+func timeToProto(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func timeFromProto(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t).UTC()
+}
+
+func assetToProto(a dia.Asset) *kafkamessages.Asset {
+	return &kafkamessages.Asset{
+		Symbol:     a.Symbol,
+		Name:       a.Name,
+		Address:    a.Address,
+		Decimals:   uint32(a.Decimals),
+		Blockchain: a.Blockchain,
+	}
+}
+
+func assetFromProto(pa *kafkamessages.Asset) dia.Asset {
+	return dia.Asset{
+		Symbol:     pa.GetSymbol(),
+		Name:       pa.GetName(),
+		Address:    pa.GetAddress(),
+		Decimals:   uint8(pa.GetDecimals()),
+		Blockchain: pa.GetBlockchain(),
+	}
+}
+
+func tradeToProto(t dia.Trade) *kafkamessages.Trade {
+	return &kafkamessages.Trade{
+		Symbol:            t.Symbol,
+		Pair:              t.Pair,
+		QuoteToken:        assetToProto(t.QuoteToken),
+		BaseToken:         assetToProto(t.BaseToken),
+		Price:             t.Price,
+		Volume:            t.Volume,
+		Time:              timeToProto(t.Time),
+		ForeignTradeId:    t.ForeignTradeID,
+		EstimatedUsdPrice: t.EstimatedUSDPrice,
+		Source:            t.Source,
+		VerifiedPair:      t.VerifiedPair,
+		Depegged:          t.Depegged,
+		ReferenceDeviant:  t.ReferenceDeviant,
+	}
+}
+
+func tradeFromProto(pt *kafkamessages.Trade) dia.Trade {
+	return dia.Trade{
+		Symbol:            pt.GetSymbol(),
+		Pair:              pt.GetPair(),
+		QuoteToken:        assetFromProto(pt.GetQuoteToken()),
+		BaseToken:         assetFromProto(pt.GetBaseToken()),
+		Price:             pt.GetPrice(),
+		Volume:            pt.GetVolume(),
+		Time:              timeFromProto(pt.GetTime()),
+		ForeignTradeID:    pt.GetForeignTradeId(),
+		EstimatedUSDPrice: pt.GetEstimatedUsdPrice(),
+		Source:            pt.GetSource(),
+		VerifiedPair:      pt.GetVerifiedPair(),
+		Depegged:          pt.GetDepegged(),
+		ReferenceDeviant:  pt.GetReferenceDeviant(),
+	}
+}
+
+func tradesBlockToProto(tb dia.TradesBlock) *kafkamessages.TradesBlock {
+	trades := make([]*kafkamessages.Trade, len(tb.TradesBlockData.Trades))
+	for i, t := range tb.TradesBlockData.Trades {
+		trades[i] = tradeToProto(t)
+	}
+	return &kafkamessages.TradesBlock{
+		BlockHash: tb.BlockHash,
+		TradesBlockData: &kafkamessages.TradesBlockData{
+			BeginTime:    timeToProto(tb.TradesBlockData.BeginTime),
+			EndTime:      timeToProto(tb.TradesBlockData.EndTime),
+			TradesNumber: int64(tb.TradesBlockData.TradesNumber),
+			Trades:       trades,
+		},
+		Correction: tb.Correction,
+	}
+}
+
+func tradesBlockFromProto(ptb *kafkamessages.TradesBlock) dia.TradesBlock {
+	data := ptb.GetTradesBlockData()
+	trades := make([]dia.Trade, len(data.GetTrades()))
+	for i, pt := range data.GetTrades() {
+		trades[i] = tradeFromProto(pt)
+	}
+	return dia.TradesBlock{
+		BlockHash: ptb.GetBlockHash(),
+		TradesBlockData: dia.TradesBlockData{
+			BeginTime:    timeFromProto(data.GetBeginTime()),
+			EndTime:      timeFromProto(data.GetEndTime()),
+			TradesNumber: int(data.GetTradesNumber()),
+			Trades:       trades,
+		},
+		Correction: ptb.GetCorrection(),
+	}
+}
+
+func filtersBlockToProto(fb dia.FiltersBlock) *kafkamessages.FiltersBlock {
+	filterPoints := make([]*kafkamessages.FilterPoint, len(fb.FiltersBlockData.FilterPoints))
+	for i, fp := range fb.FiltersBlockData.FilterPoints {
+		filterPoints[i] = &kafkamessages.FilterPoint{
+			Asset:           assetToProto(fp.Asset),
+			Value:           fp.Value,
+			Name:            fp.Name,
+			Time:            timeToProto(fp.Time),
+			Max:             fp.Max,
+			Min:             fp.Min,
+			FirstTrade:      tradeToProto(fp.FirstTrade),
+			LastTrade:       tradeToProto(fp.LastTrade),
+			RejectedSources: fp.RejectedSources,
+			Depegged:        fp.Depegged,
+			Source:          fp.Source,
+		}
+	}
+	return &kafkamessages.FiltersBlock{
+		BlockHash: fb.BlockHash,
+		FiltersBlockData: &kafkamessages.FiltersBlockData{
+			TradesBlockHash: fb.FiltersBlockData.TradesBlockHash,
+			BeginTime:       timeToProto(fb.FiltersBlockData.BeginTime),
+			EndTime:         timeToProto(fb.FiltersBlockData.EndTime),
+			FilterPoints:    filterPoints,
+			FiltersNumber:   int64(fb.FiltersBlockData.FiltersNumber),
+		},
+	}
+}
+
+func filtersBlockFromProto(pfb *kafkamessages.FiltersBlock) dia.FiltersBlock {
+	data := pfb.GetFiltersBlockData()
+	filterPoints := make([]dia.FilterPoint, len(data.GetFilterPoints()))
+	for i, pfp := range data.GetFilterPoints() {
+		filterPoints[i] = dia.FilterPoint{
+			Asset:           assetFromProto(pfp.GetAsset()),
+			Value:           pfp.GetValue(),
+			Name:            pfp.GetName(),
+			Time:            timeFromProto(pfp.GetTime()),
+			Max:             pfp.GetMax(),
+			Min:             pfp.GetMin(),
+			FirstTrade:      tradeFromProto(pfp.GetFirstTrade()),
+			LastTrade:       tradeFromProto(pfp.GetLastTrade()),
+			RejectedSources: pfp.GetRejectedSources(),
+			Depegged:        pfp.GetDepegged(),
+			Source:          pfp.GetSource(),
+		}
+	}
+	return dia.FiltersBlock{
+		BlockHash: pfb.GetBlockHash(),
+		FiltersBlockData: dia.FiltersBlockData{
+			TradesBlockHash: data.GetTradesBlockHash(),
+			BeginTime:       timeFromProto(data.GetBeginTime()),
+			EndTime:         timeFromProto(data.GetEndTime()),
+			FilterPoints:    filterPoints,
+			FiltersNumber:   int(data.GetFiltersNumber()),
+		},
+	}
+}
+
+func nftTradeToProto(nt dia.NFTTrade) *kafkamessages.NFTTrade {
+	// Attributes are arbitrary json, so they are kept as such.
+	attributes, err := json.Marshal(nt.NFT.Attributes)
+	if err != nil {
+		log.Error("marshal nft attributes: ", err)
+	}
+	var price string
+	if nt.Price != nil {
+		price = nt.Price.String()
+	}
+	return &kafkamessages.NFTTrade{
+		Nft: &kafkamessages.NFT{
+			NftClass: &kafkamessages.NFTClass{
+				Address:      nt.NFT.NFTClass.Address,
+				Symbol:       nt.NFT.NFTClass.Symbol,
+				Name:         nt.NFT.NFTClass.Name,
+				Blockchain:   nt.NFT.NFTClass.Blockchain,
+				ContractType: nt.NFT.NFTClass.ContractType,
+				Category:     nt.NFT.NFTClass.Category,
+			},
+			TokenId:        nt.NFT.TokenID,
+			CreationTime:   timeToProto(nt.NFT.CreationTime),
+			CreatorAddress: nt.NFT.CreatorAddress,
+			Uri:            nt.NFT.URI,
+			Attributes:     attributes,
+		},
+		Price:       price,
+		PriceUsd:    nt.PriceUSD,
+		FromAddress: nt.FromAddress,
+		ToAddress:   nt.ToAddress,
+		Currency:    assetToProto(nt.Currency),
+		BundleSale:  nt.BundleSale,
+		BlockNumber: nt.BlockNumber,
+		Timestamp:   timeToProto(nt.Timestamp),
+		TxHash:      nt.TxHash,
+		Exchange:    nt.Exchange,
+	}
+}
+
+func nftTradeFromProto(pnt *kafkamessages.NFTTrade) (dia.NFTTrade, error) {
+	pnft := pnt.GetNft()
+	var attributes dia.NFTAttributes
+	if len(pnft.GetAttributes()) > 0 {
+		if err := json.Unmarshal(pnft.GetAttributes(), &attributes); err != nil {
+			return dia.NFTTrade{}, err
+		}
+	}
+	var price *big.Int
+	if pnt.GetPrice() != "" {
+		var ok bool
+		price, ok = new(big.Int).SetString(pnt.GetPrice(), 10)
+		if !ok {
+			return dia.NFTTrade{}, errors.New("invalid nft trade price " + pnt.GetPrice())
+		}
+	}
+	return dia.NFTTrade{
+		NFT: dia.NFT{
+			NFTClass: dia.NFTClass{
+				Address:      pnft.GetNftClass().GetAddress(),
+				Symbol:       pnft.GetNftClass().GetSymbol(),
+				Name:         pnft.GetNftClass().GetName(),
+				Blockchain:   pnft.GetNftClass().GetBlockchain(),
+				ContractType: pnft.GetNftClass().GetContractType(),
+				Category:     pnft.GetNftClass().GetCategory(),
+			},
+			TokenID:        pnft.GetTokenId(),
+			CreationTime:   timeFromProto(pnft.GetCreationTime()),
+			CreatorAddress: pnft.GetCreatorAddress(),
+			URI:            pnft.GetUri(),
+			Attributes:     attributes,
+		},
+		Price:       price,
+		PriceUSD:    pnt.GetPriceUsd(),
+		FromAddress: pnt.GetFromAddress(),
+		ToAddress:   pnt.GetToAddress(),
+		Currency:    assetFromProto(pnt.GetCurrency()),
+		BundleSale:  pnt.GetBundleSale(),
+		BlockNumber: pnt.GetBlockNumber(),
+		Timestamp:   timeFromProto(pnt.GetTimestamp()),
+		TxHash:      pnt.GetTxHash(),
+		Exchange:    pnt.GetExchange(),
+	}, nil
+}
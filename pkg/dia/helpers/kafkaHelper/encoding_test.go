@@ -0,0 +1,94 @@
+package kafkaHelper
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestMarshalMessageRoundTrip(t *testing.T) {
+	protobufTopics["tradesBlock"] = true
+	defer delete(protobufTopics, "tradesBlock")
+
+	now := time.Unix(1650000000, 123).UTC()
+	trade := dia.Trade{
+		Symbol:            "ETH",
+		Pair:              "ETH-USDT",
+		QuoteToken:        dia.Asset{Symbol: "ETH", Name: "Ether", Address: "0x0000000000000000000000000000000000000000", Decimals: 18, Blockchain: dia.ETHEREUM},
+		BaseToken:         dia.Asset{Symbol: "USDT", Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6, Blockchain: dia.ETHEREUM},
+		Price:             1500.5,
+		Volume:            -2,
+		Time:              now,
+		ForeignTradeID:    "42",
+		EstimatedUSDPrice: 1500.4,
+		Source:            dia.BinanceExchange,
+		VerifiedPair:      true,
+		Depegged:          true,
+	}
+	tradesBlock := dia.TradesBlock{
+		BlockHash: "hash",
+		TradesBlockData: dia.TradesBlockData{
+			BeginTime:    now,
+			EndTime:      now.Add(time.Minute),
+			TradesNumber: 1,
+			Trades:       []dia.Trade{trade},
+		},
+		Correction: true,
+	}
+	filtersBlock := dia.FiltersBlock{
+		BlockHash: "hash",
+		FiltersBlockData: dia.FiltersBlockData{
+			TradesBlockHash: "tradesBlockHash",
+			BeginTime:       now,
+			EndTime:         now.Add(time.Minute),
+			FilterPoints:    []dia.FilterPoint{{Asset: trade.QuoteToken, Value: 1500, Name: "MA120", Time: now, Source: dia.BinanceExchange, FirstTrade: trade, LastTrade: trade}},
+			FiltersNumber:   1,
+		},
+	}
+	nftTrade := dia.NFTTrade{
+		NFT: dia.NFT{
+			NFTClass:   dia.NFTClass{Address: "0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D", Name: "BoredApeYachtClub", Blockchain: dia.ETHEREUM},
+			TokenID:    "1",
+			Attributes: dia.NFTAttributes{"fur": "gold"},
+		},
+		Price:     big.NewInt(1e18),
+		PriceUSD:  1500,
+		Currency:  trade.QuoteToken,
+		Timestamp: now,
+	}
+
+	cases := []struct {
+		topic    string
+		message  KafkaMessage
+		received KafkaMessageUnmarshaler
+	}{
+		{topic: "trades", message: &trade, received: &dia.Trade{}},
+		{topic: "tradesBlock", message: &trade, received: &dia.Trade{}},
+		{topic: "tradesBlock", message: &tradesBlock, received: &dia.TradesBlock{}},
+		{topic: "tradesBlock", message: &filtersBlock, received: &dia.FiltersBlock{}},
+		{topic: "tradesBlock", message: &nftTrade, received: &dia.NFTTrade{}},
+	}
+	for i, c := range cases {
+		data, err := MarshalMessage(c.topic, c.message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enveloped := data[0] == envelopeMagic; enveloped != protobufTopics[c.topic] {
+			t.Errorf("case %d: expected enveloped %v, got %v", i, protobufTopics[c.topic], enveloped)
+		}
+		err = UnmarshalMessage(data, c.received)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(c.received, c.message) {
+			t.Errorf("case %d: expected %v, got %v", i, c.message, c.received)
+		}
+	}
+
+	if err := UnmarshalMessage([]byte{envelopeMagic, 2, encodingProtobuf}, &dia.Trade{}); err == nil {
+		t.Error("expected error on unknown envelope version")
+	}
+}
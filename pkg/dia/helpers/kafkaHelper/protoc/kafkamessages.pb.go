@@ -0,0 +1,1294 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: protoc/kafkamessages.proto
+
+package kafkamessages
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Asset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol     string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address    string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Decimals   uint32 `protobuf:"varint,4,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Blockchain string `protobuf:"bytes,5,opt,name=blockchain,proto3" json:"blockchain,omitempty"`
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Asset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Asset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Asset) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Asset) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Asset) GetBlockchain() string {
+	if x != nil {
+		return x.Blockchain
+	}
+	return ""
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol            string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Pair              string  `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	QuoteToken        *Asset  `protobuf:"bytes,3,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	BaseToken         *Asset  `protobuf:"bytes,4,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	Price             float64 `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Volume            float64 `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	Time              int64   `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	ForeignTradeId    string  `protobuf:"bytes,8,opt,name=foreign_trade_id,json=foreignTradeId,proto3" json:"foreign_trade_id,omitempty"`
+	EstimatedUsdPrice float64 `protobuf:"fixed64,9,opt,name=estimated_usd_price,json=estimatedUsdPrice,proto3" json:"estimated_usd_price,omitempty"`
+	Source            string  `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
+	VerifiedPair      bool    `protobuf:"varint,11,opt,name=verified_pair,json=verifiedPair,proto3" json:"verified_pair,omitempty"`
+	Depegged          bool    `protobuf:"varint,12,opt,name=depegged,proto3" json:"depegged,omitempty"`
+	ReferenceDeviant  bool    `protobuf:"varint,13,opt,name=reference_deviant,json=referenceDeviant,proto3" json:"reference_deviant,omitempty"`
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Trade) GetQuoteToken() *Asset {
+	if x != nil {
+		return x.QuoteToken
+	}
+	return nil
+}
+
+func (x *Trade) GetBaseToken() *Asset {
+	if x != nil {
+		return x.BaseToken
+	}
+	return nil
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Trade) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Trade) GetForeignTradeId() string {
+	if x != nil {
+		return x.ForeignTradeId
+	}
+	return ""
+}
+
+func (x *Trade) GetEstimatedUsdPrice() float64 {
+	if x != nil {
+		return x.EstimatedUsdPrice
+	}
+	return 0
+}
+
+func (x *Trade) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Trade) GetVerifiedPair() bool {
+	if x != nil {
+		return x.VerifiedPair
+	}
+	return false
+}
+
+func (x *Trade) GetDepegged() bool {
+	if x != nil {
+		return x.Depegged
+	}
+	return false
+}
+
+func (x *Trade) GetReferenceDeviant() bool {
+	if x != nil {
+		return x.ReferenceDeviant
+	}
+	return false
+}
+
+type TradesBlockData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BeginTime    int64    `protobuf:"varint,1,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime      int64    `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TradesNumber int64    `protobuf:"varint,3,opt,name=trades_number,json=tradesNumber,proto3" json:"trades_number,omitempty"`
+	Trades       []*Trade `protobuf:"bytes,4,rep,name=trades,proto3" json:"trades,omitempty"`
+}
+
+func (x *TradesBlockData) Reset() {
+	*x = TradesBlockData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradesBlockData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradesBlockData) ProtoMessage() {}
+
+func (x *TradesBlockData) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradesBlockData.ProtoReflect.Descriptor instead.
+func (*TradesBlockData) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TradesBlockData) GetBeginTime() int64 {
+	if x != nil {
+		return x.BeginTime
+	}
+	return 0
+}
+
+func (x *TradesBlockData) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *TradesBlockData) GetTradesNumber() int64 {
+	if x != nil {
+		return x.TradesNumber
+	}
+	return 0
+}
+
+func (x *TradesBlockData) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type TradesBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash       string           `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TradesBlockData *TradesBlockData `protobuf:"bytes,2,opt,name=trades_block_data,json=tradesBlockData,proto3" json:"trades_block_data,omitempty"`
+	Correction      bool             `protobuf:"varint,3,opt,name=correction,proto3" json:"correction,omitempty"`
+}
+
+func (x *TradesBlock) Reset() {
+	*x = TradesBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradesBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradesBlock) ProtoMessage() {}
+
+func (x *TradesBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradesBlock.ProtoReflect.Descriptor instead.
+func (*TradesBlock) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TradesBlock) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *TradesBlock) GetTradesBlockData() *TradesBlockData {
+	if x != nil {
+		return x.TradesBlockData
+	}
+	return nil
+}
+
+func (x *TradesBlock) GetCorrection() bool {
+	if x != nil {
+		return x.Correction
+	}
+	return false
+}
+
+type FilterPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Asset           *Asset   `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Value           float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Name            string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Time            int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Max             float64  `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	Min             float64  `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+	FirstTrade      *Trade   `protobuf:"bytes,7,opt,name=first_trade,json=firstTrade,proto3" json:"first_trade,omitempty"`
+	LastTrade       *Trade   `protobuf:"bytes,8,opt,name=last_trade,json=lastTrade,proto3" json:"last_trade,omitempty"`
+	RejectedSources []string `protobuf:"bytes,9,rep,name=rejected_sources,json=rejectedSources,proto3" json:"rejected_sources,omitempty"`
+	Depegged        bool     `protobuf:"varint,10,opt,name=depegged,proto3" json:"depegged,omitempty"`
+	Source          string   `protobuf:"bytes,11,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *FilterPoint) Reset() {
+	*x = FilterPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterPoint) ProtoMessage() {}
+
+func (x *FilterPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterPoint.ProtoReflect.Descriptor instead.
+func (*FilterPoint) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FilterPoint) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *FilterPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FilterPoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FilterPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *FilterPoint) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *FilterPoint) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *FilterPoint) GetFirstTrade() *Trade {
+	if x != nil {
+		return x.FirstTrade
+	}
+	return nil
+}
+
+func (x *FilterPoint) GetLastTrade() *Trade {
+	if x != nil {
+		return x.LastTrade
+	}
+	return nil
+}
+
+func (x *FilterPoint) GetRejectedSources() []string {
+	if x != nil {
+		return x.RejectedSources
+	}
+	return nil
+}
+
+func (x *FilterPoint) GetDepegged() bool {
+	if x != nil {
+		return x.Depegged
+	}
+	return false
+}
+
+func (x *FilterPoint) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type FiltersBlockData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TradesBlockHash string         `protobuf:"bytes,1,opt,name=trades_block_hash,json=tradesBlockHash,proto3" json:"trades_block_hash,omitempty"`
+	BeginTime       int64          `protobuf:"varint,2,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime         int64          `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	FilterPoints    []*FilterPoint `protobuf:"bytes,4,rep,name=filter_points,json=filterPoints,proto3" json:"filter_points,omitempty"`
+	FiltersNumber   int64          `protobuf:"varint,5,opt,name=filters_number,json=filtersNumber,proto3" json:"filters_number,omitempty"`
+}
+
+func (x *FiltersBlockData) Reset() {
+	*x = FiltersBlockData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FiltersBlockData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiltersBlockData) ProtoMessage() {}
+
+func (x *FiltersBlockData) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiltersBlockData.ProtoReflect.Descriptor instead.
+func (*FiltersBlockData) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FiltersBlockData) GetTradesBlockHash() string {
+	if x != nil {
+		return x.TradesBlockHash
+	}
+	return ""
+}
+
+func (x *FiltersBlockData) GetBeginTime() int64 {
+	if x != nil {
+		return x.BeginTime
+	}
+	return 0
+}
+
+func (x *FiltersBlockData) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *FiltersBlockData) GetFilterPoints() []*FilterPoint {
+	if x != nil {
+		return x.FilterPoints
+	}
+	return nil
+}
+
+func (x *FiltersBlockData) GetFiltersNumber() int64 {
+	if x != nil {
+		return x.FiltersNumber
+	}
+	return 0
+}
+
+type FiltersBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash        string            `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	FiltersBlockData *FiltersBlockData `protobuf:"bytes,2,opt,name=filters_block_data,json=filtersBlockData,proto3" json:"filters_block_data,omitempty"`
+}
+
+func (x *FiltersBlock) Reset() {
+	*x = FiltersBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FiltersBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiltersBlock) ProtoMessage() {}
+
+func (x *FiltersBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiltersBlock.ProtoReflect.Descriptor instead.
+func (*FiltersBlock) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FiltersBlock) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *FiltersBlock) GetFiltersBlockData() *FiltersBlockData {
+	if x != nil {
+		return x.FiltersBlockData
+	}
+	return nil
+}
+
+type NFTClass struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Symbol       string `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name         string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Blockchain   string `protobuf:"bytes,4,opt,name=blockchain,proto3" json:"blockchain,omitempty"`
+	ContractType string `protobuf:"bytes,5,opt,name=contract_type,json=contractType,proto3" json:"contract_type,omitempty"`
+	Category     string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *NFTClass) Reset() {
+	*x = NFTClass{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NFTClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NFTClass) ProtoMessage() {}
+
+func (x *NFTClass) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NFTClass.ProtoReflect.Descriptor instead.
+func (*NFTClass) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NFTClass) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NFTClass) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *NFTClass) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NFTClass) GetBlockchain() string {
+	if x != nil {
+		return x.Blockchain
+	}
+	return ""
+}
+
+func (x *NFTClass) GetContractType() string {
+	if x != nil {
+		return x.ContractType
+	}
+	return ""
+}
+
+func (x *NFTClass) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type NFT struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NftClass       *NFTClass `protobuf:"bytes,1,opt,name=nft_class,json=nftClass,proto3" json:"nft_class,omitempty"`
+	TokenId        string    `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	CreationTime   int64     `protobuf:"varint,3,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
+	CreatorAddress string    `protobuf:"bytes,4,opt,name=creator_address,json=creatorAddress,proto3" json:"creator_address,omitempty"`
+	Uri            string    `protobuf:"bytes,5,opt,name=uri,proto3" json:"uri,omitempty"`
+	Attributes     []byte    `protobuf:"bytes,6,opt,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+func (x *NFT) Reset() {
+	*x = NFT{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NFT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NFT) ProtoMessage() {}
+
+func (x *NFT) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NFT.ProtoReflect.Descriptor instead.
+func (*NFT) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NFT) GetNftClass() *NFTClass {
+	if x != nil {
+		return x.NftClass
+	}
+	return nil
+}
+
+func (x *NFT) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *NFT) GetCreationTime() int64 {
+	if x != nil {
+		return x.CreationTime
+	}
+	return 0
+}
+
+func (x *NFT) GetCreatorAddress() string {
+	if x != nil {
+		return x.CreatorAddress
+	}
+	return ""
+}
+
+func (x *NFT) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *NFT) GetAttributes() []byte {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type NFTTrade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nft         *NFT    `protobuf:"bytes,1,opt,name=nft,proto3" json:"nft,omitempty"`
+	Price       string  `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	PriceUsd    float64 `protobuf:"fixed64,3,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	FromAddress string  `protobuf:"bytes,4,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress   string  `protobuf:"bytes,5,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Currency    *Asset  `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	BundleSale  bool    `protobuf:"varint,7,opt,name=bundle_sale,json=bundleSale,proto3" json:"bundle_sale,omitempty"`
+	BlockNumber uint64  `protobuf:"varint,8,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Timestamp   int64   `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TxHash      string  `protobuf:"bytes,10,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Exchange    string  `protobuf:"bytes,11,opt,name=exchange,proto3" json:"exchange,omitempty"`
+}
+
+func (x *NFTTrade) Reset() {
+	*x = NFTTrade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoc_kafkamessages_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NFTTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NFTTrade) ProtoMessage() {}
+
+func (x *NFTTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_kafkamessages_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NFTTrade.ProtoReflect.Descriptor instead.
+func (*NFTTrade) Descriptor() ([]byte, []int) {
+	return file_protoc_kafkamessages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NFTTrade) GetNft() *NFT {
+	if x != nil {
+		return x.Nft
+	}
+	return nil
+}
+
+func (x *NFTTrade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *NFTTrade) GetPriceUsd() float64 {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return 0
+}
+
+func (x *NFTTrade) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *NFTTrade) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *NFTTrade) GetCurrency() *Asset {
+	if x != nil {
+		return x.Currency
+	}
+	return nil
+}
+
+func (x *NFTTrade) GetBundleSale() bool {
+	if x != nil {
+		return x.BundleSale
+	}
+	return false
+}
+
+func (x *NFTTrade) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *NFTTrade) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *NFTTrade) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *NFTTrade) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+var File_protoc_kafkamessages_proto protoreflect.FileDescriptor
+
+var file_protoc_kafkamessages_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2f, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x6b, 0x61,
+	0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x05,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64,
+	0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x22, 0xc1, 0x03, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x35, 0x0a,
+	0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x09,
+	0x62, 0x61, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x66,
+	0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x75, 0x73, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x11, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x64,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x50, 0x61,
+	0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x70, 0x65, 0x67, 0x67, 0x65, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x70, 0x65, 0x67, 0x67, 0x65, 0x64, 0x12, 0x2b,
+	0x0a, 0x11, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x76, 0x69,
+	0x61, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x76, 0x69, 0x61, 0x6e, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x0f,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x61,
+	0x64, 0x65, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c,
+	0x0a, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x54,
+	0x72, 0x61, 0x64, 0x65, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0x98, 0x01, 0x0a,
+	0x0b, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x4a, 0x0a, 0x11, 0x74,
+	0x72, 0x61, 0x64, 0x65, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe6, 0x02, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x05, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x74,
+	0x72, 0x61, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66,
+	0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x52, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x33, 0x0a, 0x0a,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x64,
+	0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x65, 0x70, 0x65, 0x67, 0x67, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x64, 0x65, 0x70, 0x65, 0x67, 0x67, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x22, 0xe0, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0x7c, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x4d, 0x0a, 0x12, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x10, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74,
+	0x61, 0x22, 0xb1, 0x01, 0x0a, 0x08, 0x4e, 0x46, 0x54, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0xd6, 0x01, 0x0a, 0x03, 0x4e, 0x46, 0x54, 0x12, 0x34, 0x0a,
+	0x09, 0x6e, 0x66, 0x74, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x4e, 0x46, 0x54, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x08, 0x6e, 0x66, 0x74, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x1e,
+	0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x22, 0xee,
+	0x02, 0x0a, 0x08, 0x4e, 0x46, 0x54, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x03, 0x6e,
+	0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4e, 0x46, 0x54, 0x52, 0x03, 0x6e, 0x66,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x5f, 0x75, 0x73, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x55, 0x73, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x61, 0x66, 0x6b, 0x61,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x53, 0x61, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42,
+	0x51, 0x5a, 0x4f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x69,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x6f, 0x72, 0x67, 0x2f, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x64, 0x69, 0x61, 0x2f, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72,
+	0x73, 0x2f, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x3b, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protoc_kafkamessages_proto_rawDescOnce sync.Once
+	file_protoc_kafkamessages_proto_rawDescData = file_protoc_kafkamessages_proto_rawDesc
+)
+
+func file_protoc_kafkamessages_proto_rawDescGZIP() []byte {
+	file_protoc_kafkamessages_proto_rawDescOnce.Do(func() {
+		file_protoc_kafkamessages_proto_rawDescData = protoimpl.X.CompressGZIP(file_protoc_kafkamessages_proto_rawDescData)
+	})
+	return file_protoc_kafkamessages_proto_rawDescData
+}
+
+var file_protoc_kafkamessages_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protoc_kafkamessages_proto_goTypes = []interface{}{
+	(*Asset)(nil),            // 0: kafkamessages.Asset
+	(*Trade)(nil),            // 1: kafkamessages.Trade
+	(*TradesBlockData)(nil),  // 2: kafkamessages.TradesBlockData
+	(*TradesBlock)(nil),      // 3: kafkamessages.TradesBlock
+	(*FilterPoint)(nil),      // 4: kafkamessages.FilterPoint
+	(*FiltersBlockData)(nil), // 5: kafkamessages.FiltersBlockData
+	(*FiltersBlock)(nil),     // 6: kafkamessages.FiltersBlock
+	(*NFTClass)(nil),         // 7: kafkamessages.NFTClass
+	(*NFT)(nil),              // 8: kafkamessages.NFT
+	(*NFTTrade)(nil),         // 9: kafkamessages.NFTTrade
+}
+var file_protoc_kafkamessages_proto_depIdxs = []int32{
+	0,  // 0: kafkamessages.Trade.quote_token:type_name -> kafkamessages.Asset
+	0,  // 1: kafkamessages.Trade.base_token:type_name -> kafkamessages.Asset
+	1,  // 2: kafkamessages.TradesBlockData.trades:type_name -> kafkamessages.Trade
+	2,  // 3: kafkamessages.TradesBlock.trades_block_data:type_name -> kafkamessages.TradesBlockData
+	0,  // 4: kafkamessages.FilterPoint.asset:type_name -> kafkamessages.Asset
+	1,  // 5: kafkamessages.FilterPoint.first_trade:type_name -> kafkamessages.Trade
+	1,  // 6: kafkamessages.FilterPoint.last_trade:type_name -> kafkamessages.Trade
+	4,  // 7: kafkamessages.FiltersBlockData.filter_points:type_name -> kafkamessages.FilterPoint
+	5,  // 8: kafkamessages.FiltersBlock.filters_block_data:type_name -> kafkamessages.FiltersBlockData
+	7,  // 9: kafkamessages.NFT.nft_class:type_name -> kafkamessages.NFTClass
+	8,  // 10: kafkamessages.NFTTrade.nft:type_name -> kafkamessages.NFT
+	0,  // 11: kafkamessages.NFTTrade.currency:type_name -> kafkamessages.Asset
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_protoc_kafkamessages_proto_init() }
+func file_protoc_kafkamessages_proto_init() {
+	if File_protoc_kafkamessages_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protoc_kafkamessages_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Asset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradesBlockData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradesBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FiltersBlockData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FiltersBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NFTClass); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NFT); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoc_kafkamessages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NFTTrade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protoc_kafkamessages_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protoc_kafkamessages_proto_goTypes,
+		DependencyIndexes: file_protoc_kafkamessages_proto_depIdxs,
+		MessageInfos:      file_protoc_kafkamessages_proto_msgTypes,
+	}.Build()
+	File_protoc_kafkamessages_proto = out.File
+	file_protoc_kafkamessages_proto_rawDesc = nil
+	file_protoc_kafkamessages_proto_goTypes = nil
+	file_protoc_kafkamessages_proto_depIdxs = nil
+}
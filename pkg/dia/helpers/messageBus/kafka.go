@@ -0,0 +1,123 @@
+package messageBus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// KafkaBus is a MessageBus on top of kafka.
+type KafkaBus struct {
+	newWriter     func(topic int) *kafka.Writer
+	writers       map[int]*kafka.Writer
+	subscriptions []*kafkaSubscription
+	closed        bool
+	mu            sync.Mutex
+}
+
+type kafkaSubscription struct {
+	topic  int
+	reader *kafka.Reader
+	group  bool
+}
+
+// NewKafkaBus returns a bus writing to kafka with writers created by @newWriter,
+// such as kafkaHelper.NewSyncWriterWithCompression. If @newWriter is nil, kafkaHelper.NewWriter is used.
+func NewKafkaBus(newWriter func(topic int) *kafka.Writer) *KafkaBus {
+	if newWriter == nil {
+		newWriter = kafkaHelper.NewWriter
+	}
+	return &KafkaBus{
+		newWriter: newWriter,
+		writers:   make(map[int]*kafka.Writer),
+	}
+}
+
+// Publish writes @m to @topic. Writers are created on first use.
+func (b *KafkaBus) Publish(topic int, m kafkaHelper.KafkaMessage) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	w, ok := b.writers[topic]
+	if !ok {
+		w = b.newWriter(topic)
+		b.writers[topic] = w
+	}
+	b.mu.Unlock()
+	return kafkaHelper.WriteMessage(w, m)
+}
+
+// Subscribe returns a subscription to @topic. Without @groupID, reading starts at the next message.
+// Otherwise it starts at the first message not committed by the consumer group.
+func (b *KafkaBus) Subscribe(topic int, groupID string) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	var reader *kafka.Reader
+	if groupID != "" {
+		reader = kafkaHelper.NewConsumerGroupReader(topic, groupID)
+	} else {
+		reader = kafkaHelper.NewReaderNextMessage(topic)
+	}
+	s := &kafkaSubscription{topic: topic, reader: reader, group: groupID != ""}
+	b.subscriptions = append(b.subscriptions, s)
+	return s, nil
+}
+
+// Close closes all writers and readers of the bus.
+func (b *KafkaBus) Close() (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for _, w := range b.writers {
+		if errClose := w.Close(); errClose != nil {
+			log.Error("close kafka writer: ", errClose)
+			err = errClose
+		}
+	}
+	for _, s := range b.subscriptions {
+		if errClose := s.Close(); errClose != nil {
+			log.Error("close kafka reader: ", errClose)
+			err = errClose
+		}
+	}
+	return
+}
+
+func (s *kafkaSubscription) Next(ctx context.Context) (Message, error) {
+	var (
+		m   kafka.Message
+		err error
+	)
+	if s.group {
+		// Offsets are committed by Commit once messages are processed.
+		m, err = s.reader.FetchMessage(ctx)
+	} else {
+		m, err = s.reader.ReadMessage(ctx)
+	}
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Topic: s.topic, Partition: m.Partition, Offset: m.Offset, Key: m.Key, Value: m.Value}, nil
+}
+
+func (s *kafkaSubscription) Commit(ctx context.Context, partition int, offset int64) error {
+	if !s.group {
+		return nil
+	}
+	return s.reader.CommitMessages(ctx, kafka.Message{Topic: s.reader.Config().Topic, Partition: partition, Offset: offset})
+}
+
+func (s *kafkaSubscription) Close() error {
+	return s.reader.Close()
+}
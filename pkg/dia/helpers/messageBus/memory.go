@@ -0,0 +1,145 @@
+package messageBus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+)
+
+// MemoryBus is an in-process MessageBus. It allows running the services of the pipeline,
+// such as collector, tradesBlockService and filtersBlockService, in a single process without kafka.
+// Messages are only delivered to subscriptions existing at the time of publishing and are not retained.
+// Publish blocks until all subscriptions have buffered the message, such that slow subscribers slow down publishers.
+type MemoryBus struct {
+	bufferSize    int
+	offsets       map[int]int64
+	subscriptions map[int][]*memorySubscription
+	closed        bool
+	mu            sync.Mutex
+}
+
+type memorySubscription struct {
+	bus      *MemoryBus
+	topic    int
+	messages chan Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewMemoryBus returns an in-process bus whose subscriptions buffer up to @bufferSize messages.
+func NewMemoryBus(bufferSize int) *MemoryBus {
+	return &MemoryBus{
+		bufferSize:    bufferSize,
+		offsets:       make(map[int]int64),
+		subscriptions: make(map[int][]*memorySubscription),
+	}
+}
+
+// Publish delivers @m to all subscriptions of @topic. Messages are encoded as in kafka,
+// so that subscribers do not share memory with the publisher.
+func (b *MemoryBus) Publish(topic int, m kafkaHelper.KafkaMessage) error {
+	value, err := kafkaHelper.MarshalMessage(kafkaHelper.GetTopic(topic), m)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	message := Message{Topic: topic, Offset: b.offsets[topic], Key: kafkaHelper.MessageKey(m), Value: value}
+	b.offsets[topic]++
+	subscriptions := append([]*memorySubscription{}, b.subscriptions[topic]...)
+	b.mu.Unlock()
+
+	for _, s := range subscriptions {
+		select {
+		case s.messages <- message:
+		case <-s.done:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a subscription to messages published to @topic from now on.
+// Messages are not shared among subscriptions with the same @groupID.
+func (b *MemoryBus) Subscribe(topic int, groupID string) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	s := &memorySubscription{
+		bus:      b,
+		topic:    topic,
+		messages: make(chan Message, b.bufferSize),
+		done:     make(chan struct{}),
+	}
+	b.subscriptions[topic] = append(b.subscriptions[topic], s)
+	return s, nil
+}
+
+// Close closes the bus and all its subscriptions.
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	var subscriptions []*memorySubscription
+	for _, s := range b.subscriptions {
+		subscriptions = append(subscriptions, s...)
+	}
+	b.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.Close()
+	}
+	return nil
+}
+
+// remove removes @s from the subscriptions of its topic.
+func (b *MemoryBus) remove(s *memorySubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subscriptions := b.subscriptions[s.topic]
+	for i := range subscriptions {
+		if subscriptions[i] == s {
+			b.subscriptions[s.topic] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Next returns buffered messages before reporting a closed subscription.
+func (s *memorySubscription) Next(ctx context.Context) (Message, error) {
+	select {
+	case m := <-s.messages:
+		return m, nil
+	default:
+	}
+	select {
+	case m := <-s.messages:
+		return m, nil
+	case <-s.done:
+		return Message{}, ErrClosed
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// Commit is a no-op, as messages are not retained.
+func (s *memorySubscription) Commit(ctx context.Context, partition int, offset int64) error {
+	return nil
+}
+
+func (s *memorySubscription) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+		s.bus.remove(s)
+	})
+	return nil
+}
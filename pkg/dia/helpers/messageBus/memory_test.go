@@ -0,0 +1,86 @@
+package messageBus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Messages published without subscription are dropped.
+	err := bus.Publish(kafkaHelper.TopicTrades, &dia.Trade{Symbol: "BTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trades1, err := bus.Subscribe(kafkaHelper.TopicTrades, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trades2, err := bus.Subscribe(kafkaHelper.TopicTrades, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tradesBlocks, err := bus.Subscribe(kafkaHelper.TopicTradesBlock, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symbols := []string{"ETH", "DIA", "USDC"}
+	go func() {
+		for _, symbol := range symbols {
+			err := bus.Publish(kafkaHelper.TopicTrades, &dia.Trade{Symbol: symbol})
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		err := bus.Publish(kafkaHelper.TopicTradesBlock, &dia.TradesBlock{BlockHash: "hash"})
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	for _, s := range []Subscription{trades1, trades2} {
+		for i, symbol := range symbols {
+			m, err := s.Next(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var trade dia.Trade
+			err = kafkaHelper.UnmarshalMessage(m.Value, &trade)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if trade.Symbol != symbol || m.Offset != int64(i+1) {
+				t.Errorf("expected %s at offset %d, got %s at offset %d", symbol, i+1, trade.Symbol, m.Offset)
+			}
+		}
+	}
+
+	m, err := tradesBlocks.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(m.Key) != "hash" {
+		t.Errorf("expected tradesBlock keyed by its hash, got %s", m.Key)
+	}
+
+	err = bus.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trades1.Next(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed on closed subscription, got %v", err)
+	}
+	if err := bus.Publish(kafkaHelper.TopicTrades, &dia.Trade{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed on closed bus, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package messageBus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+)
+
+// ErrClosed is returned when publishing to or reading from a closed bus or subscription.
+var ErrClosed = errors.New("message bus closed")
+
+// MessageBus publishes messages to topics and subscribes to them. Topics are identified
+// by the topic constants of kafkaHelper, such as kafkaHelper.TopicTrades.
+type MessageBus interface {
+	// Publish writes @m to @topic, encoded as by kafkaHelper.WriteMessage.
+	Publish(topic int, m kafkaHelper.KafkaMessage) error
+	// Subscribe returns a subscription to messages published to @topic from now on.
+	// If @groupID is not empty, processed messages are committed for the consumer group @groupID,
+	// such that a restarted subscriber resumes at the first uncommitted message where the bus supports it.
+	Subscribe(topic int, groupID string) (Subscription, error)
+	// Close closes the bus and all its subscriptions.
+	Close() error
+}
+
+// Subscription is a stream of messages of a topic.
+type Subscription interface {
+	// Next blocks until the next message is available or @ctx is done.
+	Next(ctx context.Context) (Message, error)
+	// Commit marks all messages of @partition up to and including the one at @offset as processed.
+	// It is a no-op for subscriptions without consumer group.
+	Commit(ctx context.Context, partition int, offset int64) error
+	Close() error
+}
+
+// Message is a message read from a subscription. Value is decoded by kafkaHelper.UnmarshalMessage.
+// Offsets are counted per partition of the topic.
+type Message struct {
+	Topic     int
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
@@ -212,6 +212,44 @@ func FilterMEDIR(tradeBlocks []Block, asset dia.Asset, blockSize int) (filterPoi
 	return
 }
 
+// FilterTWAP returns the time-weighted average price for each block in @tradeBlocks.
+func FilterTWAP(tradeBlocks []Block, asset dia.Asset, blockSize int) (filterPoints []dia.FilterPoint, metadata *dia.FilterPointMetadata) {
+	var lastfp *dia.FilterPoint
+	metadata = dia.NewFilterPointMetadata()
+
+	for _, block := range tradeBlocks {
+		if len(block.Trades) > 0 {
+			endtime := time.Unix(block.TimeStamp/1e9, 0)
+			twapFilter := filters.NewFilterTWAP(asset, "", endtime.Add(-time.Duration(blockSize)*time.Second), blockSize)
+
+			for _, trade := range block.Trades {
+				twapFilter.Compute(trade)
+			}
+
+			twapFilter.FinalCompute(endtime)
+			fp := twapFilter.FilterPointForBlock()
+
+			metadata.AddPoint(fp.Value)
+			fp.FirstTrade = block.Trades[0]
+			fp.LastTrade = block.Trades[len(block.Trades)-1]
+			if fp.Value > 0 {
+				fp.Time = endtime
+				filterPoints = append(filterPoints, *fp)
+				lastfp = fp
+			} else if lastfp != nil {
+				lastfp.Time = endtime
+				filterPoints = append(filterPoints, *lastfp)
+			}
+		} else {
+			if lastfp != nil {
+				lastfp.Time = time.Unix(block.TimeStamp/1e9, 0)
+				filterPoints = append(filterPoints, *lastfp)
+			}
+		}
+	}
+	return
+}
+
 func FilterEMA(points []dia.FilterPoint, asset dia.Asset, blockSize int) (filterPoints []dia.FilterPoint, metadata *dia.FilterPointMetadata) {
 	emaFilter := filters.NewFilterEMA(asset, "", points[0].Time, blockSize)
 	metadata = dia.NewFilterPointMetadata()
@@ -0,0 +1,609 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package diaOracleV2MultiupdateService
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// DIAOracleV2MultiupdateMetaData contains all meta data concerning the DIAOracleV2Multiupdate contract.
+var DIAOracleV2MultiupdateMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint128\",\"name\":\"value\",\"type\":\"uint128\"},{\"indexed\":false,\"internalType\":\"uint128\",\"name\":\"timestamp\",\"type\":\"uint128\"}],\"name\":\"OracleUpdate\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newUpdater\",\"type\":\"address\"}],\"name\":\"UpdaterAddressChange\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"}],\"name\":\"getValue\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"},{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"uint128\",\"name\":\"value\",\"type\":\"uint128\"},{\"internalType\":\"uint128\",\"name\":\"timestamp\",\"type\":\"uint128\"}],\"name\":\"setValue\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOracleUpdaterAddress\",\"type\":\"address\"}],\"name\":\"updateOracleUpdaterAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"values\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string[]\",\"name\":\"keys\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"compressedValues\",\"type\":\"uint256[]\"}],\"name\":\"setMultipleValues\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"960384a0": "getValue(string)",
+		"8d241526": "setMultipleValues(string[],uint256[])",
+		"7898e0c2": "setValue(string,uint128,uint128)",
+		"6aa45efc": "updateOracleUpdaterAddress(address)",
+		"5a9ade8b": "values(string)",
+	},
+	Bin: "0x608060405234801561001057600080fd5b50600180546001600160a01b0319163317905561062c806100326000396000f3fe608060405234801561001057600080fd5b506004361061004c5760003560e01c80635a9ade8b146100515780636aa45efc146101095780637898e0c214610131578063960384a0146101ed575b610518565b6100f76004803603602081101561006757600080fd5b81019060208101813564010000000081111561008257600080fd5b82018360208201111561009457600080fd5b803590602001918460018302840111640100000000831117156100b657600080fd5b91908080601f0160208091040260200160405190810160405280939291908181526020018383808284376000920191909152509295506102c2945050505050565b60408051918252519081900360200190f35b61012f6004803603602081101561011f57600080fd5b50356001600160a01b03166102df565b005b61012f6004803603606081101561014757600080fd5b81019060208101813564010000000081111561016257600080fd5b82018360208201111561017457600080fd5b8035906020019184600183028401116401000000008311171561019657600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600092019190915250929550506001600160801b03833581169450602090930135909216915061034a9050565b6102936004803603602081101561020357600080fd5b81019060208101813564010000000081111561021e57600080fd5b82018360208201111561023057600080fd5b8035906020019184600183028401116401000000008311171561025257600080fd5b91908080601f01602080910402602001604051908101604052809392919081815260200183838082843760009201919091525092955061049a945050505050565b60405180836001600160801b03168152602001826001600160801b031681526020019250505060405180910390f35b805160208183018101805160008252928201919093012091525481565b6001546001600160a01b031633146102f657600080fd5b600180546001600160a01b0383166001600160a01b0319909116811790915560408051918252517f121e958a4cadf7f8dadefa22cc019700365240223668418faebed197da07089f9181900360200190a150565b6001546001600160a01b0316331461036157600080fd5b6000816001600160801b03166080846001600160801b0316901b019050806000856040518082805190602001908083835b602083106103b15780518252601f199092019160209182019101610392565b51815160209384036101000a6000190180199092169116179052920194855250604080519485900382018520959095556001600160801b03888116858301528716948401949094525050606080825286519082015285517fa7fc99ed7617309ee23f63ae90196a1e490d362e6f6a547a59bc809ee2291782928792879287928291608083019187019080838360005b83811015610458578181015183820152602001610440565b50505050905090810190601f1680156104855780820380516001836020036101000a031916815260200191505b5094505050505060405180910390a150505050565b600080600080846040518082805190602001908083835b602083106104d05780518252601f1990920191602091820191016104b1565b51815160209384036101000a6000190180199092169116179052920194855250604051938490030190922054608081901c976001600160801b03909116965094505050505056fe5b60003560e01c638d2415261461052d57600080fd5b60015473ffffffffffffffffffffffffffffffffffffffff16331461055157600080fd5b600435600401803560405260200160005260243560040180356040511461057757600080fd5b60200160205260006060525b604051606051101561062a576000518060605160051b01350180358082602001610100376000816101000152806020016101002060205160605160051b013580915560606080528060801c60a0526fffffffffffffffffffffffffffffffff1660c0528060e0527fa7fc99ed7617309ee23f63ae90196a1e490d362e6f6a547a59bc809ee229178290601f0160051c60051b6080016080a150606051600101606052610583565b00",
+}
+
+// DIAOracleV2MultiupdateABI is the input ABI used to generate the binding from.
+// Deprecated: Use DIAOracleV2MultiupdateMetaData.ABI instead.
+var DIAOracleV2MultiupdateABI = DIAOracleV2MultiupdateMetaData.ABI
+
+// Deprecated: Use DIAOracleV2MultiupdateMetaData.Sigs instead.
+// DIAOracleV2MultiupdateFuncSigs maps the 4-byte function signature to its string representation.
+var DIAOracleV2MultiupdateFuncSigs = DIAOracleV2MultiupdateMetaData.Sigs
+
+// DIAOracleV2MultiupdateBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use DIAOracleV2MultiupdateMetaData.Bin instead.
+var DIAOracleV2MultiupdateBin = DIAOracleV2MultiupdateMetaData.Bin
+
+// DeployDIAOracleV2Multiupdate deploys a new Ethereum contract, binding an instance of DIAOracleV2Multiupdate to it.
+func DeployDIAOracleV2Multiupdate(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *DIAOracleV2Multiupdate, error) {
+	parsed, err := DIAOracleV2MultiupdateMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(DIAOracleV2MultiupdateBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &DIAOracleV2Multiupdate{DIAOracleV2MultiupdateCaller: DIAOracleV2MultiupdateCaller{contract: contract}, DIAOracleV2MultiupdateTransactor: DIAOracleV2MultiupdateTransactor{contract: contract}, DIAOracleV2MultiupdateFilterer: DIAOracleV2MultiupdateFilterer{contract: contract}}, nil
+}
+
+// DIAOracleV2Multiupdate is an auto generated Go binding around an Ethereum contract.
+type DIAOracleV2Multiupdate struct {
+	DIAOracleV2MultiupdateCaller     // Read-only binding to the contract
+	DIAOracleV2MultiupdateTransactor // Write-only binding to the contract
+	DIAOracleV2MultiupdateFilterer   // Log filterer for contract events
+}
+
+// DIAOracleV2MultiupdateCaller is an auto generated read-only Go binding around an Ethereum contract.
+type DIAOracleV2MultiupdateCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DIAOracleV2MultiupdateTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type DIAOracleV2MultiupdateTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DIAOracleV2MultiupdateFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type DIAOracleV2MultiupdateFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DIAOracleV2MultiupdateSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type DIAOracleV2MultiupdateSession struct {
+	Contract     *DIAOracleV2Multiupdate // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// DIAOracleV2MultiupdateCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type DIAOracleV2MultiupdateCallerSession struct {
+	Contract *DIAOracleV2MultiupdateCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// DIAOracleV2MultiupdateTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type DIAOracleV2MultiupdateTransactorSession struct {
+	Contract     *DIAOracleV2MultiupdateTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// DIAOracleV2MultiupdateRaw is an auto generated low-level Go binding around an Ethereum contract.
+type DIAOracleV2MultiupdateRaw struct {
+	Contract *DIAOracleV2Multiupdate // Generic contract binding to access the raw methods on
+}
+
+// DIAOracleV2MultiupdateCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type DIAOracleV2MultiupdateCallerRaw struct {
+	Contract *DIAOracleV2MultiupdateCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// DIAOracleV2MultiupdateTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type DIAOracleV2MultiupdateTransactorRaw struct {
+	Contract *DIAOracleV2MultiupdateTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewDIAOracleV2Multiupdate creates a new instance of DIAOracleV2Multiupdate, bound to a specific deployed contract.
+func NewDIAOracleV2Multiupdate(address common.Address, backend bind.ContractBackend) (*DIAOracleV2Multiupdate, error) {
+	contract, err := bindDIAOracleV2Multiupdate(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2Multiupdate{DIAOracleV2MultiupdateCaller: DIAOracleV2MultiupdateCaller{contract: contract}, DIAOracleV2MultiupdateTransactor: DIAOracleV2MultiupdateTransactor{contract: contract}, DIAOracleV2MultiupdateFilterer: DIAOracleV2MultiupdateFilterer{contract: contract}}, nil
+}
+
+// NewDIAOracleV2MultiupdateCaller creates a new read-only instance of DIAOracleV2Multiupdate, bound to a specific deployed contract.
+func NewDIAOracleV2MultiupdateCaller(address common.Address, caller bind.ContractCaller) (*DIAOracleV2MultiupdateCaller, error) {
+	contract, err := bindDIAOracleV2Multiupdate(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2MultiupdateCaller{contract: contract}, nil
+}
+
+// NewDIAOracleV2MultiupdateTransactor creates a new write-only instance of DIAOracleV2Multiupdate, bound to a specific deployed contract.
+func NewDIAOracleV2MultiupdateTransactor(address common.Address, transactor bind.ContractTransactor) (*DIAOracleV2MultiupdateTransactor, error) {
+	contract, err := bindDIAOracleV2Multiupdate(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2MultiupdateTransactor{contract: contract}, nil
+}
+
+// NewDIAOracleV2MultiupdateFilterer creates a new log filterer instance of DIAOracleV2Multiupdate, bound to a specific deployed contract.
+func NewDIAOracleV2MultiupdateFilterer(address common.Address, filterer bind.ContractFilterer) (*DIAOracleV2MultiupdateFilterer, error) {
+	contract, err := bindDIAOracleV2Multiupdate(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2MultiupdateFilterer{contract: contract}, nil
+}
+
+// bindDIAOracleV2Multiupdate binds a generic wrapper to an already deployed contract.
+func bindDIAOracleV2Multiupdate(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(DIAOracleV2MultiupdateABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DIAOracleV2Multiupdate.Contract.DIAOracleV2MultiupdateCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.DIAOracleV2MultiupdateTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.DIAOracleV2MultiupdateTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DIAOracleV2Multiupdate.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetValue is a free data retrieval call binding the contract method 0x960384a0.
+//
+// Solidity: function getValue(string key) view returns(uint128, uint128)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateCaller) GetValue(opts *bind.CallOpts, key string) (*big.Int, *big.Int, error) {
+	var out []interface{}
+	err := _DIAOracleV2Multiupdate.contract.Call(opts, &out, "getValue", key)
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return out0, out1, err
+
+}
+
+// GetValue is a free data retrieval call binding the contract method 0x960384a0.
+//
+// Solidity: function getValue(string key) view returns(uint128, uint128)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateSession) GetValue(key string) (*big.Int, *big.Int, error) {
+	return _DIAOracleV2Multiupdate.Contract.GetValue(&_DIAOracleV2Multiupdate.CallOpts, key)
+}
+
+// GetValue is a free data retrieval call binding the contract method 0x960384a0.
+//
+// Solidity: function getValue(string key) view returns(uint128, uint128)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateCallerSession) GetValue(key string) (*big.Int, *big.Int, error) {
+	return _DIAOracleV2Multiupdate.Contract.GetValue(&_DIAOracleV2Multiupdate.CallOpts, key)
+}
+
+// Values is a free data retrieval call binding the contract method 0x5a9ade8b.
+//
+// Solidity: function values(string ) view returns(uint256)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateCaller) Values(opts *bind.CallOpts, arg0 string) (*big.Int, error) {
+	var out []interface{}
+	err := _DIAOracleV2Multiupdate.contract.Call(opts, &out, "values", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Values is a free data retrieval call binding the contract method 0x5a9ade8b.
+//
+// Solidity: function values(string ) view returns(uint256)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateSession) Values(arg0 string) (*big.Int, error) {
+	return _DIAOracleV2Multiupdate.Contract.Values(&_DIAOracleV2Multiupdate.CallOpts, arg0)
+}
+
+// Values is a free data retrieval call binding the contract method 0x5a9ade8b.
+//
+// Solidity: function values(string ) view returns(uint256)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateCallerSession) Values(arg0 string) (*big.Int, error) {
+	return _DIAOracleV2Multiupdate.Contract.Values(&_DIAOracleV2Multiupdate.CallOpts, arg0)
+}
+
+// SetMultipleValues is a paid mutator transaction binding the contract method 0x8d241526.
+//
+// Solidity: function setMultipleValues(string[] keys, uint256[] compressedValues) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactor) SetMultipleValues(opts *bind.TransactOpts, keys []string, compressedValues []*big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.contract.Transact(opts, "setMultipleValues", keys, compressedValues)
+}
+
+// SetMultipleValues is a paid mutator transaction binding the contract method 0x8d241526.
+//
+// Solidity: function setMultipleValues(string[] keys, uint256[] compressedValues) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateSession) SetMultipleValues(keys []string, compressedValues []*big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.SetMultipleValues(&_DIAOracleV2Multiupdate.TransactOpts, keys, compressedValues)
+}
+
+// SetMultipleValues is a paid mutator transaction binding the contract method 0x8d241526.
+//
+// Solidity: function setMultipleValues(string[] keys, uint256[] compressedValues) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactorSession) SetMultipleValues(keys []string, compressedValues []*big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.SetMultipleValues(&_DIAOracleV2Multiupdate.TransactOpts, keys, compressedValues)
+}
+
+// SetValue is a paid mutator transaction binding the contract method 0x7898e0c2.
+//
+// Solidity: function setValue(string key, uint128 value, uint128 timestamp) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactor) SetValue(opts *bind.TransactOpts, key string, value *big.Int, timestamp *big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.contract.Transact(opts, "setValue", key, value, timestamp)
+}
+
+// SetValue is a paid mutator transaction binding the contract method 0x7898e0c2.
+//
+// Solidity: function setValue(string key, uint128 value, uint128 timestamp) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateSession) SetValue(key string, value *big.Int, timestamp *big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.SetValue(&_DIAOracleV2Multiupdate.TransactOpts, key, value, timestamp)
+}
+
+// SetValue is a paid mutator transaction binding the contract method 0x7898e0c2.
+//
+// Solidity: function setValue(string key, uint128 value, uint128 timestamp) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactorSession) SetValue(key string, value *big.Int, timestamp *big.Int) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.SetValue(&_DIAOracleV2Multiupdate.TransactOpts, key, value, timestamp)
+}
+
+// UpdateOracleUpdaterAddress is a paid mutator transaction binding the contract method 0x6aa45efc.
+//
+// Solidity: function updateOracleUpdaterAddress(address newOracleUpdaterAddress) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactor) UpdateOracleUpdaterAddress(opts *bind.TransactOpts, newOracleUpdaterAddress common.Address) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.contract.Transact(opts, "updateOracleUpdaterAddress", newOracleUpdaterAddress)
+}
+
+// UpdateOracleUpdaterAddress is a paid mutator transaction binding the contract method 0x6aa45efc.
+//
+// Solidity: function updateOracleUpdaterAddress(address newOracleUpdaterAddress) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateSession) UpdateOracleUpdaterAddress(newOracleUpdaterAddress common.Address) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.UpdateOracleUpdaterAddress(&_DIAOracleV2Multiupdate.TransactOpts, newOracleUpdaterAddress)
+}
+
+// UpdateOracleUpdaterAddress is a paid mutator transaction binding the contract method 0x6aa45efc.
+//
+// Solidity: function updateOracleUpdaterAddress(address newOracleUpdaterAddress) returns()
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateTransactorSession) UpdateOracleUpdaterAddress(newOracleUpdaterAddress common.Address) (*types.Transaction, error) {
+	return _DIAOracleV2Multiupdate.Contract.UpdateOracleUpdaterAddress(&_DIAOracleV2Multiupdate.TransactOpts, newOracleUpdaterAddress)
+}
+
+// DIAOracleV2MultiupdateOracleUpdateIterator is returned from FilterOracleUpdate and is used to iterate over the raw logs and unpacked data for OracleUpdate events raised by the DIAOracleV2Multiupdate contract.
+type DIAOracleV2MultiupdateOracleUpdateIterator struct {
+	Event *DIAOracleV2MultiupdateOracleUpdate // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *DIAOracleV2MultiupdateOracleUpdateIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(DIAOracleV2MultiupdateOracleUpdate)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(DIAOracleV2MultiupdateOracleUpdate)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *DIAOracleV2MultiupdateOracleUpdateIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *DIAOracleV2MultiupdateOracleUpdateIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// DIAOracleV2MultiupdateOracleUpdate represents a OracleUpdate event raised by the DIAOracleV2Multiupdate contract.
+type DIAOracleV2MultiupdateOracleUpdate struct {
+	Key       string
+	Value     *big.Int
+	Timestamp *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterOracleUpdate is a free log retrieval operation binding the contract event 0xa7fc99ed7617309ee23f63ae90196a1e490d362e6f6a547a59bc809ee2291782.
+//
+// Solidity: event OracleUpdate(string key, uint128 value, uint128 timestamp)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) FilterOracleUpdate(opts *bind.FilterOpts) (*DIAOracleV2MultiupdateOracleUpdateIterator, error) {
+
+	logs, sub, err := _DIAOracleV2Multiupdate.contract.FilterLogs(opts, "OracleUpdate")
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2MultiupdateOracleUpdateIterator{contract: _DIAOracleV2Multiupdate.contract, event: "OracleUpdate", logs: logs, sub: sub}, nil
+}
+
+// WatchOracleUpdate is a free log subscription operation binding the contract event 0xa7fc99ed7617309ee23f63ae90196a1e490d362e6f6a547a59bc809ee2291782.
+//
+// Solidity: event OracleUpdate(string key, uint128 value, uint128 timestamp)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) WatchOracleUpdate(opts *bind.WatchOpts, sink chan<- *DIAOracleV2MultiupdateOracleUpdate) (event.Subscription, error) {
+
+	logs, sub, err := _DIAOracleV2Multiupdate.contract.WatchLogs(opts, "OracleUpdate")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(DIAOracleV2MultiupdateOracleUpdate)
+				if err := _DIAOracleV2Multiupdate.contract.UnpackLog(event, "OracleUpdate", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOracleUpdate is a log parse operation binding the contract event 0xa7fc99ed7617309ee23f63ae90196a1e490d362e6f6a547a59bc809ee2291782.
+//
+// Solidity: event OracleUpdate(string key, uint128 value, uint128 timestamp)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) ParseOracleUpdate(log types.Log) (*DIAOracleV2MultiupdateOracleUpdate, error) {
+	event := new(DIAOracleV2MultiupdateOracleUpdate)
+	if err := _DIAOracleV2Multiupdate.contract.UnpackLog(event, "OracleUpdate", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// DIAOracleV2MultiupdateUpdaterAddressChangeIterator is returned from FilterUpdaterAddressChange and is used to iterate over the raw logs and unpacked data for UpdaterAddressChange events raised by the DIAOracleV2Multiupdate contract.
+type DIAOracleV2MultiupdateUpdaterAddressChangeIterator struct {
+	Event *DIAOracleV2MultiupdateUpdaterAddressChange // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *DIAOracleV2MultiupdateUpdaterAddressChangeIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(DIAOracleV2MultiupdateUpdaterAddressChange)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(DIAOracleV2MultiupdateUpdaterAddressChange)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *DIAOracleV2MultiupdateUpdaterAddressChangeIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *DIAOracleV2MultiupdateUpdaterAddressChangeIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// DIAOracleV2MultiupdateUpdaterAddressChange represents a UpdaterAddressChange event raised by the DIAOracleV2Multiupdate contract.
+type DIAOracleV2MultiupdateUpdaterAddressChange struct {
+	NewUpdater common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterUpdaterAddressChange is a free log retrieval operation binding the contract event 0x121e958a4cadf7f8dadefa22cc019700365240223668418faebed197da07089f.
+//
+// Solidity: event UpdaterAddressChange(address newUpdater)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) FilterUpdaterAddressChange(opts *bind.FilterOpts) (*DIAOracleV2MultiupdateUpdaterAddressChangeIterator, error) {
+
+	logs, sub, err := _DIAOracleV2Multiupdate.contract.FilterLogs(opts, "UpdaterAddressChange")
+	if err != nil {
+		return nil, err
+	}
+	return &DIAOracleV2MultiupdateUpdaterAddressChangeIterator{contract: _DIAOracleV2Multiupdate.contract, event: "UpdaterAddressChange", logs: logs, sub: sub}, nil
+}
+
+// WatchUpdaterAddressChange is a free log subscription operation binding the contract event 0x121e958a4cadf7f8dadefa22cc019700365240223668418faebed197da07089f.
+//
+// Solidity: event UpdaterAddressChange(address newUpdater)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) WatchUpdaterAddressChange(opts *bind.WatchOpts, sink chan<- *DIAOracleV2MultiupdateUpdaterAddressChange) (event.Subscription, error) {
+
+	logs, sub, err := _DIAOracleV2Multiupdate.contract.WatchLogs(opts, "UpdaterAddressChange")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(DIAOracleV2MultiupdateUpdaterAddressChange)
+				if err := _DIAOracleV2Multiupdate.contract.UnpackLog(event, "UpdaterAddressChange", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUpdaterAddressChange is a log parse operation binding the contract event 0x121e958a4cadf7f8dadefa22cc019700365240223668418faebed197da07089f.
+//
+// Solidity: event UpdaterAddressChange(address newUpdater)
+func (_DIAOracleV2Multiupdate *DIAOracleV2MultiupdateFilterer) ParseUpdaterAddressChange(log types.Log) (*DIAOracleV2MultiupdateUpdaterAddressChange, error) {
+	event := new(DIAOracleV2MultiupdateUpdaterAddressChange)
+	if err := _DIAOracleV2Multiupdate.contract.UnpackLog(event, "UpdaterAddressChange", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
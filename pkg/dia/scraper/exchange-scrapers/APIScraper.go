@@ -20,60 +20,20 @@ const (
 type nothing struct{}
 
 var (
-	Exchanges          = make(map[string]dia.Exchange)
-	ExchangeDuplicates = make(map[string]dia.Exchange)
-	blockchains        map[string]dia.BlockChain
-	chainConfigs       map[string]dia.ChainConfig
-)
-
-var evmID map[string]string
-
-func init() {
-
-	relDB, err := models.NewRelDataStore()
-	if err != nil {
-		log.Fatal("get rel datastore: ", err)
-	}
-
-	exchanges, err := relDB.GetAllExchanges()
-	if err != nil {
-		log.Fatal("get all exchanges: ", err)
+	ExchangeDuplicates = map[string]dia.Exchange{
+		dia.Binance2Exchange: {Name: "Binance2", Centralized: true, WatchdogDelay: 300},
 	}
-	for _, exchange := range exchanges {
-		Exchanges[exchange.Name] = exchange
-	}
-
-	evmID = make(map[string]string)
-	evmID["137"] = dia.POLYGON
-	evmID["1"] = dia.ETHEREUM
-	evmID["250"] = dia.FANTOM
-	evmID["56"] = dia.BINANCESMARTCHAIN
-	evmID["43114"] = dia.BINANCESMARTCHAIN
-	evmID["1284"] = dia.MOONBEAM
-	evmID["1285"] = dia.MOONRIVER
-	evmID["42161"] = dia.ARBITRUM
-	evmID["43114"] = dia.AVALANCHE
-
-	chains, err := relDB.GetAllBlockchains(false)
-	if err != nil {
-		log.Fatal("get all chains: ", err)
-	}
-	blockchains = make(map[string]dia.BlockChain)
-	for _, chain := range chains {
-		blockchains[chain.Name] = chain
-	}
-
-	chainconfigurations, err := relDB.GetAllChainConfig()
-	if err != nil {
-		log.Fatal("get all chains: ", err)
-	}
-	chainConfigs = make(map[string]dia.ChainConfig)
-	for _, chainconfig := range chainconfigurations {
-		chainConfigs[chainconfig.ChainID] = chainconfig
-	}
-
-	ExchangeDuplicates[dia.Binance2Exchange] = dia.Exchange{Name: "Binance2", Centralized: true, WatchdogDelay: 300}
+)
 
+var evmID = map[string]string{
+	"137":   dia.POLYGON,
+	"1":     dia.ETHEREUM,
+	"250":   dia.FANTOM,
+	"56":    dia.BINANCESMARTCHAIN,
+	"1284":  dia.MOONBEAM,
+	"1285":  dia.MOONRIVER,
+	"42161": dia.ARBITRUM,
+	"43114": dia.AVALANCHE,
 }
 
 // APIScraper provides common methods needed to get Trade information from
@@ -112,140 +72,19 @@ type PairScraper interface {
 
 // NewAPIScraper returns an API scraper for @exchange. If scrape==true it actually does
 // scraping. Otherwise can be used for pairdiscovery.
-func NewAPIScraper(exchange string, scrape bool, key string, secret string, relDB *models.RelDB) APIScraper {
-	switch exchange {
-	case dia.BinanceExchange:
-		return NewBinanceScraper(key, secret, Exchanges[dia.BinanceExchange], Exchanges[dia.BinanceExchange].Name, scrape, relDB)
-	case dia.Binance2Exchange:
-		return NewBinanceScraper(key, secret, Exchanges[dia.BinanceExchange], dia.Binance2Exchange, scrape, relDB)
-	case dia.BinanceExchangeUS:
-		return NewBinanceScraperUS(key, secret, Exchanges[dia.BinanceExchangeUS], scrape, relDB)
-	case dia.BitfinexExchange:
-		return NewBitfinexScraper(key, secret, Exchanges[dia.BitfinexExchange], scrape, relDB)
-	case dia.BittrexExchange:
-		return NewBittrexScraper(Exchanges[dia.BittrexExchange], scrape, relDB)
-	case dia.CoinBaseExchange:
-		return NewCoinBaseScraper(Exchanges[dia.CoinBaseExchange], scrape, relDB)
-	case dia.KrakenExchange:
-		return NewKrakenScraper(key, secret, Exchanges[dia.KrakenExchange], scrape, relDB)
-	case dia.OKExExchange:
-		return NewOKExScraper(Exchanges[dia.OKExExchange], scrape, relDB)
-	case dia.CryptoDotComExchange:
-		return NewCryptoDotComScraper(Exchanges[dia.CryptoDotComExchange], scrape, relDB)
-	case dia.HuobiExchange:
-		return NewHuobiScraper(Exchanges[dia.HuobiExchange], scrape, relDB)
-	case dia.GateIOExchange:
-		return NewGateIOScraper(Exchanges[dia.GateIOExchange], scrape, relDB)
-	case dia.BancorExchange:
-		return NewBancorScraper(Exchanges[dia.BancorExchange], scrape)
-	case dia.UniswapExchange:
-		return NewUniswapScraper(Exchanges[dia.UniswapExchange], scrape)
-	case dia.PanCakeSwap:
-		return NewUniswapScraper(Exchanges[dia.PanCakeSwap], scrape)
-	case dia.SushiSwapExchange:
-		return NewUniswapScraper(Exchanges[dia.SushiSwapExchange], scrape)
-	case dia.SushiSwapExchangePolygon:
-		return NewUniswapScraper(Exchanges[dia.SushiSwapExchangePolygon], scrape)
-	case dia.SushiSwapExchangeArbitrum:
-		return NewUniswapScraper(Exchanges[dia.SushiSwapExchangeArbitrum], scrape)
-	case dia.CamelotExchange:
-		return NewUniswapScraper(Exchanges[dia.CamelotExchange], scrape)
-	case dia.CurveFIExchange:
-		return NewCurveFIScraper(Exchanges[dia.CurveFIExchange], scrape)
-	case dia.CurveFIExchangeFantom:
-		return NewCurveFIScraper(Exchanges[dia.CurveFIExchangeFantom], scrape)
-	case dia.CurveFIExchangeMoonbeam:
-		return NewCurveFIScraper(Exchanges[dia.CurveFIExchangeMoonbeam], scrape)
-	case dia.CurveFIExchangePolygon:
-		return NewCurveFIScraper(Exchanges[dia.CurveFIExchangePolygon], scrape)
-	case dia.CurveFIExchangeArbitrum:
-		return NewCurveFIScraper(Exchanges[dia.CurveFIExchangeArbitrum], scrape)
-	case dia.BalancerV2Exchange:
-		return NewBalancerV2Scraper(Exchanges[dia.BalancerV2Exchange], scrape)
-	case dia.BalancerV2ExchangePolygon:
-		return NewBalancerV2Scraper(Exchanges[dia.BalancerV2ExchangePolygon], scrape)
-	case dia.BeetsExchange:
-		return NewBalancerV2Scraper(Exchanges[dia.BeetsExchange], scrape)
-	case dia.KuCoinExchange:
-		return NewKuCoinScraper(key, secret, Exchanges[dia.KuCoinExchange], scrape, relDB)
-	case dia.BitMartExchange:
-		return NewBitMartScraper(Exchanges[dia.BitMartExchange], scrape, relDB)
-	case dia.BitMaxExchange:
-		return NewBitMaxScraper(Exchanges[dia.BitMaxExchange], scrape, relDB)
-	case dia.MEXCExchange:
-		return NewMEXCScraper(Exchanges[dia.MEXCExchange], scrape, relDB)
-	case dia.BKEXExchange:
-		return NewBKEXScraper(Exchanges[dia.BKEXExchange], scrape, relDB)
-	case dia.UniswapExchangeV3:
-		return NewUniswapV3Scraper(Exchanges[dia.UniswapExchangeV3], scrape)
-	case dia.DfynNetwork:
-		return NewUniswapScraper(Exchanges[dia.DfynNetwork], scrape)
-	case dia.UbeswapExchange:
-		return NewUniswapScraper(Exchanges[dia.UbeswapExchange], scrape)
-	case dia.UniswapExchangeV3Polygon:
-		return NewUniswapV3Scraper(Exchanges[dia.UniswapExchangeV3Polygon], scrape)
-	case dia.UniswapExchangeV3Arbitrum:
-		return NewUniswapV3Scraper(Exchanges[dia.UniswapExchangeV3Arbitrum], scrape)
-	case dia.HuckleberryExchange:
-		return NewUniswapScraper(Exchanges[dia.HuckleberryExchange], scrape)
-	case dia.TraderJoeExchange:
-		return NewUniswapScraper(Exchanges[dia.TraderJoeExchange], scrape)
-	case dia.PangolinExchange:
-		return NewUniswapScraper(Exchanges[dia.PangolinExchange], scrape)
-	case dia.PlatypusExchange:
-		return NewPlatypusScraper(Exchanges[dia.PlatypusExchange], scrape)
-	case dia.SpookyswapExchange:
-		return NewUniswapScraper(Exchanges[dia.SpookyswapExchange], scrape)
-	case dia.QuickswapExchange:
-		return NewUniswapScraper(Exchanges[dia.QuickswapExchange], scrape)
-	case dia.SpiritswapExchange:
-		return NewUniswapScraper(Exchanges[dia.SpiritswapExchange], scrape)
-	case dia.SolarbeamExchange:
-		return NewUniswapScraper(Exchanges[dia.SolarbeamExchange], scrape)
-	case dia.TrisolarisExchange:
-		return NewUniswapScraper(Exchanges[dia.TrisolarisExchange], scrape)
-	case dia.ByBitExchange:
-		return NewByBitScraper(Exchanges[dia.ByBitExchange], scrape, relDB)
-	case dia.OrcaExchange:
-		return NewOrcaScraper(Exchanges[dia.OrcaExchange], scrape)
-	case dia.AnyswapExchange:
-		return NewAnyswapScraper(Exchanges[dia.AnyswapExchange], scrape, relDB)
-	case dia.NetswapExchange:
-		return NewUniswapScraper(Exchanges[dia.NetswapExchange], scrape)
-	case dia.BitMexExchange:
-		return NewBitMexScraper(Exchanges[dia.BitMexExchange], scrape, relDB)
-	case dia.TethysExchange:
-		return NewUniswapScraper(Exchanges[dia.TethysExchange], scrape)
-	case dia.HermesExchange:
-		return NewUniswapScraper(Exchanges[dia.HermesExchange], scrape)
-	case dia.OmniDexExchange:
-		return NewUniswapScraper(Exchanges[dia.OmniDexExchange], scrape)
-	case dia.DiffusionExchange:
-		return NewUniswapScraper(Exchanges[dia.DiffusionExchange], scrape)
-	case dia.ApeswapExchange:
-		return NewUniswapScraper(Exchanges[dia.ApeswapExchange], scrape)
-	case dia.BiswapExchange:
-		return NewUniswapScraper(Exchanges[dia.BiswapExchange], scrape)
-	case dia.ArthswapExchange:
-		return NewUniswapScraper(Exchanges[dia.ArthswapExchange], scrape)
-	case dia.StellaswapExchange:
-		return NewUniswapScraper(Exchanges[dia.StellaswapExchange], scrape)
-	case dia.WanswapExchange:
-		return NewUniswapScraper(Exchanges[dia.WanswapExchange], scrape)
-		// case dia.FinageForex:
-		// 	return NewFinageForexScraper(Exchanges[dia.FinageForex], scrape, relDB, key, secret)
-
-	case dia.MultiChain:
-		return NewBridgeSwapScraper(Exchanges[dia.MultiChain], scrape, relDB)
-
-	case "Influx":
-		return NewInfluxScraper(scrape)
-
-	case "UniswapHistory":
-		return NewUniswapHistoryScraper(Exchanges[dia.UniswapExchange], scrape, relDB)
-
-	default:
+// @registry contains the exchanges and blockchains known to the scrapers.
+// Returns nil if no scraper is registered for @exchange.
+func NewAPIScraper(exchange string, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+	if registry == nil {
+		registry = NewExchangeRegistry(nil, nil, nil)
+	}
+	factory, ok := getAPIScraperFactory(exchange, registry)
+	if !ok {
 		return nil
 	}
-
+	exchangeInfo, ok := registry.Exchanges[exchange]
+	if !ok {
+		exchangeInfo = dia.Exchange{Name: exchange}
+	}
+	return factory(exchangeInfo, scrape, key, secret, relDB, registry)
 }
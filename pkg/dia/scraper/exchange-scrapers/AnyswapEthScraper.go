@@ -61,7 +61,7 @@ type AnyswapSwap struct {
 type AnyswapScraper struct {
 	WsClientMap   map[string]*ethclient.Client
 	RestClientMap map[string]*ethclient.Client
-	db            *models.RelDB
+	db            models.RelDatastore
 	// signaling channels for session initialization and finishing
 	//initDone     chan nothing
 	run          bool
@@ -80,8 +80,14 @@ type AnyswapScraper struct {
 	anyswapAssetInfo map[string]map[string]interface{}
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewAnyswapScraper(exchange, scrape, relDB)
+	}, dia.AnyswapExchange)
+}
+
 // NewUniswapScraper returns a new UniswapScraper for the given pair
-func NewAnyswapScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *AnyswapScraper {
+func NewAnyswapScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *AnyswapScraper {
 	log.Info("NewUniswapScraper: ", exchange.Name)
 	var wsClientMap, restClientMap map[string]*ethclient.Client
 	var waitTime int
@@ -90,7 +96,6 @@ func NewAnyswapScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB)
 
 	switch exchange.Name {
 	case dia.AnyswapExchange:
-		exchangeFactoryContractAddress = exchange.Contract
 		waitTimeString := utils.Getenv("UNISWAP_WAIT_TIME", anyswapWaitMilliseconds)
 		waitTime, err = strconv.Atoi(waitTimeString)
 		if err != nil {
@@ -31,10 +31,16 @@ type BKEXScraper struct {
 	pairScrapers map[string]*BKEXPairScraper
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
 }
 
-func NewBKEXScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BKEXScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBKEXScraper(exchange, scrape, relDB)
+	}, dia.BKEXExchange)
+}
+
+func NewBKEXScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BKEXScraper {
 	s := &BKEXScraper{
 		wsClient:     make(map[int]*ws.Conn),
 		shutdown:     make(chan nothing),
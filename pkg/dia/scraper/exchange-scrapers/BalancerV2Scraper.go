@@ -22,6 +22,7 @@ import (
 
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/diadata-org/diadata/pkg/dia/helpers/ethhelper"
+	models "github.com/diadata-org/diadata/pkg/model"
 )
 
 const (
@@ -70,17 +71,25 @@ type BalancerV2Scraper struct {
 	// used to keep track of trading pairs that we subscribed to
 	pairScrapers map[string]*BalancerV2PairScraper
 	exchangeName string
+	blockchain   string
 	chanTrades   chan *dia.Trade
 
 	tokensMap    map[string]dia.Asset
 	cachedAssets sync.Map // map[string]dia.Asset
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBalancerV2Scraper(exchange, scrape)
+	}, dia.BalancerV2Exchange, dia.BalancerV2ExchangePolygon, dia.BeetsExchange)
+}
+
 // NewBalancerV2Scraper returns a Balancer V2 scraper
 func NewBalancerV2Scraper(exchange dia.Exchange, scrape bool) *BalancerV2Scraper {
 	balancerV2VaultContract = exchange.Contract
 	scraper := &BalancerV2Scraper{
 		exchangeName: exchange.Name,
+		blockchain:   exchange.BlockChain.Name,
 		err:          nil,
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
@@ -314,7 +323,7 @@ func (s *BalancerV2Scraper) FetchAvailablePairs() (pairs []dia.ExchangePair, err
 func (s *BalancerV2Scraper) assetFromToken(token common.Address) (dia.Asset, error) {
 	cached, ok := s.cachedAssets.Load(token.Hex())
 	if !ok {
-		asset, err := ethhelper.ETHAddressToAsset(token, s.rest, Exchanges[s.exchangeName].BlockChain.Name)
+		asset, err := ethhelper.ETHAddressToAsset(token, s.rest, s.blockchain)
 		if err != nil {
 			return dia.Asset{}, err
 		}
@@ -19,6 +19,7 @@ import (
 	uniswapcontract "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers/uniswap"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/diadata-org/diadata/pkg/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -104,6 +105,12 @@ type BancorScraper struct {
 	chanTrades     chan *dia.Trade
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBancorScraper(exchange, scrape)
+	}, dia.BancorExchange)
+}
+
 func NewBancorScraper(exchange dia.Exchange, scrape bool) *BancorScraper {
 	var wsClient, restClient *ethclient.Client
 	var err error
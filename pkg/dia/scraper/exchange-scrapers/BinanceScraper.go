@@ -36,11 +36,20 @@ type BinanceScraper struct {
 	exchangeName string
 	scraperName  string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBinanceScraper(key, secret, exchange, exchange.Name, scrape, relDB)
+	}, dia.BinanceExchange)
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBinanceScraper(key, secret, registry.Exchanges[dia.BinanceExchange], dia.Binance2Exchange, scrape, relDB)
+	}, dia.Binance2Exchange)
 }
 
 // NewBinanceScraper returns a new BinanceScraper for the given pair
-func NewBinanceScraper(apiKey string, secretKey string, exchange dia.Exchange, scraperName string, scrape bool, relDB *models.RelDB) *BinanceScraper {
+func NewBinanceScraper(apiKey string, secretKey string, exchange dia.Exchange, scraperName string, scrape bool, relDB models.RelDatastore) *BinanceScraper {
 
 	s := &BinanceScraper{
 		client:       binance.NewClient(apiKey, secretKey),
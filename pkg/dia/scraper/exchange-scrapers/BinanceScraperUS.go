@@ -39,11 +39,17 @@ type BinanceScraperUS struct {
 	// pairLocks         sync.Map // dia.ExchangePair -> sync.Mutex
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBinanceScraperUS(key, secret, exchange, scrape, relDB)
+	}, dia.BinanceExchangeUS)
 }
 
 // NewBinanceScraperUS returns a new BinanceScraperUS for the given pair
-func NewBinanceScraperUS(apiKey string, secretKey string, exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BinanceScraperUS {
+func NewBinanceScraperUS(apiKey string, secretKey string, exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BinanceScraperUS {
 	binance.BaseWsMainURL = BinanceUSWsURL
 
 	s := &BinanceScraperUS{
@@ -101,11 +101,17 @@ type BitMartScraper struct {
 	pairSubscriptions sync.Map // dia.ExchangePair -> int (connection ID)
 	exchangeName      string
 	chanTrades        chan *dia.Trade
-	db                *models.RelDB
+	db                models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBitMartScraper(exchange, scrape, relDB)
+	}, dia.BitMartExchange)
 }
 
 // NewBitMartScraper returns a new BitMart scraper
-func NewBitMartScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BitMartScraper {
+func NewBitMartScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BitMartScraper {
 	s := &BitMartScraper{
 		wsClient:     make([]*ws.Conn, bitMartMaxConnections),
 		errCount:     make([]int, bitMartMaxConnections),
@@ -110,7 +110,7 @@ type BitMexScraper struct {
 	pairScrapers    sync.Map
 	exchangeName    string
 	chanTrades      chan *dia.Trade
-	db              *models.RelDB
+	db              models.RelDatastore
 	tasks           sync.Map
 	pingTicker      *time.Ticker
 	stopPingRoutine chan bool
@@ -120,8 +120,14 @@ type BitMexScraper struct {
 	connRetryCount int
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBitMexScraper(exchange, scrape, relDB)
+	}, dia.BitMexExchange)
+}
+
 // NewBitMexScraper returns a new BitMex scraper
-func NewBitMexScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BitMexScraper {
+func NewBitMexScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BitMexScraper {
 	s := &BitMexScraper{
 		shutdown:        make(chan nothing),
 		shutdownDone:    make(chan nothing),
@@ -40,11 +40,17 @@ type BitfinexScraper struct {
 	symbols           map[string]string // pair to symbol mapping
 	exchangeName      string
 	chanTrades        chan *dia.Trade
-	db                *models.RelDB
+	db                models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBitfinexScraper(key, secret, exchange, scrape, relDB)
+	}, dia.BitfinexExchange)
 }
 
 // NewBitfinexScraper returns a new BitfinexScraper for the given pair
-func NewBitfinexScraper(key string, secret string, exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BitfinexScraper {
+func NewBitfinexScraper(key string, secret string, exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BitfinexScraper {
 	// we want to ensure there are no gaps in our stream
 	// -> close the returned channel on disconnect, forcing the caller to handle
 	// possible gaps
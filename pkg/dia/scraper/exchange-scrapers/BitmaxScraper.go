@@ -79,10 +79,16 @@ type BitMaxScraper struct {
 	numPairsClient2        int
 	currencySymbolName     map[string]string
 	isTickerMapInitialised bool
-	db                     *models.RelDB
+	db                     models.RelDatastore
 }
 
-func NewBitMaxScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BitMaxScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBitMaxScraper(exchange, scrape, relDB)
+	}, dia.BitMaxExchange)
+}
+
+func NewBitMaxScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BitMaxScraper {
 	var bitmaxSocketURL = "wss://ascendex.com/0/api/pro/v1/stream"
 	s := &BitMaxScraper{
 		initDone:               make(chan nothing),
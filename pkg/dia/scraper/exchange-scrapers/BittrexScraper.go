@@ -46,10 +46,16 @@ type BittrexScraper struct {
 	pairScrapers map[string]*BittrexPairScraper
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
 }
 
-func NewBittrexScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BittrexScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewBittrexScraper(exchange, scrape, relDB)
+	}, dia.BittrexExchange)
+}
+
+func NewBittrexScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *BittrexScraper {
 	s := &BittrexScraper{
 		shutdown:              make(chan nothing),
 		shutdownDone:          make(chan nothing),
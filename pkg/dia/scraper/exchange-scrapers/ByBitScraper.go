@@ -93,11 +93,17 @@ type ByBitScraper struct {
 	exchangeName string
 	// channel to send trades
 	chanTrades chan *dia.Trade
-	db         *models.RelDB
+	db         models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewByBitScraper(exchange, scrape, relDB)
+	}, dia.ByBitExchange)
 }
 
 // NewByBitScraper get a scrapper for ByBit exchange
-func NewByBitScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *ByBitScraper {
+func NewByBitScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *ByBitScraper {
 	s := &ByBitScraper{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
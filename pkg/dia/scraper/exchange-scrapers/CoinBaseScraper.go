@@ -27,7 +27,7 @@ type CoinBaseScraper struct {
 	wsConn       *ws.Conn
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
 }
 
 const (
@@ -39,9 +39,15 @@ const (
 	ChannelFull      = "full"
 )
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewCoinBaseScraper(exchange, scrape, relDB)
+	}, dia.CoinBaseExchange)
+}
+
 // NewCoinBaseScraper returns a new CoinBaseScraper initialized with default values.
 // The instance is asynchronously scraping as soon as it is created.
-func NewCoinBaseScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *CoinBaseScraper {
+func NewCoinBaseScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *CoinBaseScraper {
 	s := &CoinBaseScraper{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
@@ -146,7 +146,7 @@ type CryptoDotComScraper struct {
 	pairScrapers sync.Map
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
 	taskCount    int32
 	tasks        sync.Map
 
@@ -155,8 +155,14 @@ type CryptoDotComScraper struct {
 	connRetryCount int
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewCryptoDotComScraper(exchange, scrape, relDB)
+	}, dia.CryptoDotComExchange)
+}
+
 // NewCryptoDotComScraper returns a new Crypto.com scraper
-func NewCryptoDotComScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *CryptoDotComScraper {
+func NewCryptoDotComScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *CryptoDotComScraper {
 	s := &CryptoDotComScraper{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
@@ -17,6 +17,7 @@ import (
 	"github.com/diadata-org/diadata/pkg/utils"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -85,6 +86,7 @@ func (p *Pools) poolsAddressNoLock() []string {
 // CurveFIScraper is a curve finance scraper on a specific blockchain.
 type CurveFIScraper struct {
 	exchangeName string
+	blockchain   string
 
 	// channels to signal events
 	run          bool
@@ -129,6 +131,7 @@ func makeCurvefiScraper(exchange dia.Exchange, registries []curveRegistry, restD
 
 	scraper = &CurveFIScraper{
 		exchangeName:   exchange.Name,
+		blockchain:     exchange.BlockChain.Name,
 		RestClient:     restClient,
 		WsClient:       wsClient,
 		initDone:       make(chan nothing),
@@ -156,6 +159,12 @@ func makeCurvefiScraper(exchange dia.Exchange, registries []curveRegistry, restD
 	return scraper
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewCurveFIScraper(exchange, scrape)
+	}, dia.CurveFIExchange, dia.CurveFIExchangeFantom, dia.CurveFIExchangeMoonbeam, dia.CurveFIExchangePolygon, dia.CurveFIExchangeArbitrum)
+}
+
 func NewCurveFIScraper(exchange dia.Exchange, scrape bool) *CurveFIScraper {
 
 	var scraper *CurveFIScraper
@@ -344,7 +353,7 @@ func (scraper *CurveFIScraper) getSwapDataCurve(pool string, s *curvepool.Curvep
 		Name:       fromToken.Name,
 		Address:    fromToken.Address,
 		Symbol:     fromToken.Symbol,
-		Blockchain: Exchanges[scraper.exchangeName].BlockChain.Name,
+		Blockchain: scraper.blockchain,
 	}
 
 	toToken, ok := scraper.pools.getPoolCoin(pool, int(s.BoughtId.Int64()))
@@ -356,7 +365,7 @@ func (scraper *CurveFIScraper) getSwapDataCurve(pool string, s *curvepool.Curvep
 		Name:       toToken.Name,
 		Address:    toToken.Address,
 		Symbol:     toToken.Symbol,
-		Blockchain: Exchanges[scraper.exchangeName].BlockChain.Name,
+		Blockchain: scraper.blockchain,
 	}
 
 	// amountIn := s.AmountSold. / math.Pow10( fromToken.Decimals )
@@ -229,7 +229,7 @@ func (s *ECBScraper) Update() error {
 }
 
 // Populate fetches historical daily datas from 1999 until today and saves them on the database
-func Populate(datastore *models.DB, rdb *models.RelDB, pairs []string) {
+func Populate(datastore *models.DB, rdb models.RelDatastore, pairs []string) {
 	// Start with USD to have conversion reference
 	xmlEurusd := populateCurrency(datastore, rdb, "USD", nil)
 
@@ -242,7 +242,7 @@ func Populate(datastore *models.DB, rdb *models.RelDB, pairs []string) {
 	}
 }
 
-func populateCurrency(datastore *models.DB, rdb *models.RelDB, currency string, xmlEurusd *XMLHistoricalEnvelope) *XMLHistoricalEnvelope {
+func populateCurrency(datastore *models.DB, rdb models.RelDatastore, currency string, xmlEurusd *XMLHistoricalEnvelope) *XMLHistoricalEnvelope {
 	var asset dia.Asset
 	var err error
 	if currency == "USD" {
@@ -0,0 +1,124 @@
+package scrapers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/ghodss/yaml"
+)
+
+// ExchangeRegistry holds the exchanges, blockchains and chain configurations needed by the scrapers.
+// It is loaded explicitly, either from a RelDatastore or from a static file, and handed to NewAPIScraper.
+type ExchangeRegistry struct {
+	Exchanges    map[string]dia.Exchange
+	Blockchains  map[string]dia.BlockChain
+	ChainConfigs map[string]dia.ChainConfig
+	// UniswapV2Forks are scraped by the UniswapV2 scrapers without a dedicated registration.
+	UniswapV2Forks map[string]UniswapV2Fork
+}
+
+// exchangeRegistryFile is the format of a static exchange registry file.
+type exchangeRegistryFile struct {
+	Exchanges      []dia.Exchange    `json:"Exchanges"`
+	Blockchains    []dia.BlockChain  `json:"Blockchains"`
+	ChainConfigs   []dia.ChainConfig `json:"ChainConfigs"`
+	UniswapV2Forks []UniswapV2Fork   `json:"UniswapV2Forks"`
+}
+
+// NewExchangeRegistry returns a registry containing @exchanges, @blockchains and @chainConfigs.
+func NewExchangeRegistry(exchanges []dia.Exchange, blockchains []dia.BlockChain, chainConfigs []dia.ChainConfig) *ExchangeRegistry {
+	registry := &ExchangeRegistry{
+		Exchanges:      make(map[string]dia.Exchange),
+		Blockchains:    make(map[string]dia.BlockChain),
+		ChainConfigs:   make(map[string]dia.ChainConfig),
+		UniswapV2Forks: make(map[string]UniswapV2Fork),
+	}
+	for _, exchange := range exchanges {
+		registry.Exchanges[exchange.Name] = exchange
+	}
+	for _, chain := range blockchains {
+		registry.Blockchains[chain.Name] = chain
+	}
+	for _, chainconfig := range chainConfigs {
+		registry.ChainConfigs[chainconfig.ChainID] = chainconfig
+	}
+	return registry
+}
+
+// LoadExchangeRegistry returns a registry with all exchanges, blockchains and chain configurations from @relDB.
+// UniswapV2 forks are added from the config folder.
+func LoadExchangeRegistry(relDB models.RelDatastore) (*ExchangeRegistry, error) {
+	exchanges, err := relDB.GetAllExchanges()
+	if err != nil {
+		return nil, err
+	}
+	chains, err := relDB.GetAllBlockchains(false)
+	if err != nil {
+		return nil, err
+	}
+	chainconfigurations, err := relDB.GetAllChainConfig()
+	if err != nil {
+		return nil, err
+	}
+	registry := NewExchangeRegistry(exchanges, chains, chainconfigurations)
+	addConfiguredUniswapV2Forks(registry)
+	return registry, nil
+}
+
+// LoadExchangeRegistryFromFile returns the registry given in @filename.
+// Files with extension .yaml or .yml are parsed as yaml, all others as json.
+// The file contains the lists Exchanges, Blockchains, ChainConfigs and UniswapV2Forks.
+func LoadExchangeRegistryFromFile(filename string) (*ExchangeRegistry, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var registryFile exchangeRegistryFile
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &registryFile)
+	default:
+		err = json.Unmarshal(content, &registryFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, fork := range registryFile.UniswapV2Forks {
+		if err := fork.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	registry := NewExchangeRegistry(registryFile.Exchanges, registryFile.Blockchains, registryFile.ChainConfigs)
+	registry.AddUniswapV2Forks(registryFile.UniswapV2Forks)
+	return registry, nil
+}
+
+// InitExchangeRegistry returns the registry from @filename if it is non-empty.
+// Otherwise the registry is loaded from postgres.
+func InitExchangeRegistry(filename string) (*ExchangeRegistry, error) {
+	if filename != "" {
+		return LoadExchangeRegistryFromFile(filename)
+	}
+	relDB, err := models.NewRelDataStore()
+	if err != nil {
+		return nil, err
+	}
+	return LoadExchangeRegistry(relDB)
+}
+
+// IsCentralized returns true if @source is a centralized exchange.
+func (r *ExchangeRegistry) IsCentralized(source string) bool {
+	return r.Exchanges[source].Centralized
+}
+
+// ExchangeNames returns the names of all exchanges in the registry.
+func (r *ExchangeRegistry) ExchangeNames() (names []string) {
+	for name := range r.Exchanges {
+		names = append(names, name)
+	}
+	return
+}
@@ -41,7 +41,7 @@ type FinageForexScraper struct {
 	closed       bool
 	pairScrapers map[string]*FinageForexPairScraper // dia.ExchangePair -> pairScraperSet
 	ticker       *time.Ticker
-	datastore    *models.RelDB
+	datastore    models.RelDatastore
 	chanTrades   chan *dia.Trade
 	wsConn       *websocket.Conn
 	exchangeName string
@@ -50,7 +50,7 @@ type FinageForexScraper struct {
 
 // SpawnECBScraper returns a new ECBScraper initialized with default values.
 // The instance is asynchronously scraping as soon as it is created.
-func NewFinageForexScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB, finageAPIkey string, finageWebsocketKey string) *FinageForexScraper {
+func NewFinageForexScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore, finageAPIkey string, finageWebsocketKey string) *FinageForexScraper {
 	var finage = "wss://w29hxx2ndd.finage.ws:8001/?token=" + finageWebsocketKey
 
 	c, _, err := websocket.DefaultDialer.Dial(finage, nil)
@@ -72,11 +72,17 @@ type GateIOScraper struct {
 	chanTrades             chan *dia.Trade
 	currencySymbolName     map[string]string
 	isTickerMapInitialised bool
-	db                     *models.RelDB
+	db                     models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewGateIOScraper(exchange, scrape, relDB)
+	}, dia.GateIOExchange)
 }
 
 // NewGateIOScraper returns a new GateIOScraper for the given pair
-func NewGateIOScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *GateIOScraper {
+func NewGateIOScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *GateIOScraper {
 
 	s := &GateIOScraper{
 		shutdown:               make(chan nothing),
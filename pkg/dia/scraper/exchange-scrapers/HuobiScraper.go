@@ -82,11 +82,17 @@ type HuobiScraper struct {
 	pairScrapers map[string]*HuobiPairScraper
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewHuobiScraper(exchange, scrape, relDB)
+	}, dia.HuobiExchange)
 }
 
 // NewHuobiScraper returns a new HuobiScraper for the given pair
-func NewHuobiScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *HuobiScraper {
+func NewHuobiScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *HuobiScraper {
 
 	s := &HuobiScraper{
 		shutdown:     make(chan nothing),
@@ -45,6 +45,12 @@ type InfluxScraper struct {
 	fbsDoneReader *kafka.Reader
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewInfluxScraper(scrape)
+	}, "Influx")
+}
+
 // NewGateIOScraper returns a new GateIOScraper for the given pair
 func NewInfluxScraper(scrape bool) *InfluxScraper {
 
@@ -31,12 +31,18 @@ type KrakenScraper struct {
 	ticker       *time.Ticker
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewKrakenScraper(key, secret, exchange, scrape, relDB)
+	}, dia.KrakenExchange)
 }
 
 // NewKrakenScraper returns a new KrakenScraper initialized with default values.
 // The instance is asynchronously scraping as soon as it is created.
-func NewKrakenScraper(key string, secret string, exchange dia.Exchange, scrape bool, relDB *models.RelDB) *KrakenScraper {
+func NewKrakenScraper(key string, secret string, exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *KrakenScraper {
 	s := &KrakenScraper{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
@@ -204,7 +210,7 @@ func (ps *KrakenPairScraper) Pair() dia.ExchangePair {
 	return ps.pair
 }
 
-func NewTrade(pair dia.ExchangePair, info krakenapi.TradeInfo, foreignTradeID string, relDB *models.RelDB) *dia.Trade {
+func NewTrade(pair dia.ExchangePair, info krakenapi.TradeInfo, foreignTradeID string, relDB models.RelDatastore) *dia.Trade {
 	volume := info.VolumeFloat
 	if info.Sell {
 		volume = -volume
@@ -71,10 +71,16 @@ type KuCoinScraper struct {
 	exchangeName string
 	chanTrades   chan *dia.Trade
 	apiService   *kucoin.ApiService
-	db           *models.RelDB
+	db           models.RelDatastore
 }
 
-func NewKuCoinScraper(apiKey string, secretKey string, exchange dia.Exchange, scrape bool, relDB *models.RelDB) *KuCoinScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewKuCoinScraper(key, secret, exchange, scrape, relDB)
+	}, dia.KuCoinExchange)
+}
+
+func NewKuCoinScraper(apiKey string, secretKey string, exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *KuCoinScraper {
 	apiService := kucoin.NewApiService()
 
 	s := &KuCoinScraper{
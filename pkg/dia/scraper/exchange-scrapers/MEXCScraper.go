@@ -91,10 +91,16 @@ type MEXCScraper struct {
 	pairScrapers map[string]*MEXCPairScraper
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
 }
 
-func NewMEXCScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *MEXCScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewMEXCScraper(exchange, scrape, relDB)
+	}, dia.MEXCExchange)
+}
+
+func NewMEXCScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *MEXCScraper {
 	s := &MEXCScraper{
 		shutdown:     make(chan nothing),
 		shutdownDone: make(chan nothing),
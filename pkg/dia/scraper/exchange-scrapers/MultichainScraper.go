@@ -70,7 +70,8 @@ type BridgeSwapScraper struct {
 	// waitTime     int
 	// If true, only pairs given in config file are scraped. Default is false.
 	//listenByAddress bool
-	relDB *models.RelDB
+	relDB       *models.RelDB
+	blockchains map[string]dia.BlockChain
 }
 
 type MultiChainConfig struct {
@@ -162,18 +163,27 @@ const (
 	}]`
 )
 
-// NewBridgeSwapScraper returns a new BridgeSwapScraper for the given pair
-func NewBridgeSwapScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *BridgeSwapScraper {
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		// Asset maps are only available in postgres.
+		postgresDB, _ := relDB.(*models.RelDB)
+		return NewBridgeSwapScraper(exchange, scrape, postgresDB, registry)
+	}, dia.MultiChain)
+}
+
+// NewBridgeSwapScraper returns a new BridgeSwapScraper for the given pair.
+// Chain configurations and blockchains are taken from @registry.
+func NewBridgeSwapScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB, registry *ExchangeRegistry) *BridgeSwapScraper {
 	var s *BridgeSwapScraper
 	// var waitgroup sync.WaitGroup
 	multichainconfigs = make(map[string]MultiChainConfig)
 
-	multichainconfigs["1"] = MultiChainConfig{restURL: chainConfigs["1"].RestURL, wsURL: chainConfigs["1"].WSURL, contratDeployedAtBlock: 12242619, contractAddress: "0x765277eebeca2e31912c9946eae1021199b39c61"}
-	multichainconfigs["56"] = MultiChainConfig{restURL: chainConfigs["56"].RestURL, wsURL: chainConfigs["56"].WSURL, contratDeployedAtBlock: 7910338, contractAddress: "0xd1c5966f9f5ee6881ff6b261bbeda45972b1b5f3"}
-	multichainconfigs["137"] = MultiChainConfig{restURL: chainConfigs["137"].RestURL, wsURL: chainConfigs["137"].WSURL, contratDeployedAtBlock: 17355461, contractAddress: "0x6ff0609046a38d76bd40c5863b4d1a2dce687f73"}
-	multichainconfigs["250"] = MultiChainConfig{restURL: chainConfigs["250"].RestURL, wsURL: chainConfigs["250"].WSURL, contratDeployedAtBlock: 8475644, contractAddress: "0x1ccca1ce62c62f7be95d4a67722a8fdbed6eecb4"}
-	multichainconfigs["42161"] = MultiChainConfig{restURL: chainConfigs["42161"].RestURL, wsURL: chainConfigs["42161"].WSURL, contratDeployedAtBlock: 15315466, contractAddress: "0x650af55d5877f289837c30b94af91538a7504b76"}
-	multichainconfigs["43114"] = MultiChainConfig{restURL: chainConfigs["43114"].RestURL, wsURL: chainConfigs["43114"].WSURL, contratDeployedAtBlock: 3397229, contractAddress: "0xB0731d50C681C45856BFc3f7539D5f61d4bE81D8"}
+	multichainconfigs["1"] = MultiChainConfig{restURL: registry.ChainConfigs["1"].RestURL, wsURL: registry.ChainConfigs["1"].WSURL, contratDeployedAtBlock: 12242619, contractAddress: "0x765277eebeca2e31912c9946eae1021199b39c61"}
+	multichainconfigs["56"] = MultiChainConfig{restURL: registry.ChainConfigs["56"].RestURL, wsURL: registry.ChainConfigs["56"].WSURL, contratDeployedAtBlock: 7910338, contractAddress: "0xd1c5966f9f5ee6881ff6b261bbeda45972b1b5f3"}
+	multichainconfigs["137"] = MultiChainConfig{restURL: registry.ChainConfigs["137"].RestURL, wsURL: registry.ChainConfigs["137"].WSURL, contratDeployedAtBlock: 17355461, contractAddress: "0x6ff0609046a38d76bd40c5863b4d1a2dce687f73"}
+	multichainconfigs["250"] = MultiChainConfig{restURL: registry.ChainConfigs["250"].RestURL, wsURL: registry.ChainConfigs["250"].WSURL, contratDeployedAtBlock: 8475644, contractAddress: "0x1ccca1ce62c62f7be95d4a67722a8fdbed6eecb4"}
+	multichainconfigs["42161"] = MultiChainConfig{restURL: registry.ChainConfigs["42161"].RestURL, wsURL: registry.ChainConfigs["42161"].WSURL, contratDeployedAtBlock: 15315466, contractAddress: "0x650af55d5877f289837c30b94af91538a7504b76"}
+	multichainconfigs["43114"] = MultiChainConfig{restURL: registry.ChainConfigs["43114"].RestURL, wsURL: registry.ChainConfigs["43114"].WSURL, contratDeployedAtBlock: 3397229, contractAddress: "0xB0731d50C681C45856BFc3f7539D5f61d4bE81D8"}
 
 	log.Info("NewBridgeSwapScraper: ", exchange.Name)
 	log.Infof("Init rest and ws client for %s.", exchange.BlockChain.Name)
@@ -184,6 +194,7 @@ func NewBridgeSwapScraper(exchange dia.Exchange, scrape bool, relDB *models.RelD
 		shutdownDone: make(chan nothing),
 		pairScrapers: make(map[string]*BridgeSwapPairScraper),
 		relDB:        relDB,
+		blockchains:  registry.Blockchains,
 	}
 
 	if scrape {
@@ -274,7 +285,7 @@ func (s *BridgeSwapScraper) checkTransactionOnChain(events chan types.Log) {
 				Symbol:     quoteTokenSymbol,
 				Name:       quoteTokenName,
 				Decimals:   quoteTokenDecimal,
-				Blockchain: blockchains[quoteBlockchain].Name,
+				Blockchain: s.blockchains[quoteBlockchain].Name,
 			}
 
 			baseTokenName, err := GetName(tokenbridged, fromChainIdValue.String())
@@ -296,7 +307,7 @@ func (s *BridgeSwapScraper) checkTransactionOnChain(events chan types.Log) {
 				Symbol:     baseTokenName,
 				Name:       baseTokenSymbol,
 				Decimals:   baseTokenDecimal,
-				Blockchain: blockchains[baseBlockchain].Name,
+				Blockchain: s.blockchains[baseBlockchain].Name,
 			}
 
 			inAmountt := inAmount.Quo(inAmount, inAmount.Exp(big.NewInt(10), big.NewInt(int64(baseTokenDecimal)), nil))
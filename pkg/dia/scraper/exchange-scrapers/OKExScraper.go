@@ -56,11 +56,17 @@ type OKExScraper struct {
 	pairScrapers map[string]*OKExPairScraper
 	exchangeName string
 	chanTrades   chan *dia.Trade
-	db           *models.RelDB
+	db           models.RelDatastore
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewOKExScraper(exchange, scrape, relDB)
+	}, dia.OKExExchange)
 }
 
 // NewOKExScraper returns a new OKExScraper for the given pair
-func NewOKExScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *OKExScraper {
+func NewOKExScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *OKExScraper {
 
 	s := &OKExScraper{
 		shutdown:     make(chan nothing),
@@ -11,6 +11,7 @@ import (
 
 	"github.com/diadata-org/diadata/pkg/dia"
 	orcaWhirlpoolIdlBind "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers/orca/whirlpool"
+	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/diadata-org/diadata/pkg/utils"
 
 	bin "github.com/gagliardetto/binary"
@@ -52,6 +53,12 @@ type OrcaScraper struct {
 	WsClient   *ws.Client
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewOrcaScraper(exchange, scrape)
+	}, dia.OrcaExchange)
+}
+
 // Returns a new exchange scraper
 func NewOrcaScraper(exchange dia.Exchange, scrape bool) *OrcaScraper {
 
@@ -17,6 +17,7 @@ import (
 	"github.com/diadata-org/diadata/pkg/utils"
 
 	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -74,6 +75,7 @@ func (p *PlatypusPools) poolsAddressNoLock() []string {
 // PlatypusScraper The scraper object for Platypus Finance
 type PlatypusScraper struct {
 	exchangeName string
+	blockchain   string
 
 	// channels to signal events
 	run          bool
@@ -97,6 +99,12 @@ type PlatypusScraper struct {
 	basePoolRegistry platypusRegistry
 }
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewPlatypusScraper(exchange, scrape)
+	}, dia.PlatypusExchange)
+}
+
 // NewPlatypusScraper Returns a new exchange scraper
 func NewPlatypusScraper(exchange dia.Exchange, scrape bool) *PlatypusScraper {
 
@@ -118,6 +126,7 @@ func NewPlatypusScraper(exchange dia.Exchange, scrape bool) *PlatypusScraper {
 
 	scraper := &PlatypusScraper{
 		exchangeName:  exchange.Name,
+		blockchain:    exchange.BlockChain.Name,
 		RestClient:    restClient,
 		WsClient:      wsClient,
 		initDone:      make(chan nothing),
@@ -402,7 +411,7 @@ func (s *PlatypusScraper) getSwapData(pool string, swap *platypusPoolABI.PoolSwa
 		Name:       fromToken.Name,
 		Address:    fromToken.Address,
 		Symbol:     fromToken.Symbol,
-		Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+		Blockchain: s.blockchain,
 	}
 
 	toToken, ok := s.platypusCoins[swap.ToToken.Hex()]
@@ -414,7 +423,7 @@ func (s *PlatypusScraper) getSwapData(pool string, swap *platypusPoolABI.PoolSwa
 		Name:       toToken.Name,
 		Address:    toToken.Address,
 		Symbol:     toToken.Symbol,
-		Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+		Blockchain: s.blockchain,
 	}
 
 	// amountIn = AmountSold / math.Pow10( fromToken.Decimals )
@@ -0,0 +1,50 @@
+package scrapers
+
+import (
+	"sort"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+// APIScraperFactory returns an APIScraper for @exchange. If scrape==true it actually does
+// scraping. Otherwise can be used for pairdiscovery.
+// @registry contains the exchanges, blockchains and chain configurations known to the scrapers.
+type APIScraperFactory func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper
+
+// apiScraperFactories maps exchange names onto the factory of their scraper.
+// Scrapers register their factories in the init function of their file.
+var apiScraperFactories = make(map[string]APIScraperFactory)
+
+// RegisterAPIScraper registers @factory for all exchanges in @exchangeNames.
+// It panics if a factory is already registered for one of the exchanges.
+func RegisterAPIScraper(factory APIScraperFactory, exchangeNames ...string) {
+	for _, name := range exchangeNames {
+		if _, ok := apiScraperFactories[name]; ok {
+			panic("scrapers: RegisterAPIScraper called twice for exchange " + name)
+		}
+		apiScraperFactories[name] = factory
+	}
+}
+
+// RegisteredAPIScrapers returns the names of all exchanges with a registered scraper in alphabetical order.
+func RegisteredAPIScrapers() []string {
+	var names []string
+	for name := range apiScraperFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getAPIScraperFactory returns the factory registered for @exchange.
+// UniswapV2 forks in @registry without registration are served by the UniswapV2 scraper.
+func getAPIScraperFactory(exchange string, registry *ExchangeRegistry) (APIScraperFactory, bool) {
+	if factory, ok := apiScraperFactories[exchange]; ok {
+		return factory, true
+	}
+	if registry.IsUniswapV2Fork(exchange) {
+		return newUniswapAPIScraper, true
+	}
+	return nil, false
+}
@@ -0,0 +1,123 @@
+package scrapers
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+)
+
+const (
+	// uniswapV2ForksFile is the json file in the config folder containing additional UniswapV2 forks.
+	uniswapV2ForksFile = "uniswap/forks"
+	// Default time waited between requests for forks without WaitMilliseconds.
+	uniswapV2ForkWaitMilliseconds = 200
+	// Default watchdog delay for forks without WatchdogDelay.
+	uniswapV2ForkWatchdogDelay = 600
+)
+
+// UniswapV2Fork is the configuration of an exchange deploying the UniswapV2 contracts.
+// Such an exchange can be scraped without a dedicated case in the scrapers.
+type UniswapV2Fork struct {
+	Name           string `json:"Name"`
+	Blockchain     string `json:"Blockchain"`
+	FactoryAddress string `json:"FactoryAddress"`
+	// Fee is the swap fee of the fork in percent, such as 0.3 for UniswapV2.
+	Fee float64 `json:"Fee"`
+	// RestURI and WsURI are the blockchain nodes used by the scrapers. Both can be overridden
+	// by the env vars <BLOCKCHAIN>_URI_REST and <BLOCKCHAIN>_URI_WS.
+	RestURI string `json:"RestURI"`
+	WsURI   string `json:"WsURI"`
+	// WaitMilliseconds is the time waited between requests to the blockchain node.
+	WaitMilliseconds int `json:"WaitMilliseconds"`
+	WatchdogDelay    int `json:"WatchdogDelay"`
+}
+
+type uniswapV2ForksConfig struct {
+	Forks []UniswapV2Fork `json:"Forks"`
+}
+
+// Validate returns an error if mandatory fields of @fork are missing.
+func (fork UniswapV2Fork) Validate() error {
+	if fork.Name == "" {
+		return errors.New("missing name of UniswapV2 fork")
+	}
+	if fork.Blockchain == "" {
+		return errors.New("missing blockchain of UniswapV2 fork " + fork.Name)
+	}
+	if fork.FactoryAddress == "" {
+		return errors.New("missing factory address of UniswapV2 fork " + fork.Name)
+	}
+	return nil
+}
+
+// Exchange returns the decentralized exchange corresponding to @fork.
+func (fork UniswapV2Fork) Exchange() dia.Exchange {
+	watchdogDelay := fork.WatchdogDelay
+	if watchdogDelay == 0 {
+		watchdogDelay = uniswapV2ForkWatchdogDelay
+	}
+	return dia.Exchange{
+		Name:          fork.Name,
+		Contract:      fork.FactoryAddress,
+		BlockChain:    dia.BlockChain{Name: fork.Blockchain},
+		WatchdogDelay: watchdogDelay,
+		ScraperActive: true,
+	}
+}
+
+// WaitTime returns the time in milliseconds waited between requests for @fork as used by the UniswapV2 scrapers.
+func (fork UniswapV2Fork) WaitTime() string {
+	if fork.WaitMilliseconds == 0 {
+		return strconv.Itoa(uniswapV2ForkWaitMilliseconds)
+	}
+	return strconv.Itoa(fork.WaitMilliseconds)
+}
+
+// LoadUniswapV2Forks returns the forks listed in the json file @filename in the config folder.
+func LoadUniswapV2Forks(filename string) ([]UniswapV2Fork, error) {
+	content, err := configCollectors.ReadJSONFromConfig(filename)
+	if err != nil {
+		return []UniswapV2Fork{}, err
+	}
+	var config uniswapV2ForksConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return []UniswapV2Fork{}, err
+	}
+	for _, fork := range config.Forks {
+		if err := fork.Validate(); err != nil {
+			return []UniswapV2Fork{}, err
+		}
+	}
+	return config.Forks, nil
+}
+
+// AddUniswapV2Forks adds @forks to the registry. The exchange of a fork is only added if
+// the registry does not contain an exchange with the same name yet.
+func (r *ExchangeRegistry) AddUniswapV2Forks(forks []UniswapV2Fork) {
+	for _, fork := range forks {
+		r.UniswapV2Forks[fork.Name] = fork
+		if _, ok := r.Exchanges[fork.Name]; !ok {
+			r.Exchanges[fork.Name] = fork.Exchange()
+		}
+	}
+}
+
+// IsUniswapV2Fork returns true if @exchange is a fork configured in the registry.
+func (r *ExchangeRegistry) IsUniswapV2Fork(exchange string) bool {
+	_, ok := r.UniswapV2Forks[exchange]
+	return ok
+}
+
+// addConfiguredUniswapV2Forks adds the forks from the config folder to @registry, if present.
+func addConfiguredUniswapV2Forks(registry *ExchangeRegistry) {
+	forks, err := LoadUniswapV2Forks(uniswapV2ForksFile)
+	if err != nil {
+		log.Warn("no UniswapV2 forks loaded from config: ", err)
+		return
+	}
+	registry.AddUniswapV2Forks(forks)
+}
@@ -25,13 +25,11 @@ import (
 )
 
 var (
-	exchangeFactoryContractAddress = "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"
-	reverseBasetokens              *[]string
-	reverseQuotetokens             *[]string
-	mainBaseAssets                 = []string{
+	reverseBasetokens  *[]string
+	reverseQuotetokens *[]string
+	mainBaseAssets     = []string{
 		"0xdAC17F958D2ee523a2206206994597C13D831ec7",
 	}
-	poolMap = make(map[string]UniswapPair)
 )
 
 const (
@@ -99,6 +97,45 @@ const (
 	wanchainWaitMilliseconds    = "1000"
 )
 
+// uniswapForkConfig contains the dials and the time waited between requests of a UniswapV2 fork.
+type uniswapForkConfig struct {
+	restDial         string
+	wsDial           string
+	waitMilliseconds string
+}
+
+// uniswapForks are the UniswapV2 forks with built-in configuration.
+// Further forks can be added through the UniswapV2Forks of the ExchangeRegistry.
+var uniswapForks = map[string]uniswapForkConfig{
+	dia.UniswapExchange:           {restDialEth, wsDialEth, uniswapWaitMilliseconds},
+	dia.SushiSwapExchange:         {restDialEth, wsDialEth, sushiswapWaitMilliseconds},
+	dia.SushiSwapExchangePolygon:  {restDialPolygon, wsDialPolygon, metisWaitMilliseconds},
+	dia.SushiSwapExchangeFantom:   {restDialFantom, wsDialFantom, metisWaitMilliseconds},
+	dia.SushiSwapExchangeArbitrum: {restDialArbitrum, wsDialArbitrum, metisWaitMilliseconds},
+	dia.CamelotExchange:           {restDialArbitrum, wsDialArbitrum, metisWaitMilliseconds},
+	dia.PanCakeSwap:               {restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds},
+	dia.DfynNetwork:               {restDialPolygon, wsDialPolygon, dfynWaitMilliseconds},
+	dia.QuickswapExchange:         {restDialPolygon, wsDialPolygon, quickswapWaitMilliseconds},
+	dia.UbeswapExchange:           {restDialCelo, wsDialCelo, ubeswapWaitMilliseconds},
+	dia.SpookyswapExchange:        {restDialFantom, wsDialFantom, spookyswapWaitMilliseconds},
+	dia.SpiritswapExchange:        {restDialFantom, wsDialFantom, spookyswapWaitMilliseconds},
+	dia.SolarbeamExchange:         {restDialMoonriver, wsDialMoonriver, solarbeamWaitMilliseconds},
+	dia.TrisolarisExchange:        {restDialAurora, wsDialAurora, trisolarisWaitMilliseconds},
+	dia.NetswapExchange:           {restDialMetis, wsDialMetis, metisWaitMilliseconds},
+	dia.HuckleberryExchange:       {restDialMoonriver, wsDialMoonriver, moonriverWaitMilliseconds},
+	dia.TraderJoeExchange:         {restDialAvalanche, wsDialAvalanche, avalancheWaitMilliseconds},
+	dia.PangolinExchange:          {restDialAvalanche, wsDialAvalanche, avalancheWaitMilliseconds},
+	dia.TethysExchange:            {restDialMetis, wsDialMetis, metisWaitMilliseconds},
+	dia.HermesExchange:            {restDialMetis, wsDialMetis, metisWaitMilliseconds},
+	dia.OmniDexExchange:           {restDialTelos, wsDialTelos, telosWaitMilliseconds},
+	dia.DiffusionExchange:         {restDialEvmos, wsDialEvmos, evmosWaitMilliseconds},
+	dia.ApeswapExchange:           {restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds},
+	dia.BiswapExchange:            {restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds},
+	dia.ArthswapExchange:          {restDialAstar, wsDialAstar, astarWaitMilliseconds},
+	dia.StellaswapExchange:        {restDialMoonbeam, wsDialMoonbeam, moonbeamWaitMilliseconds},
+	dia.WanswapExchange:           {restDialWanchain, wsDialWanchain, wanchainWaitMilliseconds},
+}
+
 type UniswapToken struct {
 	Address  common.Address
 	Symbol   string
@@ -126,7 +163,7 @@ type UniswapSwap struct {
 type UniswapScraper struct {
 	WsClient   *ethclient.Client
 	RestClient *ethclient.Client
-	relDB      *models.RelDB
+	relDB      models.RelDatastore
 	// signaling channels for session initialization and finishing
 	//initDone     chan nothing
 	run          bool
@@ -140,15 +177,37 @@ type UniswapScraper struct {
 	// used to keep track of trading pairs that we subscribed to
 	pairScrapers map[string]*UniswapPairScraper
 	exchangeName string
+	blockchain   string
 	chanTrades   chan *dia.Trade
 	waitTime     int
 	// If true, only pairs given in config file are scraped. Default is false.
 	listenByAddress  bool
 	fetchPoolsFromDB bool
+	// factoryContractAddress is the address of the exchange's factory contract.
+	factoryContractAddress common.Address
+	// poolMap maps pool addresses onto the pools fetched from the database.
+	poolMap map[string]UniswapPair
+}
+
+func init() {
+	for name := range uniswapForks {
+		RegisterAPIScraper(newUniswapAPIScraper, name)
+	}
+}
+
+// newUniswapAPIScraper is the APIScraperFactory of all UniswapV2 forks.
+func newUniswapAPIScraper(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+	return newUniswapScraper(exchange, scrape, relDB, registry.UniswapV2Forks[exchange.Name])
 }
 
 // NewUniswapScraper returns a new UniswapScraper for the given pair
-func NewUniswapScraper(exchange dia.Exchange, scrape bool) *UniswapScraper {
+func NewUniswapScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *UniswapScraper {
+	return newUniswapScraper(exchange, scrape, relDB, UniswapV2Fork{})
+}
+
+// newUniswapScraper returns a new UniswapScraper. @fork is the configuration of @exchange
+// if it is a fork without dedicated configuration in uniswapForks.
+func newUniswapScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore, fork UniswapV2Fork) *UniswapScraper {
 	log.Info("NewUniswapScraper: ", exchange.Name)
 	var (
 		s                *UniswapScraper
@@ -156,8 +215,6 @@ func NewUniswapScraper(exchange dia.Exchange, scrape bool) *UniswapScraper {
 		fetchPoolsFromDB bool
 		err              error
 	)
-	exchangeFactoryContractAddress = exchange.Contract
-
 	listenByAddress, err = strconv.ParseBool(utils.Getenv("LISTEN_BY_ADDRESS", ""))
 	if err != nil {
 		log.Fatal("parse LISTEN_BY_ADDRESS: ", err)
@@ -168,67 +225,13 @@ func NewUniswapScraper(exchange dia.Exchange, scrape bool) *UniswapScraper {
 		log.Fatal("parse FETCH_POOLS_FROM_DB: ", err)
 	}
 
-	switch exchange.Name {
-	case dia.UniswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialEth, wsDialEth, uniswapWaitMilliseconds)
-	case dia.SushiSwapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialEth, wsDialEth, sushiswapWaitMilliseconds)
-	case dia.SushiSwapExchangePolygon:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialPolygon, wsDialPolygon, metisWaitMilliseconds)
-	case dia.SushiSwapExchangeFantom:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialFantom, wsDialFantom, metisWaitMilliseconds)
-	case dia.SushiSwapExchangeArbitrum:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialArbitrum, wsDialArbitrum, metisWaitMilliseconds)
-	case dia.CamelotExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialArbitrum, wsDialArbitrum, metisWaitMilliseconds)
-	case dia.PanCakeSwap:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds)
-	case dia.DfynNetwork:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialPolygon, wsDialPolygon, dfynWaitMilliseconds)
-	case dia.QuickswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialPolygon, wsDialPolygon, quickswapWaitMilliseconds)
-	case dia.UbeswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialCelo, wsDialCelo, ubeswapWaitMilliseconds)
-	case dia.SpookyswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialFantom, wsDialFantom, spookyswapWaitMilliseconds)
-	case dia.SpiritswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialFantom, wsDialFantom, spookyswapWaitMilliseconds)
-	case dia.SolarbeamExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMoonriver, wsDialMoonriver, solarbeamWaitMilliseconds)
-	case dia.TrisolarisExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialAurora, wsDialAurora, trisolarisWaitMilliseconds)
-	case dia.NetswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMetis, wsDialMetis, metisWaitMilliseconds)
-	case dia.HuckleberryExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMoonriver, wsDialMoonriver, moonriverWaitMilliseconds)
-	case dia.TraderJoeExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialAvalanche, wsDialAvalanche, avalancheWaitMilliseconds)
-	case dia.PangolinExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialAvalanche, wsDialAvalanche, avalancheWaitMilliseconds)
-	case dia.TethysExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMetis, wsDialMetis, metisWaitMilliseconds)
-	case dia.HermesExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMetis, wsDialMetis, metisWaitMilliseconds)
-	case dia.OmniDexExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialTelos, wsDialTelos, telosWaitMilliseconds)
-	case dia.DiffusionExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialEvmos, wsDialEvmos, evmosWaitMilliseconds)
-	case dia.ApeswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds)
-	case dia.BiswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialBSC, wsDialBSC, pancakeswapWaitMilliseconds)
-	case dia.ArthswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialAstar, wsDialAstar, astarWaitMilliseconds)
-	case dia.StellaswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialMoonbeam, wsDialMoonbeam, moonbeamWaitMilliseconds)
-	case dia.WanswapExchange:
-		s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, restDialWanchain, wsDialWanchain, wanchainWaitMilliseconds)
-	}
-
-	s.relDB, err = models.NewPostgresDataStore()
-	if err != nil {
-		log.Fatal("new postgres datastore: ", err)
+	forkConfig, ok := uniswapForks[exchange.Name]
+	if !ok {
+		forkConfig = uniswapForkConfig{restDial: fork.RestURI, wsDial: fork.WsURI, waitMilliseconds: fork.WaitTime()}
 	}
+	s = makeUniswapScraper(exchange, listenByAddress, fetchPoolsFromDB, forkConfig.restDial, forkConfig.wsDial, forkConfig.waitMilliseconds)
+
+	s.relDB = relDB
 
 	// Only include pools with (minimum) liquidity bigger than given env var.
 	liquidityThreshold, err := strconv.ParseFloat(utils.Getenv("LIQUIDITY_THRESHOLD", "0"), 64)
@@ -238,7 +241,7 @@ func NewUniswapScraper(exchange dia.Exchange, scrape bool) *UniswapScraper {
 	}
 
 	// Fetch all pool with given liquidity threshold from database.
-	poolMap, err = s.makeUniPoolMap(liquidityThreshold)
+	s.poolMap, err = s.makeUniPoolMap(liquidityThreshold)
 	if err != nil {
 		log.Fatal("build poolMap: ", err)
 	}
@@ -275,17 +278,20 @@ func makeUniswapScraper(exchange dia.Exchange, listenByAddress bool, fetchPoolsF
 	}
 
 	s = &UniswapScraper{
-		WsClient:         wsClient,
-		RestClient:       restClient,
-		shutdown:         make(chan nothing),
-		shutdownDone:     make(chan nothing),
-		pairScrapers:     make(map[string]*UniswapPairScraper),
-		exchangeName:     exchange.Name,
-		error:            nil,
-		chanTrades:       make(chan *dia.Trade),
-		waitTime:         waitTime,
-		listenByAddress:  listenByAddress,
-		fetchPoolsFromDB: fetchPoolsFromDB,
+		WsClient:               wsClient,
+		RestClient:             restClient,
+		shutdown:               make(chan nothing),
+		shutdownDone:           make(chan nothing),
+		pairScrapers:           make(map[string]*UniswapPairScraper),
+		exchangeName:           exchange.Name,
+		blockchain:             exchange.BlockChain.Name,
+		error:                  nil,
+		chanTrades:             make(chan *dia.Trade),
+		waitTime:               waitTime,
+		listenByAddress:        listenByAddress,
+		fetchPoolsFromDB:       fetchPoolsFromDB,
+		factoryContractAddress: common.HexToAddress(exchange.Contract),
+		poolMap:                make(map[string]UniswapPair),
 	}
 	return s
 }
@@ -314,7 +320,7 @@ func (s *UniswapScraper) mainLoop() {
 
 		var wg sync.WaitGroup
 		count := 0
-		for address := range poolMap {
+		for address := range s.poolMap {
 			time.Sleep(time.Duration(s.waitTime) * time.Millisecond)
 			wg.Add(1)
 			go func(index int, address common.Address, w *sync.WaitGroup) {
@@ -369,7 +375,7 @@ func (s *UniswapScraper) ListenToPair(i int, address common.Address) {
 		}
 	} else {
 		// Relevant pool info is retrieved from @poolMap.
-		pair = poolMap[address.Hex()]
+		pair = s.poolMap[address.Hex()]
 	}
 
 	if len(pair.Token0.Symbol) < 2 || len(pair.Token1.Symbol) < 2 {
@@ -406,14 +412,14 @@ func (s *UniswapScraper) ListenToPair(i int, address common.Address) {
 					Symbol:     pair.Token0.Symbol,
 					Name:       pair.Token0.Name,
 					Decimals:   pair.Token0.Decimals,
-					Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+					Blockchain: s.blockchain,
 				}
 				token1 := dia.Asset{
 					Address:    pair.Token1.Address.Hex(),
 					Symbol:     pair.Token1.Symbol,
 					Name:       pair.Token1.Name,
 					Decimals:   pair.Token1.Decimals,
-					Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+					Blockchain: s.blockchain,
 				}
 				t := &dia.Trade{
 					Symbol:         pair.Token0.Symbol,
@@ -640,14 +646,14 @@ func (s *UniswapScraper) FetchAvailablePairs() (pairs []dia.ExchangePair, err er
 			Name:       pair.Token0.Name,
 			Address:    pair.Token0.Address.Hex(),
 			Decimals:   pair.Token0.Decimals,
-			Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+			Blockchain: s.blockchain,
 		}
 		basetoken := dia.Asset{
 			Symbol:     pair.Token1.Symbol,
 			Name:       pair.Token1.Name,
 			Address:    pair.Token1.Address.Hex(),
 			Decimals:   pair.Token1.Decimals,
-			Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+			Blockchain: s.blockchain,
 		}
 		pairToNormalise := dia.ExchangePair{
 			Symbol:         pair.Token0.Symbol,
@@ -674,7 +680,7 @@ func (s *UniswapScraper) GetAllPairs() ([]UniswapPair, error) {
 	time.Sleep(20 * time.Millisecond)
 	connection := s.RestClient
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), connection)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, connection)
 	if err != nil {
 		log.Error(err)
 	}
@@ -710,7 +716,7 @@ func (up *UniswapScraper) NormalizePair(pair dia.ExchangePair) (dia.ExchangePair
 // GetPairByID returns the UniswapPair with the integer id @num
 func (s *UniswapScraper) GetPairByID(num int64) (UniswapPair, error) {
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), s.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, s.RestClient)
 	if err != nil {
 		log.Error(err)
 		return UniswapPair{}, err
@@ -835,7 +841,7 @@ func (s *UniswapScraper) GetName(tokenAddress common.Address) (name string, err
 func (s *UniswapScraper) getNumPairs() (int, error) {
 
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), s.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, s.RestClient)
 	if err != nil {
 		log.Error(err)
 	}
@@ -942,7 +948,7 @@ func (s *UniswapScraper) makeUniPoolMap(liquiThreshold float64) (map[string]Unis
 			log.Error("fetch pool addresses from config file: ", errAddr)
 		}
 		for _, address := range poolAddresses {
-			pool, errPool := s.relDB.GetPoolByAddress(Exchanges[s.exchangeName].BlockChain.Name, address.Hex())
+			pool, errPool := s.relDB.GetPoolByAddress(s.blockchain, address.Hex())
 			if errPool != nil {
 				log.Fatalf("Get pool with address %s: %v", address.Hex(), errPool)
 			}
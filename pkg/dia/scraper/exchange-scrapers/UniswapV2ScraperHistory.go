@@ -37,15 +37,18 @@ type UniswapHistoryScraper struct {
 	// used to keep track of trading pairs that we subscribed to
 	pairScrapers  map[string]*UniswapHistoryPairScraper
 	exchangeName  string
+	blockchain    string
 	chanTrades    chan *dia.Trade
 	waitTime      int
 	genesisBlock  uint64
 	finalBlock    uint64
 	pairmap       map[common.Address]UniswapPair
 	pairAddresses []common.Address
-	//db            *models.RelDB
+	//db            models.RelDatastore
 	// If true, only pairs given in config file are scraped. Default is false.
 	listenByAddress bool
+	// factoryContractAddress is the address of the exchange's factory contract.
+	factoryContractAddress common.Address
 }
 
 const (
@@ -56,12 +59,17 @@ const (
 	uniswapHistoryWaitMilliseconds = "1000"
 )
 
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewUniswapHistoryScraper(registry.Exchanges[dia.UniswapExchange], scrape, relDB)
+	}, "UniswapHistory")
+}
+
 // NewUniswapScraper returns a new UniswapScraper for the given pair
-func NewUniswapHistoryScraper(exchange dia.Exchange, scrape bool, relDB *models.RelDB) *UniswapHistoryScraper {
+func NewUniswapHistoryScraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *UniswapHistoryScraper {
 	log.Info("NewUniswapHistoryScraper: ", exchange.Name)
 	var s *UniswapHistoryScraper
 	var listenByAddress bool
-	exchangeFactoryContractAddress = exchange.Contract
 
 	switch exchange.Name {
 	case dia.UniswapExchange:
@@ -120,18 +128,20 @@ func makeUniswapHistoryScraper(exchange dia.Exchange, listenByAddress bool, rest
 	}
 
 	s = &UniswapHistoryScraper{
-		WsClient:        wsClient,
-		RestClient:      restClient,
-		shutdown:        make(chan nothing),
-		shutdownDone:    make(chan nothing),
-		pairScrapers:    make(map[string]*UniswapHistoryPairScraper),
-		exchangeName:    exchange.Name,
-		error:           nil,
-		chanTrades:      make(chan *dia.Trade),
-		waitTime:        waitTime,
-		listenByAddress: listenByAddress,
-		genesisBlock:    uint64(startblock),
-		finalBlock:      uint64(finalblock),
+		WsClient:               wsClient,
+		RestClient:             restClient,
+		shutdown:               make(chan nothing),
+		shutdownDone:           make(chan nothing),
+		pairScrapers:           make(map[string]*UniswapHistoryPairScraper),
+		exchangeName:           exchange.Name,
+		blockchain:             exchange.BlockChain.Name,
+		error:                  nil,
+		chanTrades:             make(chan *dia.Trade),
+		waitTime:               waitTime,
+		listenByAddress:        listenByAddress,
+		genesisBlock:           uint64(startblock),
+		finalBlock:             uint64(finalblock),
+		factoryContractAddress: common.HexToAddress(exchange.Contract),
 	}
 	return s
 }
@@ -358,14 +368,14 @@ func (s *UniswapHistoryScraper) fetchSwaps(startblock uint64, endblock uint64) e
 			Symbol:     swp.Pair.Token0.Symbol,
 			Name:       swp.Pair.Token0.Name,
 			Decimals:   swp.Pair.Token0.Decimals,
-			Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+			Blockchain: s.blockchain,
 		}
 		token1 := dia.Asset{
 			Address:    swp.Pair.Token1.Address.Hex(),
 			Symbol:     swp.Pair.Token1.Symbol,
 			Name:       swp.Pair.Token1.Name,
 			Decimals:   swp.Pair.Token1.Decimals,
-			Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+			Blockchain: s.blockchain,
 		}
 
 		timestamp := time.Unix(int64(blockdata.Time()), 0)
@@ -486,7 +496,7 @@ func (s *UniswapHistoryScraper) GetAllPairs() ([]UniswapPair, error) {
 	time.Sleep(20 * time.Millisecond)
 	connection := s.RestClient
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), connection)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, connection)
 	if err != nil {
 		log.Error(err)
 	}
@@ -521,7 +531,7 @@ func (up *UniswapHistoryScraper) NormalizePair(pair dia.ExchangePair) (dia.Excha
 func (s *UniswapHistoryScraper) GetPairByID(num int64) (UniswapPair, error) {
 	log.Info("Get pair ID: ", num)
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), s.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, s.RestClient)
 	if err != nil {
 		log.Error(err)
 		return UniswapPair{}, err
@@ -646,7 +656,7 @@ func (s *UniswapHistoryScraper) GetName(tokenAddress common.Address) (name strin
 func (s *UniswapHistoryScraper) getNumPairs() (int, error) {
 
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), s.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(s.factoryContractAddress, s.RestClient)
 	if err != nil {
 		log.Error(err)
 	}
@@ -34,7 +34,7 @@ type UniswapV3Swap struct {
 type UniswapV3Scraper struct {
 	WsClient   *ethclient.Client
 	RestClient *ethclient.Client
-	relDB      *models.RelDB
+	relDB      models.RelDatastore
 	// signaling channels for session initialization and finishing
 	//initDone     chan nothing
 	run          bool
@@ -50,15 +50,24 @@ type UniswapV3Scraper struct {
 	pairRecieved chan *UniswapPair
 
 	exchangeName           string
+	blockchain             string
 	startBlock             uint64
 	waitTime               int
 	listenByAddress        bool
 	chanTrades             chan *dia.Trade
 	factoryContractAddress common.Address
+	// poolMap maps pool addresses onto the pools fetched from the database.
+	poolMap map[string]UniswapPair
+}
+
+func init() {
+	RegisterAPIScraper(func(exchange dia.Exchange, scrape bool, key string, secret string, relDB models.RelDatastore, registry *ExchangeRegistry) APIScraper {
+		return NewUniswapV3Scraper(exchange, scrape, relDB)
+	}, dia.UniswapExchangeV3, dia.UniswapExchangeV3Polygon, dia.UniswapExchangeV3Arbitrum)
 }
 
 // NewUniswapV3Scraper returns a new UniswapV3Scraper
-func NewUniswapV3Scraper(exchange dia.Exchange, scrape bool) *UniswapV3Scraper {
+func NewUniswapV3Scraper(exchange dia.Exchange, scrape bool, relDB models.RelDatastore) *UniswapV3Scraper {
 	log.Info("NewUniswapScraper ", exchange.Name)
 	log.Info("NewUniswapScraper Address ", exchange.Contract)
 
@@ -76,10 +85,7 @@ func NewUniswapV3Scraper(exchange dia.Exchange, scrape bool) *UniswapV3Scraper {
 		s = makeUniswapV3Scraper(exchange, false, "", "", "200", uint64(165))
 	}
 
-	s.relDB, err = models.NewPostgresDataStore()
-	if err != nil {
-		log.Fatal("new postgres datastore: ", err)
-	}
+	s.relDB = relDB
 
 	// Only include pools with (minimum) liquidity bigger than given env var.
 	liquidityThreshold, err := strconv.ParseFloat(utils.Getenv("LIQUIDITY_THRESHOLD", "0"), 64)
@@ -88,7 +94,7 @@ func NewUniswapV3Scraper(exchange dia.Exchange, scrape bool) *UniswapV3Scraper {
 		log.Warnf("parse liquidity threshold:  %v. Set to default %v", err, liquidityThreshold)
 	}
 
-	poolMap, err = s.makeUniV3PoolMap(liquidityThreshold)
+	s.poolMap, err = s.makeUniV3PoolMap(liquidityThreshold)
 	if err != nil {
 		log.Fatal("build poolMap: ", err)
 	}
@@ -130,6 +136,7 @@ func makeUniswapV3Scraper(exchange dia.Exchange, listenByAddress bool, restDial
 		shutdownDone:           make(chan nothing),
 		pairScrapers:           make(map[string]*UniswapPairV3Scraper),
 		exchangeName:           exchange.Name,
+		blockchain:             exchange.BlockChain.Name,
 		pairRecieved:           make(chan *UniswapPair),
 		error:                  nil,
 		chanTrades:             make(chan *dia.Trade),
@@ -137,6 +144,7 @@ func makeUniswapV3Scraper(exchange dia.Exchange, listenByAddress bool, restDial
 		listenByAddress:        listenByAddress,
 		startBlock:             startBlock,
 		factoryContractAddress: common.HexToAddress(exchange.Contract),
+		poolMap:                make(map[string]UniswapPair),
 	}
 	return s
 }
@@ -205,14 +213,14 @@ func (s *UniswapV3Scraper) mainLoop() {
 						Symbol:     pool.Token0.Symbol,
 						Name:       pool.Token0.Name,
 						Decimals:   pool.Token0.Decimals,
-						Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+						Blockchain: s.blockchain,
 					}
 					token1 := dia.Asset{
 						Address:    pool.Token1.Address.Hex(),
 						Symbol:     pool.Token1.Symbol,
 						Name:       pool.Token1.Name,
 						Decimals:   pool.Token1.Decimals,
-						Blockchain: Exchanges[s.exchangeName].BlockChain.Name,
+						Blockchain: s.blockchain,
 					}
 
 					t := &dia.Trade{
@@ -281,7 +289,7 @@ func (s *UniswapV3Scraper) getSwapData(swap UniswapV3Swap) (price float64, volum
 // normalizeUniswapSwap takes a swap as returned by the swap contract's channel and converts it to a UniswapSwap type
 func (s *UniswapV3Scraper) normalizeUniswapSwap(swap UniswapV3Pair.UniswapV3PairSwap) (normalizedSwap UniswapV3Swap) {
 
-	pair := poolMap[swap.Raw.Address.Hex()]
+	pair := s.poolMap[swap.Raw.Address.Hex()]
 
 	decimals0 := int(pair.Token0.Decimals)
 	decimals1 := int(pair.Token1.Decimals)
@@ -387,8 +395,8 @@ func (s *UniswapV3Scraper) GetDecimals(tokenAddress common.Address) (decimals ui
 }
 
 func (s *UniswapV3Scraper) feedPoolsToSubscriptions() (pairs []UniswapPair) {
-	for i := range poolMap {
-		up := poolMap[i]
+	for i := range s.poolMap {
+		up := s.poolMap[i]
 		pairs = append(pairs, up)
 		s.pairRecieved <- &up
 	}
@@ -34,6 +34,12 @@ type BalancerV2Scraper struct {
 	cachedAssets           map[string]dia.Asset
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewBalancerV2Scraper(exchange)
+	}, dia.BalancerV2Exchange, dia.BalancerV2ExchangePolygon, dia.BeetsExchange)
+}
+
 // NewBalancerV2Scraper returns a Balancer V2 scraper
 func NewBalancerV2Scraper(exchange dia.Exchange) *BalancerV2Scraper {
 	var (
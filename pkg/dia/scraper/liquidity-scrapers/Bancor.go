@@ -79,6 +79,12 @@ type BancorPools struct {
 	} `json:"timestamp"`
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewBancorPoolScraper(exchange)
+	}, dia.BancorExchange)
+}
+
 func NewBancorPoolScraper(exchange dia.Exchange) *BancorPoolScraper {
 	var (
 		restClient  *ethclient.Client
@@ -32,6 +32,12 @@ type CurveFIScraper struct {
 	poolAddrs    []string
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewCurveFIScraper(exchange)
+	}, dia.CurveFIExchange, dia.CurveFIExchangePolygon, dia.CurveFIExchangeFantom, dia.CurveFIExchangeMoonbeam, dia.CurveFIExchangeArbitrum)
+}
+
 func NewCurveFIScraper(exchange dia.Exchange) *CurveFIScraper {
 	var (
 		restClient  *ethclient.Client
@@ -30,6 +30,12 @@ type OrcaScraper struct {
 	doneChannel  chan bool
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewOrcaScraper(exchange)
+	}, dia.OrcaExchange)
+}
+
 func NewOrcaScraper(exchange dia.Exchange) *OrcaScraper {
 
 	log.Infof("init rest and ws client for %s", exchange.BlockChain.Name)
@@ -50,6 +50,12 @@ type PlatypusScraper struct {
 	basePoolRegistry platypusRegistry
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewPlatypusScraper(exchange)
+	}, dia.PlatypusExchange)
+}
+
 // Returns a new exchange scraper
 func NewPlatypusScraper(exchange dia.Exchange) *PlatypusScraper {
 
@@ -12,104 +12,43 @@ type LiquidityScraper interface {
 }
 
 var (
-	log       *logrus.Logger
-	exchanges map[string]dia.Exchange
+	log *logrus.Logger
 )
 
 func init() {
 	log = logrus.New()
-	exchanges = scrapers.Exchanges
+}
 
+// LiquidityScraperFactory returns a liquidity scraper for @exchange.
+type LiquidityScraperFactory func(exchange dia.Exchange) LiquidityScraper
+
+// liquidityScraperFactories maps exchange names onto the factory of their liquidity scraper.
+// Scrapers register their factories in the init function of their file.
+var liquidityScraperFactories = make(map[string]LiquidityScraperFactory)
+
+// RegisterLiquidityScraper registers @factory for all exchanges in @exchangeNames.
+// It panics if a factory is already registered for one of the exchanges.
+func RegisterLiquidityScraper(factory LiquidityScraperFactory, exchangeNames ...string) {
+	for _, name := range exchangeNames {
+		if _, ok := liquidityScraperFactories[name]; ok {
+			panic("liquidityscrapers: RegisterLiquidityScraper called twice for exchange " + name)
+		}
+		liquidityScraperFactories[name] = factory
+	}
 }
 
 // NewLiquidityScraper returns a liquidity scraper for @source.
-func NewLiquidityScraper(source string) LiquidityScraper {
-	switch source {
-	case dia.UniswapExchange:
-		return NewUniswapScraper(exchanges[dia.UniswapExchange])
-	case dia.SushiSwapExchange:
-		return NewUniswapScraper(exchanges[dia.SushiSwapExchange])
-	case dia.SushiSwapExchangePolygon:
-		return NewUniswapScraper(exchanges[dia.SushiSwapExchangePolygon])
-	case dia.SushiSwapExchangeFantom:
-		return NewUniswapScraper(exchanges[dia.SushiSwapExchangeFantom])
-	case dia.SushiSwapExchangeArbitrum:
-		return NewUniswapScraper(exchanges[dia.SushiSwapExchangeArbitrum])
-	case dia.CamelotExchange:
-		return NewUniswapScraper(exchanges[dia.CamelotExchange])
-	case dia.PanCakeSwap:
-		return NewUniswapScraper(exchanges[dia.PanCakeSwap])
-	case dia.DfynNetwork:
-		return NewUniswapScraper(exchanges[dia.DfynNetwork])
-	case dia.QuickswapExchange:
-		return NewUniswapScraper(exchanges[dia.QuickswapExchange])
-	case dia.UbeswapExchange:
-		return NewUniswapScraper(exchanges[dia.UbeswapExchange])
-	case dia.SpookyswapExchange:
-		return NewUniswapScraper(exchanges[dia.SpookyswapExchange])
-	case dia.SpiritswapExchange:
-		return NewUniswapScraper(exchanges[dia.SpiritswapExchange])
-	case dia.SolarbeamExchange:
-		return NewUniswapScraper(exchanges[dia.SolarbeamExchange])
-	case dia.TrisolarisExchange:
-		return NewUniswapScraper(exchanges[dia.TrisolarisExchange])
-	case dia.NetswapExchange:
-		return NewUniswapScraper(exchanges[dia.NetswapExchange])
-	case dia.HuckleberryExchange:
-		return NewUniswapScraper(exchanges[dia.HuckleberryExchange])
-	case dia.TraderJoeExchange:
-		return NewUniswapScraper(exchanges[dia.TraderJoeExchange])
-	case dia.PangolinExchange:
-		return NewUniswapScraper(exchanges[dia.PangolinExchange])
-	case dia.TethysExchange:
-		return NewUniswapScraper(exchanges[dia.TethysExchange])
-	case dia.HermesExchange:
-		return NewUniswapScraper(exchanges[dia.HermesExchange])
-	case dia.OmniDexExchange:
-		return NewUniswapScraper(exchanges[dia.OmniDexExchange])
-	case dia.DiffusionExchange:
-		return NewUniswapScraper(exchanges[dia.DiffusionExchange])
-	case dia.ApeswapExchange:
-		return NewUniswapScraper(exchanges[dia.ApeswapExchange])
-	case dia.BiswapExchange:
-		return NewUniswapScraper(exchanges[dia.BiswapExchange])
-	case dia.ArthswapExchange:
-		return NewUniswapScraper(exchanges[dia.ArthswapExchange])
-	case dia.CurveFIExchange:
-		return NewCurveFIScraper(exchanges[dia.CurveFIExchange])
-	case dia.CurveFIExchangePolygon:
-		return NewCurveFIScraper(exchanges[dia.CurveFIExchangePolygon])
-	case dia.CurveFIExchangeFantom:
-		return NewCurveFIScraper(exchanges[dia.CurveFIExchangeFantom])
-	case dia.CurveFIExchangeMoonbeam:
-		return NewCurveFIScraper(exchanges[dia.CurveFIExchangeMoonbeam])
-	case dia.CurveFIExchangeArbitrum:
-		return NewCurveFIScraper(exchanges[dia.CurveFIExchangeArbitrum])
-	case dia.BalancerV2Exchange:
-		return NewBalancerV2Scraper(exchanges[dia.BalancerV2Exchange])
-	case dia.PlatypusExchange:
-		return NewPlatypusScraper(exchanges[dia.PlatypusExchange])
-	case dia.BalancerV2ExchangePolygon:
-		return NewBalancerV2Scraper(exchanges[dia.BalancerV2ExchangePolygon])
-	case dia.BeetsExchange:
-		return NewBalancerV2Scraper(exchanges[dia.BeetsExchange])
-	case dia.UniswapExchangeV3:
-		return NewUniswapV3Scraper(exchanges[dia.UniswapExchangeV3])
-	case dia.UniswapExchangeV3Polygon:
-		return NewUniswapV3Scraper(exchanges[dia.UniswapExchangeV3Polygon])
-	case dia.StellaswapExchange:
-		return NewUniswapScraper(exchanges[dia.StellaswapExchange])
-	case dia.WanswapExchange:
-		return NewUniswapScraper(exchanges[dia.WanswapExchange])
-	case dia.UniswapExchangeV3Arbitrum:
-		return NewUniswapV3Scraper(exchanges[dia.UniswapExchangeV3Arbitrum])
-	case dia.BancorExchange:
-		return NewBancorPoolScraper(exchanges[dia.BancorExchange])
-	case dia.OrcaExchange:
-		return NewOrcaScraper(exchanges[dia.OrcaExchange])
-
-	default:
-		return nil
+// The exchange corresponding to @source is taken from @registry.
+// UniswapV2 forks configured in @registry are served by the UniswapV2 liquidity scraper.
+// Returns nil if no scraper is registered for @source.
+func NewLiquidityScraper(source string, registry *scrapers.ExchangeRegistry) LiquidityScraper {
+	factory, ok := liquidityScraperFactories[source]
+	if !ok {
+		fork, isFork := registry.UniswapV2Forks[source]
+		if !isFork {
+			return nil
+		}
+		return newUniswapScraper(registry.Exchanges[source], fork)
 	}
-
+	return factory(registry.Exchanges[source])
 }
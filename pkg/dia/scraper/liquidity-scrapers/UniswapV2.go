@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+	scrapers "github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers"
 	"github.com/diadata-org/diadata/pkg/dia/scraper/exchange-scrapers/uniswap"
 
 	"github.com/diadata-org/diadata/pkg/dia"
@@ -63,6 +64,44 @@ const (
 	wanchainWaitMilliseconds    = "1000"
 )
 
+// uniswapForkConfig contains the dial and the time waited between requests of a UniswapV2 fork.
+type uniswapForkConfig struct {
+	restDial         string
+	waitMilliseconds string
+}
+
+// uniswapForks are the UniswapV2 forks with built-in configuration.
+// Further forks can be added through the UniswapV2Forks of the ExchangeRegistry.
+var uniswapForks = map[string]uniswapForkConfig{
+	dia.UniswapExchange:           {restDialEthereum, uniswapWaitMilliseconds},
+	dia.SushiSwapExchange:         {restDialEthereum, sushiswapWaitMilliseconds},
+	dia.SushiSwapExchangePolygon:  {restDialPolygon, sushiswapWaitMilliseconds},
+	dia.SushiSwapExchangeFantom:   {restDialFantom, sushiswapWaitMilliseconds},
+	dia.SushiSwapExchangeArbitrum: {restDialArbitrum, sushiswapWaitMilliseconds},
+	dia.CamelotExchange:           {restDialArbitrum, sushiswapWaitMilliseconds},
+	dia.PanCakeSwap:               {restDialBSC, pancakeswapWaitMilliseconds},
+	dia.DfynNetwork:               {restDialPolygon, dfynWaitMilliseconds},
+	dia.QuickswapExchange:         {restDialPolygon, dfynWaitMilliseconds},
+	dia.UbeswapExchange:           {restDialCelo, ubeswapWaitMilliseconds},
+	dia.SpookyswapExchange:        {restDialFantom, spookyswapWaitMilliseconds},
+	dia.SpiritswapExchange:        {restDialFantom, spiritswapWaitMilliseconds},
+	dia.SolarbeamExchange:         {restDialMoonriver, solarbeamWaitMilliseconds},
+	dia.TrisolarisExchange:        {restDialAurora, trisolarisWaitMilliseconds},
+	dia.NetswapExchange:           {restDialMetis, metisWaitMilliseconds},
+	dia.HuckleberryExchange:       {restDialMoonriver, moonriverWaitMilliseconds},
+	dia.TraderJoeExchange:         {restDialAvalanche, avalancheWaitMilliseconds},
+	dia.PangolinExchange:          {restDialAvalanche, avalancheWaitMilliseconds},
+	dia.TethysExchange:            {restDialMetis, metisWaitMilliseconds},
+	dia.HermesExchange:            {restDialMetis, metisWaitMilliseconds},
+	dia.OmniDexExchange:           {restDialTelos, telosWaitMilliseconds},
+	dia.DiffusionExchange:         {restDialEvmos, evmosWaitMilliseconds},
+	dia.ArthswapExchange:          {restDialAstar, astarWaitMilliseconds},
+	dia.ApeswapExchange:           {restDialAstar, astarWaitMilliseconds},
+	dia.BiswapExchange:            {restDialAstar, astarWaitMilliseconds},
+	dia.StellaswapExchange:        {restDialMoonbeam, moonbeamWaitMilliseconds},
+	dia.WanswapExchange:           {restDialWanchain, wanchainWaitMilliseconds},
+}
+
 type UniswapScraper struct {
 	RestClient   *ethclient.Client
 	poolChannel  chan dia.Pool
@@ -71,72 +110,36 @@ type UniswapScraper struct {
 	waitTime     int
 	exchangeName string
 	pathToPools  string
+	// factoryContractAddress is the address of the exchange's factory contract.
+	factoryContractAddress common.Address
+}
+
+func init() {
+	for name := range uniswapForks {
+		RegisterLiquidityScraper(newUniswapLiquidityScraper, name)
+	}
+}
+
+// newUniswapLiquidityScraper is the LiquidityScraperFactory of all UniswapV2 forks.
+func newUniswapLiquidityScraper(exchange dia.Exchange) LiquidityScraper {
+	return NewUniswapScraper(exchange)
 }
 
-var exchangeFactoryContractAddress string
+func NewUniswapScraper(exchange dia.Exchange) *UniswapScraper {
+	return newUniswapScraper(exchange, scrapers.UniswapV2Fork{})
+}
 
-func NewUniswapScraper(exchange dia.Exchange) (us *UniswapScraper) {
+// newUniswapScraper returns a new UniswapScraper. @fork is the configuration of @exchange
+// if it is a fork without dedicated configuration in uniswapForks.
+func newUniswapScraper(exchange dia.Exchange, fork scrapers.UniswapV2Fork) (us *UniswapScraper) {
 
 	pathToPools := utils.Getenv("PATH_TO_POOLS", "")
 
-	switch exchange.Name {
-	case dia.UniswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialEthereum, uniswapWaitMilliseconds)
-	case dia.SushiSwapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialEthereum, sushiswapWaitMilliseconds)
-	case dia.SushiSwapExchangePolygon:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialPolygon, sushiswapWaitMilliseconds)
-	case dia.SushiSwapExchangeFantom:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialFantom, sushiswapWaitMilliseconds)
-	case dia.SushiSwapExchangeArbitrum:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialArbitrum, sushiswapWaitMilliseconds)
-	case dia.CamelotExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialArbitrum, sushiswapWaitMilliseconds)
-	case dia.PanCakeSwap:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialBSC, pancakeswapWaitMilliseconds)
-	case dia.DfynNetwork:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialPolygon, dfynWaitMilliseconds)
-	case dia.QuickswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialPolygon, dfynWaitMilliseconds)
-	case dia.UbeswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialCelo, ubeswapWaitMilliseconds)
-	case dia.SpookyswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialFantom, spookyswapWaitMilliseconds)
-	case dia.SpiritswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialFantom, spiritswapWaitMilliseconds)
-	case dia.SolarbeamExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMoonriver, solarbeamWaitMilliseconds)
-	case dia.TrisolarisExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAurora, trisolarisWaitMilliseconds)
-	case dia.NetswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMetis, metisWaitMilliseconds)
-	case dia.HuckleberryExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMoonriver, moonriverWaitMilliseconds)
-	case dia.TraderJoeExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAvalanche, avalancheWaitMilliseconds)
-	case dia.PangolinExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAvalanche, avalancheWaitMilliseconds)
-	case dia.TethysExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMetis, metisWaitMilliseconds)
-	case dia.HermesExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMetis, metisWaitMilliseconds)
-	case dia.OmniDexExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialTelos, telosWaitMilliseconds)
-	case dia.DiffusionExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialEvmos, evmosWaitMilliseconds)
-	case dia.ArthswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAstar, astarWaitMilliseconds)
-	case dia.ApeswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAstar, astarWaitMilliseconds)
-	case dia.BiswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialAstar, astarWaitMilliseconds)
-	case dia.StellaswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialMoonbeam, moonbeamWaitMilliseconds)
-	case dia.WanswapExchange:
-		us = makeUniswapPoolScraper(exchange, pathToPools, restDialWanchain, wanchainWaitMilliseconds)
+	forkConfig, ok := uniswapForks[exchange.Name]
+	if !ok {
+		forkConfig = uniswapForkConfig{restDial: fork.RestURI, waitMilliseconds: fork.WaitTime()}
 	}
-
-	exchangeFactoryContractAddress = exchange.Contract
+	us = makeUniswapPoolScraper(exchange, pathToPools, forkConfig.restDial, forkConfig.waitMilliseconds)
 
 	go func() {
 		us.fetchPools()
@@ -176,6 +179,8 @@ func makeUniswapPoolScraper(exchange dia.Exchange, pathToPools string, restDial
 		waitTime:     waitTime,
 		exchangeName: exchange.Name,
 		pathToPools:  pathToPools,
+
+		factoryContractAddress: common.HexToAddress(exchange.Contract),
 	}
 	return us
 }
@@ -229,7 +234,7 @@ func (us *UniswapScraper) fetchPools() {
 func (us *UniswapScraper) GetPoolByID(num int64) (dia.Pool, error) {
 	var contract *uniswap.IUniswapV2FactoryCaller
 
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), us.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(us.factoryContractAddress, us.RestClient)
 	if err != nil {
 		log.Error(err)
 		return dia.Pool{}, err
@@ -383,7 +388,7 @@ func (us *UniswapScraper) Done() chan bool {
 
 func (us *UniswapScraper) getNumPairs() (int, error) {
 	var contract *uniswap.IUniswapV2FactoryCaller
-	contract, err := uniswap.NewIUniswapV2FactoryCaller(common.HexToAddress(exchangeFactoryContractAddress), us.RestClient)
+	contract, err := uniswap.NewIUniswapV2FactoryCaller(us.factoryContractAddress, us.RestClient)
 	if err != nil {
 		log.Error(err)
 	}
@@ -29,6 +29,12 @@ type UniswapV3Scraper struct {
 	waitTime        int
 }
 
+func init() {
+	RegisterLiquidityScraper(func(exchange dia.Exchange) LiquidityScraper {
+		return NewUniswapV3Scraper(exchange)
+	}, dia.UniswapExchangeV3, dia.UniswapExchangeV3Polygon, dia.UniswapExchangeV3Arbitrum)
+}
+
 // NewUniswapV3Scraper returns a new UniswapV3Scraper.
 func NewUniswapV3Scraper(exchange dia.Exchange) *UniswapV3Scraper {
 	log.Info("NewUniswapScraper ", exchange.Name)
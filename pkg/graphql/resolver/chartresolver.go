@@ -37,6 +37,11 @@ func (qr *FilterPointResolver) Blockchain(ctx context.Context) (*string, error)
 	return &qr.q.Asset.Blockchain, nil
 }
 
+// Exchange returns the exchange of the filter point, which is empty for filters across all exchanges.
+func (qr *FilterPointResolver) Exchange(ctx context.Context) (*string, error) {
+	return &qr.q.Source, nil
+}
+
 func (qr *FilterPointResolver) FirstTrade(ctx context.Context) (*TradeResolver, error) {
 	return &TradeResolver{q: qr.q.FirstTrade}, nil
 }
@@ -0,0 +1,35 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type exchangeVolume struct {
+	Exchange  string
+	Volume    float64
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type ExchangeVolumeResolver struct {
+	q exchangeVolume
+}
+
+func (er *ExchangeVolumeResolver) Exchange(ctx context.Context) (*string, error) {
+	return &er.q.Exchange, nil
+}
+
+func (er *ExchangeVolumeResolver) Volume(ctx context.Context) (*float64, error) {
+	return &er.q.Volume, nil
+}
+
+func (er *ExchangeVolumeResolver) StartTime(ctx context.Context) (*graphql.Time, error) {
+	return &graphql.Time{Time: er.q.StartTime}, nil
+}
+
+func (er *ExchangeVolumeResolver) EndTime(ctx context.Context) (*graphql.Time, error) {
+	return &graphql.Time{Time: er.q.EndTime}, nil
+}
@@ -2,6 +2,7 @@ package resolver
 
 import (
 	"context"
+	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
 	"github.com/graph-gophers/graphql-go"
@@ -237,3 +238,27 @@ func (br *NFTBidResolver) TxHash(ctx context.Context) (*string, error) {
 func (br *NFTBidResolver) Exchange(ctx context.Context) (*string, error) {
 	return &br.bid.Exchange, nil
 }
+
+// -----------------------------------------------------------------------------
+
+type nftFloor struct {
+	Floor  float64
+	Time   time.Time
+	Source string
+}
+
+type NFTFloorResolver struct {
+	q nftFloor
+}
+
+func (fr *NFTFloorResolver) FloorPrice(ctx context.Context) (*float64, error) {
+	return &fr.q.Floor, nil
+}
+
+func (fr *NFTFloorResolver) Time(ctx context.Context) (*graphql.Time, error) {
+	return &graphql.Time{Time: fr.q.Time}, nil
+}
+
+func (fr *NFTFloorResolver) Source(ctx context.Context) (*string, error) {
+	return &fr.q.Source, nil
+}
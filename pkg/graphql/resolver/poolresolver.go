@@ -0,0 +1,63 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// PoolResolver resolves a liquidity pool. The total liquidity is nil if
+// a price is missing for one of the pool's assets.
+type PoolResolver struct {
+	p                 dia.Pool
+	totalLiquidityUSD *float64
+}
+
+func (pr *PoolResolver) Exchange(ctx context.Context) (*string, error) {
+	return &pr.p.Exchange.Name, nil
+}
+
+func (pr *PoolResolver) Blockchain(ctx context.Context) (*string, error) {
+	return &pr.p.Blockchain.Name, nil
+}
+
+func (pr *PoolResolver) Address(ctx context.Context) (*string, error) {
+	return &pr.p.Address, nil
+}
+
+func (pr *PoolResolver) Time(ctx context.Context) (*graphql.Time, error) {
+	return &graphql.Time{Time: pr.p.Time}, nil
+}
+
+func (pr *PoolResolver) TotalLiquidityUSD(ctx context.Context) (*float64, error) {
+	return pr.totalLiquidityUSD, nil
+}
+
+func (pr *PoolResolver) Liquidity(ctx context.Context) (*[]*AssetLiquidityResolver, error) {
+	var lr []*AssetLiquidityResolver
+	for _, assetvolume := range pr.p.Assetvolumes {
+		lr = append(lr, &AssetLiquidityResolver{a: assetvolume})
+	}
+	return &lr, nil
+}
+
+type AssetLiquidityResolver struct {
+	a dia.AssetVolume
+}
+
+func (ar *AssetLiquidityResolver) Symbol(ctx context.Context) (*string, error) {
+	return &ar.a.Asset.Symbol, nil
+}
+
+func (ar *AssetLiquidityResolver) Address(ctx context.Context) (*string, error) {
+	return &ar.a.Asset.Address, nil
+}
+
+func (ar *AssetLiquidityResolver) Blockchain(ctx context.Context) (*string, error) {
+	return &ar.a.Asset.Blockchain, nil
+}
+
+func (ar *AssetLiquidityResolver) Liquidity(ctx context.Context) (*float64, error) {
+	return &ar.a.Volume, nil
+}
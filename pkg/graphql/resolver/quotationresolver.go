@@ -0,0 +1,48 @@
+package resolver
+
+import (
+	"context"
+
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type AssetQuotationResolver struct {
+	q models.AssetQuotationFull
+}
+
+func (qr *AssetQuotationResolver) Symbol(ctx context.Context) (*string, error) {
+	return &qr.q.Symbol, nil
+}
+
+func (qr *AssetQuotationResolver) Name(ctx context.Context) (*string, error) {
+	return &qr.q.Name, nil
+}
+
+func (qr *AssetQuotationResolver) Address(ctx context.Context) (*string, error) {
+	return &qr.q.Address, nil
+}
+
+func (qr *AssetQuotationResolver) Blockchain(ctx context.Context) (*string, error) {
+	return &qr.q.Blockchain, nil
+}
+
+func (qr *AssetQuotationResolver) Price(ctx context.Context) (*float64, error) {
+	return &qr.q.Price, nil
+}
+
+func (qr *AssetQuotationResolver) PriceYesterday(ctx context.Context) (*float64, error) {
+	return &qr.q.PriceYesterday, nil
+}
+
+func (qr *AssetQuotationResolver) VolumeYesterdayUSD(ctx context.Context) (*float64, error) {
+	return &qr.q.VolumeYesterdayUSD, nil
+}
+
+func (qr *AssetQuotationResolver) Time(ctx context.Context) (*graphql.Time, error) {
+	return &graphql.Time{Time: qr.q.Time}, nil
+}
+
+func (qr *AssetQuotationResolver) Source(ctx context.Context) (*string, error) {
+	return &qr.q.Source, nil
+}
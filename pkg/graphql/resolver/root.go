@@ -2,6 +2,8 @@ package resolver
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
@@ -15,11 +17,27 @@ import (
 
 var log = logrus.New()
 
+const (
+	// maxQuotationAssets is the maximal number of assets in a GetAssetQuotations query.
+	maxQuotationAssets = 100
+	// maxExchangeVolumesRange is the maximal time range of a GetExchangeVolumes query.
+	maxExchangeVolumesRange = 30 * 24 * time.Hour
+	// maxExchangeVolumesExchanges is the maximal number of exchanges in a GetExchangeVolumes query.
+	maxExchangeVolumesExchanges = 20
+)
+
+// FilterPointSubscriber provides live filter points for subscriptions.
+type FilterPointSubscriber interface {
+	SubscribeFilterPoints(ctx context.Context, filter string, exchange string, assets []dia.Asset) (<-chan dia.FilterPoint, error)
+}
+
 // Resolver is the root resolver
 type DiaResolver struct {
 	DS              models.DB
 	RelDB           models.RelDB
 	InfluxBatchSize int64
+	// Stream serves subscriptions. If nil, subscriptions fail.
+	Stream FilterPointSubscriber
 }
 
 func (r *DiaResolver) GetSupply(ctx context.Context, args struct{ Symbol graphql.NullString }) (*SupplyResolver, error) {
@@ -84,6 +102,11 @@ type BaseAsset struct {
 	BlockChain string
 }
 
+type AssetInput struct {
+	Address    graphql.NullString
+	Blockchain graphql.NullString
+}
+
 func (r *DiaResolver) GetChart(ctx context.Context, args struct {
 	Filter               graphql.NullString
 	BlockDurationSeconds graphql.NullInt
@@ -293,6 +316,10 @@ func (r *DiaResolver) GetChartMeta(ctx context.Context, args struct {
 		{
 			filterPoints, filterMetadata = queryhelper.FilterMEDIR(tradeBlocks, asset, int(blockSizeSeconds))
 		}
+	case "twap":
+		{
+			filterPoints, filterMetadata = queryhelper.FilterTWAP(tradeBlocks, asset, int(blockSizeSeconds))
+		}
 	case "vol":
 		{
 			filterPoints, filterMetadata = queryhelper.FilterVOL(tradeBlocks, asset, int(blockSizeSeconds))
@@ -488,3 +515,226 @@ func (r *DiaResolver) GetNFTBids(ctx context.Context, args struct {
 
 	return &br, nil
 }
+
+// GetAssetQuotation returns the latest quotation of an asset by address and blockchain.
+func (r *DiaResolver) GetAssetQuotation(ctx context.Context, args struct {
+	Address    graphql.NullString
+	Blockchain graphql.NullString
+}) (*AssetQuotationResolver, error) {
+	asset, err := r.RelDB.GetAsset(*args.Address.Value, *args.Blockchain.Value)
+	if err != nil {
+		return nil, err
+	}
+	q, err := r.getAssetQuotation(asset)
+	if err != nil {
+		return nil, err
+	}
+	return &AssetQuotationResolver{q: q}, nil
+}
+
+// GetAssetQuotations returns the latest quotations of a list of assets.
+// Assets without quotation are omitted.
+func (r *DiaResolver) GetAssetQuotations(ctx context.Context, args struct {
+	Assets []AssetInput
+}) (*[]*AssetQuotationResolver, error) {
+	if len(args.Assets) > maxQuotationAssets {
+		return nil, fmt.Errorf("at most %d assets can be queried", maxQuotationAssets)
+	}
+
+	var qr []*AssetQuotationResolver
+	for _, assetInput := range args.Assets {
+		asset, err := r.RelDB.GetAsset(*assetInput.Address.Value, *assetInput.Blockchain.Value)
+		if err != nil {
+			log.Warnf("Asset not found with address %s and blockchain %s ", *assetInput.Address.Value, *assetInput.Blockchain.Value)
+			continue
+		}
+		q, err := r.getAssetQuotation(asset)
+		if err != nil {
+			log.Warnf("no quotation for %s on %s: %v", asset.Address, asset.Blockchain, err)
+			continue
+		}
+		qr = append(qr, &AssetQuotationResolver{q: q})
+	}
+	return &qr, nil
+}
+
+// getAssetQuotation returns the latest quotation of @asset along with price and volume of the previous day.
+func (r *DiaResolver) getAssetQuotation(asset dia.Asset) (q models.AssetQuotationFull, err error) {
+	timestamp := time.Now()
+	quotation, err := r.DS.GetAssetQuotation(asset, timestamp)
+	if err != nil {
+		return
+	}
+	quotationYesterday, err := r.DS.GetAssetQuotation(asset, timestamp.AddDate(0, 0, -1))
+	if err != nil {
+		log.Warn("get quotation yesterday: ", err)
+	} else {
+		q.PriceYesterday = quotationYesterday.Price
+	}
+	volumeYesterday, err := r.DS.Get24HoursAssetVolume(asset)
+	if err != nil {
+		log.Warn("get volume yesterday: ", err)
+	} else {
+		q.VolumeYesterdayUSD = *volumeYesterday
+	}
+
+	q.Symbol = quotation.Asset.Symbol
+	q.Name = quotation.Asset.Name
+	q.Address = quotation.Asset.Address
+	q.Blockchain = quotation.Asset.Blockchain
+	q.Price = quotation.Price
+	q.Time = quotation.Time
+	q.Source = quotation.Source
+	return q, nil
+}
+
+// GetPool returns a liquidity pool by address and blockchain along with its total liquidity in USD.
+func (r *DiaResolver) GetPool(ctx context.Context, args struct {
+	Address    graphql.NullString
+	Blockchain graphql.NullString
+}) (*PoolResolver, error) {
+	pool, err := r.RelDB.GetPoolByAddress(*args.Blockchain.Value, *args.Address.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalLiquidity float64
+	for _, assetvolume := range pool.Assetvolumes {
+		price, err := r.DS.GetAssetPriceUSDCache(assetvolume.Asset)
+		if err != nil {
+			log.Warnf("no quotation for %v: %v", assetvolume.Asset, err)
+			return &PoolResolver{p: pool}, nil
+		}
+		totalLiquidity += price * assetvolume.Volume
+	}
+	return &PoolResolver{p: pool, totalLiquidityUSD: &totalLiquidity}, nil
+}
+
+// GetNFTFloor returns the floor price of an NFT collection at a given time, defaulting to now.
+// If no floor window is given, the floor is searched in 24h windows up to 30 days back.
+func (r *DiaResolver) GetNFTFloor(ctx context.Context, args struct {
+	Address            graphql.NullString
+	Blockchain         graphql.NullString
+	Time               graphql.NullTime
+	FloorWindowSeconds graphql.NullInt
+	Exchange           graphql.NullString
+	Bundles            graphql.NullBool
+}) (*NFTFloorResolver, error) {
+	timestamp := time.Now()
+	if args.Time.Value != nil {
+		timestamp = args.Time.Value.Time
+	}
+	floorWindow := 24 * time.Hour
+	stepBackLimit := 30
+	if args.FloorWindowSeconds.Value != nil {
+		floorWindow = time.Duration(*args.FloorWindowSeconds.Value) * time.Second
+		stepBackLimit = 1
+	}
+	var exchange string
+	if args.Exchange.Value != nil {
+		exchange = *args.Exchange.Value
+	}
+	// Exclude bundle sales by default.
+	bundles := args.Bundles.Value != nil && *args.Bundles.Value
+
+	nftClass := dia.NFTClass{Address: *args.Address.Value, Blockchain: *args.Blockchain.Value}
+	floor, err := r.RelDB.GetNFTFloorRecursive(nftClass, timestamp, floorWindow, stepBackLimit, !bundles, exchange)
+	if err != nil {
+		return nil, err
+	}
+	return &NFTFloorResolver{q: nftFloor{Floor: floor, Time: timestamp, Source: dia.Diadata}}, nil
+}
+
+// GetExchangeVolumes returns the trading volumes in USD of the given exchanges in a time range
+// defaulting to the last 24 hours. Only exchanges known to the exchange table can be queried.
+func (r *DiaResolver) GetExchangeVolumes(ctx context.Context, args struct {
+	Exchanges []graphql.NullString
+	StartTime graphql.NullTime
+	EndTime   graphql.NullTime
+}) (*[]*ExchangeVolumeResolver, error) {
+	endtime := time.Now()
+	if args.EndTime.Value != nil {
+		endtime = args.EndTime.Value.Time
+	}
+	starttime := endtime.AddDate(0, 0, -1)
+	if args.StartTime.Value != nil {
+		starttime = args.StartTime.Value.Time
+	}
+	if !utils.ValidTimeRange(starttime, endtime, maxExchangeVolumesRange) {
+		return nil, fmt.Errorf("time range must not exceed %v", maxExchangeVolumesRange)
+	}
+
+	if len(args.Exchanges) == 0 {
+		return nil, errors.New("no exchange given")
+	}
+	if len(args.Exchanges) > maxExchangeVolumesExchanges {
+		return nil, fmt.Errorf("at most %d exchanges can be queried", maxExchangeVolumesExchanges)
+	}
+	exchangeNames, err := r.RelDB.GetExchangeNames()
+	if err != nil {
+		return nil, err
+	}
+	knownExchanges := make(map[string]struct{})
+	for _, name := range exchangeNames {
+		knownExchanges[name] = struct{}{}
+	}
+	var exchanges []string
+	for _, exchange := range args.Exchanges {
+		if _, ok := knownExchanges[*exchange.Value]; !ok {
+			return nil, fmt.Errorf("unknown exchange %s", *exchange.Value)
+		}
+		exchanges = append(exchanges, *exchange.Value)
+	}
+
+	var er []*ExchangeVolumeResolver
+	for _, exchange := range exchanges {
+		volume, err := r.DS.GetVolumeInflux(dia.Asset{}, exchange, starttime, endtime)
+		if err != nil {
+			log.Warnf("get volume of %s: %v", exchange, err)
+			continue
+		}
+		er = append(er, &ExchangeVolumeResolver{q: exchangeVolume{Exchange: exchange, Volume: *volume, StartTime: starttime, EndTime: endtime}})
+	}
+	return &er, nil
+}
+
+// FilterPoints subscribes to the points of a filter on an exchange for a list of assets.
+// The filter defaults to the king filter and the exchange to all exchanges.
+func (r *DiaResolver) FilterPoints(ctx context.Context, args struct {
+	Filter   graphql.NullString
+	Exchange graphql.NullString
+	Assets   []AssetInput
+}) (<-chan *FilterPointResolver, error) {
+	if r.Stream == nil {
+		return nil, errors.New("subscriptions are not available")
+	}
+	filter := dia.FilterKing
+	if args.Filter.Value != nil {
+		filter = *args.Filter.Value
+	}
+	var exchange string
+	if args.Exchange.Value != nil {
+		exchange = *args.Exchange.Value
+	}
+	var assets []dia.Asset
+	for _, assetInput := range args.Assets {
+		assets = append(assets, dia.Asset{Address: *assetInput.Address.Value, Blockchain: *assetInput.Blockchain.Value})
+	}
+
+	filterPoints, err := r.Stream.SubscribeFilterPoints(ctx, filter, exchange, assets)
+	if err != nil {
+		return nil, err
+	}
+	fpr := make(chan *FilterPointResolver)
+	go func() {
+		defer close(fpr)
+		for fp := range filterPoints {
+			select {
+			case fpr <- &FilterPointResolver{q: fp}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return fpr, nil
+}
@@ -0,0 +1,426 @@
+package apiKeyApi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/http/restApi"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/diadata-org/diadata/pkg/utils"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// HeaderAPIKey is the request header holding the API key.
+	HeaderAPIKey = "X-API-KEY"
+
+	// keyCacheTTL is the time keys are cached, i.e. the time until changes of keys take effect.
+	keyCacheTTL = time.Minute
+	// unknownKeyCacheTTL is the time unknown keys are rejected without asking the store.
+	unknownKeyCacheTTL = 10 * time.Second
+	// maxUnknownKeys bounds the memory used by unknown keys sent to the server.
+	maxUnknownKeys    = 10000
+	usageSaveInterval = time.Minute
+	maxUsageRange     = 366 * 24 * time.Hour
+)
+
+// Store persists API keys and counts their requests.
+type Store interface {
+	CreateAPIKey(apiKey dia.APIKey) (string, dia.APIKey, error)
+	UpdateAPIKey(apiKey dia.APIKey) error
+	GetAPIKey(key string) (dia.APIKey, error)
+	GetAPIKeyByID(id string) (dia.APIKey, error)
+	GetAPIKeys(owner string) ([]dia.APIKey, error)
+	IncrAPIKeyRate(id string, t time.Time) (int64, error)
+	IncrAPIKeyUsage(id string, t time.Time) (int64, error)
+	SaveAPIKeyUsage(id string, t time.Time) error
+	GetAPIKeyUsage(id string, starttime time.Time, endtime time.Time) ([]dia.APIKeyUsage, error)
+}
+
+type cachedKey struct {
+	apiKey  dia.APIKey
+	expires time.Time
+}
+
+// usageDay identifies the requests of a key on a day.
+type usageDay struct {
+	id  string
+	day string
+}
+
+// Manager authorizes requests by API keys within their scopes, rate limits and daily quotas, and meters
+// their usage. Counters are kept in redis such that limits hold across all instances of the server.
+type Manager struct {
+	store Store
+	// requireKey is true if requests to metered endpoints without API key are rejected.
+	requireKey bool
+
+	mu   sync.Mutex
+	keys map[string]cachedKey
+	// unknown maps hashes of unknown keys onto the time until which they are cached.
+	unknown map[string]time.Time
+	used    map[usageDay]time.Time
+}
+
+// NewManager returns a manager of the keys in @store. If @requireKey is false, requests
+// without API key are served unmetered.
+func NewManager(store Store, requireKey bool) *Manager {
+	return &Manager{
+		store:      store,
+		requireKey: requireKey,
+		keys:       make(map[string]cachedKey),
+		unknown:    make(map[string]time.Time),
+		used:       make(map[usageDay]time.Time),
+	}
+}
+
+// Run saves the usage counters of keys to the store until @ctx is done.
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(usageSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.saveUsage()
+		case <-ctx.Done():
+			m.saveUsage()
+			return
+		}
+	}
+}
+
+func (m *Manager) saveUsage() {
+	m.mu.Lock()
+	used := m.used
+	m.used = make(map[usageDay]time.Time)
+	m.mu.Unlock()
+
+	for u, t := range used {
+		err := m.store.SaveAPIKeyUsage(u.id, t)
+		if err != nil {
+			log.Errorf("save usage of api key %s: %v", u.id, err)
+		}
+	}
+}
+
+// Middleware returns a handler authorizing requests with API keys granting @scope.
+// Requests without API key are passed on unless keys are required.
+func (m *Manager) Middleware(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderAPIKey)
+		if key == "" {
+			if m.requireKey {
+				abort(c, http.StatusUnauthorized, errors.New("missing api key"))
+			}
+			return
+		}
+		m.authorize(c, key, scope)
+	}
+}
+
+// KeyOrElse returns a handler authorizing requests with API keys granting @scope.
+// Requests without API key are handled by @fallback, such as another authentication.
+func (m *Manager) KeyOrElse(scope string, fallback gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderAPIKey)
+		if key == "" {
+			fallback(c)
+			return
+		}
+		m.authorize(c, key, scope)
+	}
+}
+
+// authorize aborts @c unless @key is valid, grants @scope and is within its limits.
+func (m *Manager) authorize(c *gin.Context, key string, scope string) {
+	now := time.Now()
+	apiKey, err := m.getAPIKey(key, now)
+	if err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			abort(c, http.StatusUnauthorized, errors.New("invalid api key"))
+			return
+		}
+		log.Error("get api key: ", err)
+		abort(c, http.StatusInternalServerError, errors.New("api key could not be checked"))
+		return
+	}
+	if !apiKey.Valid(now) {
+		abort(c, http.StatusUnauthorized, errors.New("api key is inactive or expired"))
+		return
+	}
+	if !apiKey.HasScope(scope) {
+		abort(c, http.StatusForbidden, fmt.Errorf("api key lacks scope %s", scope))
+		return
+	}
+
+	// Counters are not available if redis is down. Read requests are served anyway,
+	// requests of scopes failing closed are rejected.
+	if apiKey.RateLimit > 0 {
+		count, err := m.store.IncrAPIKeyRate(apiKey.ID, now)
+		if err != nil {
+			log.Error("count api key rate: ", err)
+			if failsClosed(scope) {
+				abort(c, http.StatusServiceUnavailable, errors.New("api key limits could not be checked"))
+				return
+			}
+		} else if count > int64(apiKey.RateLimit) {
+			c.Header("Retry-After", strconv.Itoa(60-now.Second()))
+			abort(c, http.StatusTooManyRequests, fmt.Errorf("rate limit of %d requests per minute exceeded", apiKey.RateLimit))
+			return
+		}
+	}
+	count, err := m.store.IncrAPIKeyUsage(apiKey.ID, now)
+	if err != nil {
+		log.Error("count api key usage: ", err)
+		if failsClosed(scope) {
+			abort(c, http.StatusServiceUnavailable, errors.New("api key limits could not be checked"))
+			return
+		}
+	} else {
+		m.mu.Lock()
+		m.used[usageDay{id: apiKey.ID, day: now.UTC().Format("2006-01-02")}] = now
+		m.mu.Unlock()
+		if apiKey.DailyQuota > 0 {
+			if count > apiKey.DailyQuota {
+				abort(c, http.StatusTooManyRequests, fmt.Errorf("daily quota of %d requests exceeded", apiKey.DailyQuota))
+				return
+			}
+			c.Header("X-Quota-Remaining", strconv.FormatInt(apiKey.DailyQuota-count, 10))
+		}
+	}
+}
+
+// failsClosed returns true if requests of @scope are rejected if the limits of their key cannot be checked.
+func failsClosed(scope string) bool {
+	return scope == dia.ScopeWrite || scope == dia.ScopeKafka
+}
+
+// getAPIKey returns the properties of @key from the cache or the store.
+func (m *Manager) getAPIKey(key string, now time.Time) (dia.APIKey, error) {
+	hash := models.HashAPIKey(key)
+	m.mu.Lock()
+	cached, ok := m.keys[hash]
+	unknownUntil, unknown := m.unknown[hash]
+	m.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.apiKey, nil
+	}
+	if unknown && now.Before(unknownUntil) {
+		return dia.APIKey{}, models.ErrAPIKeyNotFound
+	}
+
+	apiKey, err := m.store.GetAPIKey(key)
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		m.cacheUnknown(hash, now)
+	}
+	if err != nil {
+		return apiKey, err
+	}
+	m.mu.Lock()
+	m.keys[hash] = cachedKey{apiKey: apiKey, expires: now.Add(keyCacheTTL)}
+	m.mu.Unlock()
+	return apiKey, nil
+}
+
+// cacheUnknown caches the unknown key with @hash, such that repeated requests with the key do not hit the store.
+func (m *Manager) cacheUnknown(hash string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.unknown) >= maxUnknownKeys {
+		for h, until := range m.unknown {
+			if !now.Before(until) {
+				delete(m.unknown, h)
+			}
+		}
+		if len(m.unknown) >= maxUnknownKeys {
+			return
+		}
+	}
+	m.unknown[hash] = now.Add(unknownKeyCacheTTL)
+}
+
+// uncache removes the key with @id from the cache, such that changes take effect immediately on this instance.
+func (m *Manager) uncache(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, cached := range m.keys {
+		if cached.apiKey.ID == id {
+			delete(m.keys, hash)
+		}
+	}
+}
+
+func abort(c *gin.Context, code int, err error) {
+	restApi.SendError(c, code, err)
+	c.Abort()
+}
+
+// AddRoutes adds the endpoints for owners of API keys to @group, usually /v1.
+func (m *Manager) AddRoutes(group *gin.RouterGroup) {
+	group.GET("/apiKeyUsage", m.GetOwnUsage)
+}
+
+// AddAdminRoutes adds the endpoints managing API keys to @group, which must only be accessible for admins.
+func (m *Manager) AddAdminRoutes(group *gin.RouterGroup) {
+	group.POST("/apiKeys", m.CreateAPIKey)
+	group.GET("/apiKeys", m.GetAPIKeys)
+	group.PUT("/apiKeys/:id", m.UpdateAPIKey)
+	group.GET("/apiKeys/:id/usage", m.GetUsage)
+}
+
+// CreateAPIKey creates a key with owner, scopes and limits given in the request body such as
+// '{"Owner":"partner","Scopes":["read","write"],"RateLimit":600,"DailyQuota":100000}'.
+// The key is only contained in this response.
+func (m *Manager) CreateAPIKey(c *gin.Context) {
+	var apiKey dia.APIKey
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, errors.New("ReadAll"))
+		return
+	}
+	err = json.Unmarshal(body, &apiKey)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	if apiKey.Owner == "" {
+		restApi.SendError(c, http.StatusBadRequest, errors.New("missing owner"))
+		return
+	}
+	if err = validate(apiKey); err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	key, stored, err := m.store.CreateAPIKey(apiKey)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	log.Infof("created api key %s for %s with scopes %v", stored.Prefix, stored.Owner, stored.Scopes)
+	c.JSON(http.StatusOK, gin.H{"Key": key, "APIKey": stored})
+}
+
+// GetAPIKeys returns all keys, or the keys of the owner given by the query parameter owner.
+func (m *Manager) GetAPIKeys(c *gin.Context) {
+	apiKeys, err := m.store.GetAPIKeys(c.Query("owner"))
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, apiKeys)
+}
+
+// UpdateAPIKey sets the scopes, limits, state or expiry given in the request body such as
+// '{"Active":false}'. Properties not given are left unchanged.
+func (m *Manager) UpdateAPIKey(c *gin.Context) {
+	id := c.Param("id")
+	apiKey, err := m.store.GetAPIKeyByID(id)
+	if err != nil {
+		sendKeyError(c, err)
+		return
+	}
+	owner := apiKey.Owner
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, errors.New("ReadAll"))
+		return
+	}
+	err = json.Unmarshal(body, &apiKey)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err = validate(apiKey); err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	apiKey.ID = id
+	apiKey.Owner = owner
+
+	err = m.store.UpdateAPIKey(apiKey)
+	if err != nil {
+		sendKeyError(c, err)
+		return
+	}
+	m.uncache(id)
+	c.JSON(http.StatusOK, apiKey)
+}
+
+// GetUsage returns the daily requests of the key with ID given in the path.
+// Query parameters starttime and endtime are unix timestamps and default to the last 30 days.
+func (m *Manager) GetUsage(c *gin.Context) {
+	m.sendUsage(c, c.Param("id"))
+}
+
+// GetOwnUsage returns the properties and daily requests of the key of the request.
+func (m *Manager) GetOwnUsage(c *gin.Context) {
+	key := c.GetHeader(HeaderAPIKey)
+	if key == "" {
+		restApi.SendError(c, http.StatusUnauthorized, errors.New("missing api key"))
+		return
+	}
+	apiKey, err := m.getAPIKey(key, time.Now())
+	if err != nil {
+		sendKeyError(c, err)
+		return
+	}
+	m.sendUsage(c, apiKey.ID)
+}
+
+func (m *Manager) sendUsage(c *gin.Context, id string) {
+	starttime, endtime, err := utils.MakeTimerange(c.Query("starttime"), c.Query("endtime"), 30*24*time.Hour)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	if !utils.ValidTimeRange(starttime, endtime, maxUsageRange) {
+		restApi.SendError(c, http.StatusBadRequest, fmt.Errorf("time range must not exceed %v", maxUsageRange))
+		return
+	}
+	apiKey, err := m.store.GetAPIKeyByID(id)
+	if err != nil {
+		sendKeyError(c, err)
+		return
+	}
+	usage, err := m.store.GetAPIKeyUsage(id, starttime, endtime)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"APIKey": apiKey, "Usage": usage})
+}
+
+func sendKeyError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		restApi.SendError(c, http.StatusNotFound, err)
+		return
+	}
+	restApi.SendError(c, http.StatusInternalServerError, err)
+}
+
+// validate returns an error if @apiKey has unknown scopes or negative limits.
+func validate(apiKey dia.APIKey) error {
+	for _, scope := range apiKey.Scopes {
+		switch scope {
+		case dia.ScopeRead, dia.ScopeWrite, dia.ScopeKafka:
+		default:
+			return fmt.Errorf("unknown scope %s", scope)
+		}
+	}
+	if apiKey.RateLimit < 0 || apiKey.DailyQuota < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
+
+var _ Store = (*models.RelDB)(nil)
@@ -0,0 +1,218 @@
+package apiKeyApi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeStore holds keys by their plain value and counts requests in memory.
+// If err is set, counting requests fails as if redis was down.
+type fakeStore struct {
+	keys  map[string]dia.APIKey
+	rate  map[string]int64
+	usage map[string]int64
+	err   error
+	// lookups counts the calls of GetAPIKey.
+	lookups int
+}
+
+func newFakeStore(keys map[string]dia.APIKey) *fakeStore {
+	return &fakeStore{
+		keys:  keys,
+		rate:  make(map[string]int64),
+		usage: make(map[string]int64),
+	}
+}
+
+func (s *fakeStore) CreateAPIKey(apiKey dia.APIKey) (string, dia.APIKey, error) {
+	return "", apiKey, errors.New("not implemented")
+}
+
+func (s *fakeStore) UpdateAPIKey(apiKey dia.APIKey) error {
+	return errors.New("not implemented")
+}
+
+func (s *fakeStore) GetAPIKey(key string) (dia.APIKey, error) {
+	s.lookups++
+	apiKey, ok := s.keys[key]
+	if !ok {
+		return dia.APIKey{}, models.ErrAPIKeyNotFound
+	}
+	return apiKey, nil
+}
+
+func (s *fakeStore) GetAPIKeyByID(id string) (dia.APIKey, error) {
+	for _, apiKey := range s.keys {
+		if apiKey.ID == id {
+			return apiKey, nil
+		}
+	}
+	return dia.APIKey{}, models.ErrAPIKeyNotFound
+}
+
+func (s *fakeStore) GetAPIKeys(owner string) ([]dia.APIKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeStore) IncrAPIKeyRate(id string, t time.Time) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.rate[id]++
+	return s.rate[id], nil
+}
+
+func (s *fakeStore) IncrAPIKeyUsage(id string, t time.Time) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.usage[id]++
+	return s.usage[id], nil
+}
+
+func (s *fakeStore) SaveAPIKeyUsage(id string, t time.Time) error {
+	return nil
+}
+
+func (s *fakeStore) GetAPIKeyUsage(id string, starttime time.Time, endtime time.Time) ([]dia.APIKeyUsage, error) {
+	return nil, nil
+}
+
+var _ Store = (*fakeStore)(nil)
+
+// serve sends a request with @key to an endpoint of @scope protected by @m and returns the response.
+func serve(m *Manager, scope string, key string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/endpoint", m.Middleware(scope), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/endpoint", nil)
+	if key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareKeys(t *testing.T) {
+	store := newFakeStore(map[string]dia.APIKey{
+		"reader":   {ID: "1", Scopes: []string{dia.ScopeRead}, Active: true},
+		"inactive": {ID: "2", Scopes: []string{dia.ScopeRead}, Active: false},
+		"expired":  {ID: "3", Scopes: []string{dia.ScopeRead}, Active: true, ValidUntil: time.Now().Add(-time.Hour)},
+	})
+
+	cases := []struct {
+		name       string
+		requireKey bool
+		scope      string
+		key        string
+		code       int
+	}{
+		{"no key", false, dia.ScopeRead, "", http.StatusOK},
+		{"no key required", true, dia.ScopeRead, "", http.StatusUnauthorized},
+		{"unknown key", false, dia.ScopeRead, "unknown", http.StatusUnauthorized},
+		{"inactive key", false, dia.ScopeRead, "inactive", http.StatusUnauthorized},
+		{"expired key", false, dia.ScopeRead, "expired", http.StatusUnauthorized},
+		{"valid key", true, dia.ScopeRead, "reader", http.StatusOK},
+		{"missing scope", false, dia.ScopeWrite, "reader", http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		w := serve(NewManager(store, tc.requireKey), tc.scope, tc.key)
+		if w.Code != tc.code {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.code, w.Code)
+		}
+	}
+}
+
+func TestGetAPIKeyUnknown(t *testing.T) {
+	store := newFakeStore(map[string]dia.APIKey{})
+	m := NewManager(store, false)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if _, err := m.getAPIKey("unknown", now); !errors.Is(err, models.ErrAPIKeyNotFound) {
+			t.Fatalf("expected ErrAPIKeyNotFound, got %v", err)
+		}
+	}
+	if store.lookups != 1 {
+		t.Errorf("expected 1 lookup of unknown key, got %d", store.lookups)
+	}
+
+	// Keys created in the meantime are found once the entry expired.
+	store.keys["unknown"] = dia.APIKey{ID: "1", Active: true}
+	apiKey, err := m.getAPIKey("unknown", now.Add(unknownKeyCacheTTL))
+	if err != nil || apiKey.ID != "1" {
+		t.Errorf("expected key 1 after expiry, got %v, %v", apiKey, err)
+	}
+}
+
+func TestMiddlewareRateLimit(t *testing.T) {
+	store := newFakeStore(map[string]dia.APIKey{
+		"key": {ID: "1", Scopes: []string{dia.ScopeRead}, RateLimit: 2, Active: true},
+	})
+	m := NewManager(store, true)
+	for i, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		w := serve(m, dia.ScopeRead, "key")
+		if w.Code != code {
+			t.Errorf("request %d: expected status %d, got %d", i, code, w.Code)
+		}
+		if code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Errorf("request %d: missing Retry-After header", i)
+		}
+	}
+}
+
+func TestMiddlewareDailyQuota(t *testing.T) {
+	store := newFakeStore(map[string]dia.APIKey{
+		"key": {ID: "1", Scopes: []string{dia.ScopeRead}, DailyQuota: 2, Active: true},
+	})
+	m := NewManager(store, true)
+
+	w := serve(m, dia.ScopeRead, "key")
+	if w.Code != http.StatusOK || w.Header().Get("X-Quota-Remaining") != "1" {
+		t.Errorf("expected status 200 with 1 remaining request, got %d with %q", w.Code, w.Header().Get("X-Quota-Remaining"))
+	}
+	w = serve(m, dia.ScopeRead, "key")
+	if w.Code != http.StatusOK || w.Header().Get("X-Quota-Remaining") != "0" {
+		t.Errorf("expected status 200 with 0 remaining requests, got %d with %q", w.Code, w.Header().Get("X-Quota-Remaining"))
+	}
+	w = serve(m, dia.ScopeRead, "key")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after the quota, got %d", w.Code)
+	}
+}
+
+func TestMiddlewareCountersUnavailable(t *testing.T) {
+	scopes := []string{dia.ScopeRead, dia.ScopeWrite, dia.ScopeKafka}
+	for _, limits := range []dia.APIKey{{RateLimit: 10}, {DailyQuota: 10}, {}} {
+		apiKey := limits
+		apiKey.ID = "1"
+		apiKey.Scopes = scopes
+		apiKey.Active = true
+		store := newFakeStore(map[string]dia.APIKey{"key": apiKey})
+		store.err = errors.New("redis down")
+		m := NewManager(store, true)
+
+		expected := map[string]int{
+			dia.ScopeRead:  http.StatusOK,
+			dia.ScopeWrite: http.StatusServiceUnavailable,
+			dia.ScopeKafka: http.StatusServiceUnavailable,
+		}
+		for _, scope := range scopes {
+			w := serve(m, scope, "key")
+			if w.Code != expected[scope] {
+				t.Errorf("scope %s with rate limit %d and quota %d: expected status %d, got %d",
+					scope, apiKey.RateLimit, apiKey.DailyQuota, expected[scope], w.Code)
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	filters "github.com/diadata-org/diadata/internal/pkg/filtersBlockService"
@@ -25,6 +26,20 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// maxBatchQuotationAssets is the maximal number of assets in a batch quotation request.
+	maxBatchQuotationAssets = 200
+	// batchQuotationWorkers is the number of assets of a batch quotation request looked up concurrently.
+	batchQuotationWorkers = 16
+	// maxCandles is the maximal number of candles in a candles request.
+	maxCandles = 1000
+	// defaultCandles is the number of candles returned if no time range is given.
+	defaultCandles = 100
+	// maxCandleGaps is the maximal number of gaps in stored candles computed from trades separately.
+	// Requests with more gaps are computed from trades as a whole.
+	maxCandleGaps = 10
+)
+
 var (
 	DECIMALS_CACHE = make(map[dia.Asset]uint8)
 	ASSET_CACHE    = make(map[string]dia.Asset)
@@ -36,19 +51,16 @@ type Env struct {
 	RelDB     models.RelDB
 }
 
-func init() {
-	relDB, err := models.NewRelDataStore()
-	if err != nil {
-		log.Errorln("Error connecting to asset DB: ", err)
-		return
-	}
+// LoadBlockchains fills BLOCKCHAINS with all blockchains from @relDB.
+func LoadBlockchains(relDB *models.RelDB) error {
 	chains, err := relDB.GetAllBlockchains(false)
 	if err != nil {
-		log.Fatal("get all chains: ", err)
+		return err
 	}
 	for _, chain := range chains {
 		BLOCKCHAINS[chain.Name] = chain
 	}
+	return nil
 }
 
 // PostSupply deprecated? TO DO
@@ -159,7 +171,15 @@ func (env *Env) GetAssetQuotation(c *gin.Context) {
 		restApi.SendError(c, http.StatusNotFound, err)
 		return
 	}
+	quotationExtended = env.extendAssetQuotation(*quotation, timestamp)
+
+	c.JSON(http.StatusOK, quotationExtended)
+
+}
 
+// extendAssetQuotation adds price and volume of the day before @timestamp to @quotation.
+func (env *Env) extendAssetQuotation(quotation models.AssetQuotation, timestamp time.Time) (quotationExtended models.AssetQuotationFull) {
+	asset := quotation.Asset
 	quotationYesterday, err := env.DataStore.GetAssetQuotation(asset, timestamp.AddDate(0, 0, -1))
 	if err != nil {
 		log.Warn("get quotation yesterday: ", err)
@@ -181,9 +201,224 @@ func (env *Env) GetAssetQuotation(c *gin.Context) {
 	quotationExtended.Price = quotation.Price
 	quotationExtended.Time = quotation.Time
 	quotationExtended.Source = quotation.Source
+	return
+}
 
-	c.JSON(http.StatusOK, quotationExtended)
+// GetAssetQuotationsBatch returns quotations, market caps and 24h volumes of a list of assets.
+// Input must be of the format:
+// '[{"Blockchain":"Ethereum","Address":"0x..."},...]'
+// Assets without quotation are reported individually in the Error field of their item.
+func (env *Env) GetAssetQuotationsBatch(c *gin.Context) {
+	var input []struct {
+		Blockchain string `json:"Blockchain"`
+		Address    string `json:"Address"`
+	}
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, errors.New("ReadAll"))
+		return
+	}
+	err = json.Unmarshal(body, &input)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	if len(input) == 0 {
+		restApi.SendError(c, http.StatusBadRequest, errors.New("no assets given"))
+		return
+	}
+	if len(input) > maxBatchQuotationAssets {
+		restApi.SendError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("at most %d assets can be requested", maxBatchQuotationAssets))
+		return
+	}
+
+	items := make([]models.AssetQuotationBatchItem, len(input))
+	for i, in := range input {
+		items[i].Blockchain = in.Blockchain
+		items[i].Address = in.Address
+	}
+	env.fillAssetQuotationsBatch(env.RelDB.GetAsset, items, time.Now())
+
+	c.JSON(http.StatusOK, items)
+}
+
+// fillAssetQuotationsBatch adds quotation and market cap to the @items given by blockchain and address,
+// or sets their Error field. Assets are obtained from @getAsset. Items are processed concurrently by
+// batchQuotationWorkers workers.
+func (env *Env) fillAssetQuotationsBatch(getAsset func(address string, blockchain string) (dia.Asset, error), items []models.AssetQuotationBatchItem, timestamp time.Time) {
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < batchQuotationWorkers && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				env.fillAssetQuotationBatchItem(getAsset, &items[i], timestamp)
+			}
+		}()
+	}
+	for i := range items {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
 
+// fillAssetQuotationBatchItem adds quotation and market cap to @item or sets its Error field.
+func (env *Env) fillAssetQuotationBatchItem(getAsset func(address string, blockchain string) (dia.Asset, error), item *models.AssetQuotationBatchItem, timestamp time.Time) {
+	if item.Blockchain == "" || item.Address == "" || containsSpecialChars(item.Blockchain) || containsSpecialChars(item.Address) {
+		item.Error = "invalid blockchain or address"
+		return
+	}
+
+	asset, err := getAsset(makeAddressEIP55Compliant(item.Address, item.Blockchain), item.Blockchain)
+	if err != nil {
+		item.Error = "asset not found"
+		return
+	}
+	quotation, err := env.DataStore.GetAssetQuotationLatest(asset)
+	if err != nil {
+		item.Error = "no quotation available"
+		return
+	}
+	// Cached quotations do not necessarily carry the full asset.
+	quotation.Asset = asset
+	quotationExtended := env.extendAssetQuotation(*quotation, timestamp)
+	item.Quotation = &quotationExtended
+
+	supply, err := env.DataStore.GetSupplyCache(asset)
+	if err != nil {
+		log.Warnf("get supply of %s on %s: %v", asset.Address, asset.Blockchain, err)
+		return
+	}
+	marketCap := quotation.Price * supply.CirculatingSupply
+	item.CirculatingSupply = &supply.CirculatingSupply
+	item.MarketCap = &marketCap
+}
+
+// GetCandles returns OHLCV candles of an asset computed from its trades.
+// Query parameters:
+// resolution: one of 1m, 5m, 1h, 1d. Defaults to 1h.
+// starttime, endtime: unix timestamps. Default to the last 100 candles.
+// exchange: restricts trades to the exchange. Can be repeated.
+// baseAsset: restricts trades to the base asset given as blockchain:address. Can be repeated.
+// price: usd (default) or native, where native prices are in terms of the only base asset.
+func (env *Env) GetCandles(c *gin.Context) {
+	if !validateInputParams(c) {
+		return
+	}
+
+	blockchain := c.Param("blockchain")
+	address := makeAddressEIP55Compliant(c.Param("address"), blockchain)
+	asset := dia.Asset{Address: address, Blockchain: blockchain}
+
+	resolution := c.DefaultQuery("resolution", "1h")
+	duration, err := models.CandleResolution(resolution)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	starttime, endtime, err := utils.MakeTimerange(c.Query("starttime"), c.Query("endtime"), defaultCandles*duration)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	if !utils.ValidTimeRange(starttime, endtime, maxCandles*duration) {
+		restApi.SendError(c, http.StatusBadRequest, fmt.Errorf("time range exceeds %d candles", maxCandles))
+		return
+	}
+
+	exchanges := c.QueryArray("exchange")
+	var baseassets []dia.Asset
+	for _, baseAsset := range c.QueryArray("baseAsset") {
+		parts := strings.Split(baseAsset, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			restApi.SendError(c, http.StatusBadRequest, errors.New("base assets must be given as blockchain:address"))
+			return
+		}
+		baseassets = append(baseassets, dia.Asset{Blockchain: parts[0], Address: makeAddressEIP55Compliant(parts[1], parts[0])})
+	}
+	var nativePrice bool
+	switch c.DefaultQuery("price", "usd") {
+	case "usd":
+	case "native":
+		nativePrice = true
+	default:
+		restApi.SendError(c, http.StatusBadRequest, errors.New("price must be usd or native"))
+		return
+	}
+
+	// Stored candles only exist in USD across all base assets, for all exchanges and for single exchanges.
+	if nativePrice || len(baseassets) > 0 || len(exchanges) > 1 {
+		candles, err := env.DataStore.GetCandles(asset, baseassets, exchanges, resolution, nativePrice, starttime, endtime)
+		if err != nil {
+			restApi.SendError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, candles)
+		return
+	}
+	candles, err := env.getCandlesStored(asset, exchanges, resolution, duration, starttime, endtime)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, candles)
+}
+
+// getCandlesStored returns the candles of @asset in [@starttime, @endtime) from stored candles if available.
+// Time ranges not covered by stored candles, such as candles which failed to be stored, are computed from trades.
+func (env *Env) getCandlesStored(asset dia.Asset, exchanges []string, resolution string, duration time.Duration, starttime time.Time, endtime time.Time) ([]dia.Candle, error) {
+	var exchange string
+	if len(exchanges) == 1 {
+		exchange = exchanges[0]
+	}
+	stored, err := env.DataStore.GetStoredCandles(asset, exchange, resolution, starttime, endtime)
+	if err != nil {
+		log.Warn("get stored candles: ", err)
+	}
+	if len(stored) == 0 || countCandleGaps(stored, duration, starttime, endtime) > maxCandleGaps {
+		return env.DataStore.GetCandles(asset, nil, exchanges, resolution, false, starttime, endtime)
+	}
+
+	// Candles are aligned to multiples of their resolution, so gaps are computed from the start of their candle.
+	var candles []dia.Candle
+	cursor := starttime
+	for _, candle := range stored {
+		if candle.Time.After(cursor) {
+			gap, err := env.DataStore.GetCandles(asset, nil, exchanges, resolution, false, cursor, candle.Time)
+			if err != nil {
+				return candles, err
+			}
+			candles = append(candles, gap...)
+		}
+		candles = append(candles, candle)
+		cursor = candle.Time.Add(duration)
+	}
+	if cursor.Before(endtime) {
+		gap, err := env.DataStore.GetCandles(asset, nil, exchanges, resolution, false, cursor, endtime)
+		if err != nil {
+			return candles, err
+		}
+		candles = append(candles, gap...)
+	}
+	return candles, nil
+}
+
+// countCandleGaps returns the number of time ranges in [@starttime, @endtime) not covered by @stored,
+// which are sorted in ascending order.
+func countCandleGaps(stored []dia.Candle, duration time.Duration, starttime time.Time, endtime time.Time) (gaps int) {
+	cursor := starttime
+	for _, candle := range stored {
+		if candle.Time.After(cursor) {
+			gaps++
+		}
+		cursor = candle.Time.Add(duration)
+	}
+	if cursor.Before(endtime) {
+		gaps++
+	}
+	return
 }
 
 // GetQuotation returns quotation of asset with highest market cap among
@@ -584,6 +819,38 @@ func (env *Env) GetAssetChartPoints(c *gin.Context) {
 	}
 }
 
+// GetRejectedSources returns the filter points of the asset given by address and blockchain at which
+// @filter rejected sources as outliers. The optional query parameter exchange restricts to the filter on a single exchange.
+func (env *Env) GetRejectedSources(c *gin.Context) {
+	if !validateInputParams(c) {
+		return
+	}
+
+	filter := c.Param("filter")
+	blockchain := c.Param("blockchain")
+	address := makeAddressEIP55Compliant(c.Param("address"), blockchain)
+
+	exchange := c.Query("exchange")
+
+	starttime, endtime, err := utils.MakeTimerange(c.Query("starttime"), c.Query("endtime"), time.Duration(24*time.Hour))
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, fmt.Errorf("parse time range"))
+		return
+	}
+
+	if ok := utils.ValidTimeRange(starttime, endtime, time.Duration(7*24*time.Hour)); !ok {
+		restApi.SendError(c, http.StatusInternalServerError, fmt.Errorf("time-range too big. max duration is %v", 7*24*time.Hour))
+		return
+	}
+
+	filterPoints, err := env.DataStore.GetRejectedSources(filter, dia.Asset{Address: address, Blockchain: blockchain}, exchange, starttime, endtime)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+	} else {
+		c.JSON(http.StatusOK, filterPoints)
+	}
+}
+
 // GetChartPoints returns Filter points for given symbol -> Deprecated?
 func (env *Env) GetChartPoints(c *gin.Context) {
 	if !validateInputParams(c) {
@@ -1952,6 +2219,32 @@ func (env *Env) GetAllBlockchains(c *gin.Context) {
 	}
 }
 
+// GetAssetBridges returns the bridging table used by the tradesBlockService for pricing base tokens of trades.
+// Optionally, the bridges can be restricted to a source blockchain by the query parameter blockchain.
+func (env *Env) GetAssetBridges(c *gin.Context) {
+	bridges, err := env.DataStore.GetActiveAssetBridges()
+	if errors.Is(err, redis.Nil) {
+		// No tradesBlockService has stored its table yet. Use the table it would load from postgres.
+		var table *dia.AssetBridges
+		table, err = models.LoadAssetBridges(&env.RelDB)
+		if err == nil {
+			bridges = table.List()
+		}
+	}
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	blockchain := c.Query("blockchain")
+	response := []dia.AssetBridge{}
+	for _, bridge := range bridges {
+		if blockchain == "" || bridge.Source.Blockchain == blockchain {
+			response = append(response, bridge)
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // -----------------------------------------------------------------------------
 // NFT
 // -----------------------------------------------------------------------------
@@ -0,0 +1,90 @@
+package diaApi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+)
+
+func TestFillAssetQuotationsBatch(t *testing.T) {
+	quoted := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	unquoted := dia.Asset{Symbol: "XYZ", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000001"}
+
+	relDB := models.NewMemoryRelDataStore()
+	ds := models.NewMemoryDataStore()
+	for _, asset := range []dia.Asset{quoted, unquoted} {
+		if err := relDB.SetAsset(asset); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ds.SetAssetPriceUSD(quoted, 1000, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.SetSupply(&dia.Supply{Asset: quoted, CirculatingSupply: 10, Time: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []models.AssetQuotationBatchItem{
+		{Blockchain: quoted.Blockchain, Address: quoted.Address},
+		{Blockchain: unquoted.Blockchain, Address: unquoted.Address},
+		{Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000002"},
+		{Blockchain: dia.ETHEREUM, Address: ""},
+	}
+	env := &Env{DataStore: ds}
+	env.fillAssetQuotationsBatch(relDB.GetAsset, items, time.Now())
+
+	if items[0].Error != "" || items[0].Quotation == nil || items[0].Quotation.Price != 1000 {
+		t.Errorf("expected quotation of 1000 for %s, got %+v", quoted.Symbol, items[0])
+	}
+	if items[0].MarketCap == nil || *items[0].MarketCap != 10000 {
+		t.Errorf("expected market cap of 10000 for %s, got %v", quoted.Symbol, items[0].MarketCap)
+	}
+	for i, expected := range []string{"no quotation available", "asset not found", "invalid blockchain or address"} {
+		item := items[i+1]
+		if item.Error != expected || item.Quotation != nil {
+			t.Errorf("expected error %q for item %d, got %+v", expected, i+1, item)
+		}
+	}
+}
+
+func TestGetCandlesStored(t *testing.T) {
+	eth := dia.Asset{Symbol: "ETH", Blockchain: dia.ETHEREUM, Address: "0x0000000000000000000000000000000000000000"}
+	starttime := time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	ds := models.NewMemoryDataStore()
+	for i := 0; i < 5; i++ {
+		trade := dia.Trade{
+			QuoteToken:        eth,
+			Price:             float64(1800 + i),
+			EstimatedUSDPrice: float64(1800 + i),
+			Volume:            1,
+			Source:            dia.BinanceExchange,
+			Time:              starttime.Add(time.Duration(i) * time.Minute),
+		}
+		if err := ds.SaveTradeInflux(&trade); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The candles of minute 1 and 2 are missing in the stored candles, as are the candles at both ends.
+	for _, minute := range []int{0, 3} {
+		candle := dia.Candle{Asset: eth, Resolution: "1m", Time: starttime.Add(time.Duration(minute) * time.Minute), Close: -1}
+		if err := ds.SaveCandleInflux(candle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	env := &Env{DataStore: ds}
+	candles, err := env.getCandlesStored(eth, nil, "1m", time.Minute, starttime.Add(-time.Minute), starttime.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 5 {
+		t.Fatalf("expected 5 candles, got %v", candles)
+	}
+	for i, expected := range []float64{-1, 1801, 1802, -1, 1804} {
+		if !candles[i].Time.Equal(starttime.Add(time.Duration(i)*time.Minute)) || candles[i].Close != expected {
+			t.Errorf("expected candle at minute %d with close %v, got %+v", i, expected, candles[i])
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package nodeApi
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/http/restApi"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/diadata-org/diadata/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Env serves the subset of the REST API available on a self-contained price node, i.e. quotations,
+// trades and filter values of the assets processed by the node, as well as its exchanges and blockchains.
+// Routes and responses are as in diaApi. In contrast to diaApi.Env, it works with any Datastore and RelDatastore.
+type Env struct {
+	DataStore models.Datastore
+	RelDB     models.RelDatastore
+}
+
+// AddRoutes adds all endpoints to @group, usually /v1.
+func (env *Env) AddRoutes(group *gin.RouterGroup) {
+	group.GET("/quotation/:symbol", env.GetQuotation)
+	group.GET("/assetQuotation/:blockchain/:address", env.GetAssetQuotation)
+	group.GET("/lastTradesAsset/:blockchain/:address", env.GetLastTradesAsset)
+	group.GET("/assetChartPoints/:filter/:blockchain/:address", env.GetAssetChartPoints)
+	group.GET("/exchanges", env.GetExchanges)
+	group.GET("/blockchains", env.GetAllBlockchains)
+}
+
+// GetAssetQuotation returns the latest quotation of the asset with @address on @blockchain.
+func (env *Env) GetAssetQuotation(c *gin.Context) {
+	blockchain := c.Param("blockchain")
+	asset, err := env.RelDB.GetAsset(env.normalizeAddress(c.Param("address"), blockchain), blockchain)
+	if err != nil {
+		restApi.SendError(c, http.StatusNotFound, err)
+		return
+	}
+	env.sendQuotation(c, asset)
+}
+
+// GetQuotation returns the latest quotation of the asset with the largest volume among all assets with @symbol.
+func (env *Env) GetQuotation(c *gin.Context) {
+	assets, err := env.RelDB.GetAssetsBySymbolName(c.Param("symbol"), "")
+	if err != nil || len(assets) == 0 {
+		restApi.SendError(c, http.StatusNotFound, errors.New("no quotation available"))
+		return
+	}
+	topAsset := assets[0]
+	var topVolume float64
+	for _, asset := range assets {
+		volume, err := env.RelDB.GetLastAssetVolume24H(asset)
+		if err == nil && volume > topVolume {
+			topAsset = asset
+			topVolume = volume
+		}
+	}
+	env.sendQuotation(c, topAsset)
+}
+
+func (env *Env) sendQuotation(c *gin.Context, asset dia.Asset) {
+	timestamp := time.Now()
+	quotation, err := env.DataStore.GetAssetQuotation(asset, timestamp)
+	if err != nil {
+		restApi.SendError(c, http.StatusNotFound, errors.New("no quotation available"))
+		return
+	}
+
+	var quotationExtended models.AssetQuotationFull
+	quotationYesterday, err := env.DataStore.GetAssetQuotation(asset, timestamp.AddDate(0, 0, -1))
+	if err != nil {
+		log.Warn("get quotation yesterday: ", err)
+	} else {
+		quotationExtended.PriceYesterday = quotationYesterday.Price
+	}
+	volumeYesterday, err := env.DataStore.Get24HoursAssetVolume(asset)
+	if err != nil {
+		log.Warn("get volume yesterday: ", err)
+	} else {
+		quotationExtended.VolumeYesterdayUSD = *volumeYesterday
+	}
+
+	quotationExtended.Symbol = quotation.Asset.Symbol
+	quotationExtended.Name = quotation.Asset.Name
+	quotationExtended.Address = quotation.Asset.Address
+	quotationExtended.Blockchain = quotation.Asset.Blockchain
+	quotationExtended.Price = quotation.Price
+	quotationExtended.Time = quotation.Time
+	quotationExtended.Source = quotation.Source
+	c.JSON(http.StatusOK, quotationExtended)
+}
+
+// GetLastTradesAsset returns the last trades of the asset with @address on @blockchain.
+// Query parameters are numTrades, at most 5000, and exchange.
+func (env *Env) GetLastTradesAsset(c *gin.Context) {
+	blockchain := c.Param("blockchain")
+	numTrades, err := strconv.Atoi(c.DefaultQuery("numTrades", "1000"))
+	if err != nil {
+		numTrades = 1000
+	}
+	if numTrades > 5000 {
+		numTrades = 5000
+	}
+
+	asset, err := env.RelDB.GetAsset(env.normalizeAddress(c.Param("address"), blockchain), blockchain)
+	if err != nil {
+		restApi.SendError(c, http.StatusNotFound, err)
+		return
+	}
+	trades, err := env.DataStore.GetLastTrades(asset, c.Query("exchange"), time.Now(), numTrades, true)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, trades)
+}
+
+// GetAssetChartPoints returns the values of @filter for the asset with @address on @blockchain.
+// Query parameters are starttime and endtime, at most 14 days apart, and exchange.
+func (env *Env) GetAssetChartPoints(c *gin.Context) {
+	blockchain := c.Param("blockchain")
+	address := env.normalizeAddress(c.Param("address"), blockchain)
+
+	starttime, endtime, err := utils.MakeTimerange(c.Query("starttime"), c.Query("endtime"), 7*24*time.Hour)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, errors.New("parse time range"))
+		return
+	}
+	if ok := utils.ValidTimeRange(starttime, endtime, 14*24*time.Hour); !ok {
+		restApi.SendError(c, http.StatusInternalServerError, errors.New("time-range too big. max duration is 336h0m0s"))
+		return
+	}
+
+	points, err := env.DataStore.GetFilterPointsAsset(c.Param("filter"), c.Query("exchange"), address, blockchain, starttime, endtime)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, points)
+}
+
+// GetExchanges returns all exchanges sorted by 24h volume.
+func (env *Env) GetExchanges(c *gin.Context) {
+	type exchangeReturn struct {
+		Name          string
+		Volume24h     float64
+		Trades        int64
+		Pairs         int
+		Type          string
+		Blockchain    string
+		ScraperActive bool
+	}
+	exchanges, err := env.RelDB.GetAllExchanges()
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	exchangereturns := []exchangeReturn{}
+	for _, exchange := range exchanges {
+		exchangereturn := exchangeReturn{
+			Name:          exchange.Name,
+			Type:          models.GetExchangeType(exchange),
+			Blockchain:    exchange.BlockChain.Name,
+			ScraperActive: exchange.ScraperActive,
+		}
+		// Statistics are missing for exchanges without recent trades.
+		if volume, err := env.DataStore.Get24HoursExchangeVolume(exchange.Name); err == nil {
+			exchangereturn.Volume24h = *volume
+		}
+		if numTrades, err := env.DataStore.GetNumTradesExchange24H(exchange.Name); err == nil {
+			exchangereturn.Trades = numTrades
+		}
+		if numPairs, err := env.RelDB.GetNumPairs(exchange); err == nil {
+			exchangereturn.Pairs = numPairs
+		}
+		exchangereturns = append(exchangereturns, exchangereturn)
+	}
+	sort.Slice(exchangereturns, func(i, j int) bool {
+		return exchangereturns[i].Volume24h > exchangereturns[j].Volume24h
+	})
+	c.JSON(http.StatusOK, exchangereturns)
+}
+
+// GetAllBlockchains returns the names of all blockchains.
+func (env *Env) GetAllBlockchains(c *gin.Context) {
+	blockchains, err := env.RelDB.GetAllBlockchains(false)
+	if err != nil {
+		restApi.SendError(c, http.StatusInternalServerError, err)
+		return
+	}
+	names := []string{}
+	for _, blockchain := range blockchains {
+		names = append(names, blockchain.Name)
+	}
+	c.JSON(http.StatusOK, names)
+}
+
+// normalizeAddress returns @address in checksum format for EVM chains.
+func (env *Env) normalizeAddress(address string, blockchain string) string {
+	chain, err := env.RelDB.GetBlockchain(blockchain)
+	if err == nil && strings.Contains(chain.ChainID, "Ethereum") {
+		return common.HexToAddress(address).Hex()
+	}
+	return address
+}
@@ -0,0 +1,340 @@
+package streamApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/kafkaHelper"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/messageBus"
+	"github.com/diadata-org/diadata/pkg/http/restApi"
+	models "github.com/diadata-org/diadata/pkg/model"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	EventQuotation   = "quotation"
+	EventFilterPoint = "filterPoint"
+
+	// maxSubscriptions is the maximal number of assets and filters a client can subscribe to.
+	maxSubscriptions = 100
+	// eventBufferSize is the number of events buffered per client. Events are dropped for clients
+	// which do not keep up, so that slow clients do not delay others.
+	eventBufferSize   = 256
+	keepAliveInterval = 30 * time.Second
+	writeTimeout      = 10 * time.Second
+	// lastTimesTTL is the time after which a filter not contained in any filtersBlock is forgotten.
+	lastTimesTTL = time.Hour
+	// pruneInterval is the minimal time between two prunings of forgotten filters.
+	pruneInterval = time.Minute
+)
+
+// Event is sent to subscribed clients for each new quotation of an asset or point of a filter.
+type Event struct {
+	Type        string                 `json:"Type"`
+	Quotation   *models.AssetQuotation `json:"Quotation,omitempty"`
+	FilterPoint *dia.FilterPoint       `json:"FilterPoint,omitempty"`
+}
+
+// filterKey identifies a filter on an exchange for an asset. An empty exchange denotes the filter across all exchanges.
+type filterKey struct {
+	filter   string
+	exchange string
+	asset    string
+}
+
+// lastPoint is the time of the last point forwarded for a filter and the time the filter was last seen in a filtersBlock.
+type lastPoint struct {
+	time time.Time
+	seen time.Time
+}
+
+type subscriber struct {
+	assets  map[string]struct{}
+	filters map[filterKey]struct{}
+	events  chan Event
+}
+
+// Hub forwards the quotations and filter points of filtersBlocks to clients subscribed over
+// WebSocket or Server-Sent Events. Quotations are given by the king filter across all exchanges.
+// Further filters and filters per exchange are available if the filtersblock service publishes them.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	// lastTimes holds the time of the last point forwarded per filter, so that unchanged points
+	// repeated in subsequent filtersBlocks are not sent again. Filters not seen for lastTimesTTL are removed.
+	lastTimes map[filterKey]lastPoint
+	lastPrune time.Time
+	upgrader  websocket.Upgrader
+}
+
+// NewHub returns a hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*subscriber]struct{}),
+		lastTimes:   make(map[filterKey]lastPoint),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// AddRoutes adds the streaming endpoints to @group, usually /v1.
+func (h *Hub) AddRoutes(group *gin.RouterGroup) {
+	group.GET("/stream/sse", h.ServeSSE)
+	group.GET("/stream/ws", h.ServeWebSocket)
+}
+
+// Run forwards the filtersBlocks received on @sub until @ctx is done or @sub is closed.
+func (h *Hub) Run(ctx context.Context, sub messageBus.Subscription) {
+	for {
+		m, err := sub.Next(ctx)
+		if err != nil {
+			if errors.Is(err, messageBus.ErrClosed) || ctx.Err() != nil {
+				return
+			}
+			log.Error("stream: next filtersBlock: ", err)
+			continue
+		}
+		var fb dia.FiltersBlock
+		err = kafkaHelper.UnmarshalMessage(m.Value, &fb)
+		if err != nil {
+			log.Errorf("stream: ignored filtersBlock at offset %d: %v", m.Offset, err)
+			continue
+		}
+		h.Publish(&fb)
+	}
+}
+
+// Publish sends the filter points of @fb to all subscribed clients.
+func (h *Hub) Publish(fb *dia.FiltersBlock) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if now.Sub(h.lastPrune) >= pruneInterval {
+		h.prune(now)
+	}
+	for i := range fb.FiltersBlockData.FilterPoints {
+		fp := fb.FiltersBlockData.FilterPoints[i]
+		key := filterKey{filter: fp.Name, exchange: fp.Source, asset: assetKey(fp.Asset.Blockchain, fp.Asset.Address)}
+		last := h.lastTimes[key]
+		if !fp.Time.After(last.time) {
+			h.lastTimes[key] = lastPoint{time: last.time, seen: now}
+			continue
+		}
+		h.lastTimes[key] = lastPoint{time: fp.Time, seen: now}
+
+		var quotation *models.AssetQuotation
+		if fp.Name == dia.FilterKing && fp.Source == "" {
+			quotation = &models.AssetQuotation{Asset: fp.Asset, Price: fp.Value, Source: dia.Diadata, Time: fp.Time}
+		}
+		for s := range h.subscribers {
+			if _, ok := s.filters[key]; ok {
+				s.send(Event{Type: EventFilterPoint, FilterPoint: &fp})
+			}
+			if _, ok := s.assets[key.asset]; ok && quotation != nil {
+				s.send(Event{Type: EventQuotation, Quotation: quotation})
+			}
+		}
+	}
+}
+
+// prune removes the filters not seen in a filtersBlock for lastTimesTTL before @now.
+// The caller must hold the write lock.
+func (h *Hub) prune(now time.Time) {
+	for key, last := range h.lastTimes {
+		if now.Sub(last.seen) >= lastTimesTTL {
+			delete(h.lastTimes, key)
+		}
+	}
+	h.lastPrune = now
+}
+
+func (s *subscriber) send(event Event) {
+	select {
+	case s.events <- event:
+	default:
+		log.Warn("stream: dropped event for slow client")
+	}
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{
+		assets:  make(map[string]struct{}),
+		filters: make(map[filterKey]struct{}),
+		events:  make(chan Event, eventBufferSize),
+	}
+}
+
+// subscribe registers a client with the subscriptions given by the query parameters of @c.
+// Assets are given as asset=blockchain:address and filters as filter=name:exchange:blockchain:address,
+// where the exchange is left empty for filters across all exchanges. Both parameters can be repeated.
+func (h *Hub) subscribe(c *gin.Context) (*subscriber, error) {
+	s := newSubscriber()
+	for _, asset := range c.QueryArray("asset") {
+		parts := strings.Split(asset, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid asset %s, expected blockchain:address", asset)
+		}
+		s.assets[assetKey(parts[0], parts[1])] = struct{}{}
+	}
+	for _, filter := range c.QueryArray("filter") {
+		parts := strings.Split(filter, ":")
+		if len(parts) != 4 || parts[0] == "" || parts[2] == "" || parts[3] == "" {
+			return nil, fmt.Errorf("invalid filter %s, expected name:exchange:blockchain:address", filter)
+		}
+		s.filters[filterKey{filter: parts[0], exchange: parts[1], asset: assetKey(parts[2], parts[3])}] = struct{}{}
+	}
+	if err := h.register(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SubscribeFilterPoints returns the points of @filter on @exchange for @assets until @ctx is done.
+// An empty @exchange denotes the filter across all exchanges.
+func (h *Hub) SubscribeFilterPoints(ctx context.Context, filter string, exchange string, assets []dia.Asset) (<-chan dia.FilterPoint, error) {
+	s := newSubscriber()
+	for _, asset := range assets {
+		s.filters[filterKey{filter: filter, exchange: exchange, asset: assetKey(asset.Blockchain, asset.Address)}] = struct{}{}
+	}
+	if err := h.register(s); err != nil {
+		return nil, err
+	}
+
+	filterPoints := make(chan dia.FilterPoint)
+	go func() {
+		defer close(filterPoints)
+		defer h.unsubscribe(s)
+		for {
+			select {
+			case event := <-s.events:
+				select {
+				case filterPoints <- *event.FilterPoint:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return filterPoints, nil
+}
+
+func (h *Hub) register(s *subscriber) error {
+	if len(s.assets)+len(s.filters) == 0 {
+		return errors.New("no asset or filter given")
+	}
+	if len(s.assets)+len(s.filters) > maxSubscriptions {
+		return fmt.Errorf("at most %d assets and filters can be subscribed to", maxSubscriptions)
+	}
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *Hub) unsubscribe(s *subscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, s)
+	h.mu.Unlock()
+}
+
+// ServeSSE streams the events of the subscribed assets and filters as Server-Sent Events
+// named quotation and filterPoint.
+func (h *Hub) ServeSSE(c *gin.Context) {
+	s, err := h.subscribe(c)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	defer h.unsubscribe(s)
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event := <-s.events:
+			c.SSEvent(event.Type, event)
+			return true
+		case t := <-keepAlive.C:
+			c.SSEvent("ping", t.Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// ServeWebSocket streams the events of the subscribed assets and filters as json messages over a WebSocket.
+func (h *Hub) ServeWebSocket(c *gin.Context) {
+	s, err := h.subscribe(c)
+	if err != nil {
+		restApi.SendError(c, http.StatusBadRequest, err)
+		return
+	}
+	defer h.unsubscribe(s)
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Error("stream: upgrade to websocket: ", err)
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Error("stream: close websocket: ", err)
+		}
+	}()
+
+	// Messages from the client are discarded. Reading is needed to process control messages and notice closed connections.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event := <-s.events:
+			if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+				log.Info("stream: close websocket: ", err)
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				log.Info("stream: close websocket: ", err)
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				log.Info("stream: close websocket: ", err)
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// assetKey identifies the asset with @address on @blockchain. Hex addresses are compared case-insensitively,
+// as clients may not use checksum addresses.
+func assetKey(blockchain string, address string) string {
+	if strings.HasPrefix(address, "0x") {
+		address = strings.ToLower(address)
+	}
+	return blockchain + "-" + address
+}
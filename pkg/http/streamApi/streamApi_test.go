@@ -0,0 +1,217 @@
+package streamApi
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	streamTestTime = time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	streamTestETH  = dia.Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: dia.ETHEREUM}
+	streamTestUSDC = dia.Asset{Symbol: "USDC", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Blockchain: dia.ETHEREUM}
+)
+
+func streamTestBlock(points ...dia.FilterPoint) *dia.FiltersBlock {
+	return &dia.FiltersBlock{FiltersBlockData: dia.FiltersBlockData{FilterPoints: points}}
+}
+
+func streamTestSubscriber(assets []dia.Asset, filters []filterKey) *subscriber {
+	s := newSubscriber()
+	for _, asset := range assets {
+		s.assets[assetKey(asset.Blockchain, asset.Address)] = struct{}{}
+	}
+	for _, filter := range filters {
+		s.filters[filter] = struct{}{}
+	}
+	return s
+}
+
+// receive returns the events buffered for @s.
+func receive(s *subscriber) []Event {
+	var events []Event
+	for {
+		select {
+		case event := <-s.events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	binanceMA := filterKey{filter: "MA120", exchange: dia.BinanceExchange, asset: assetKey(streamTestETH.Blockchain, streamTestETH.Address)}
+	s := streamTestSubscriber([]dia.Asset{streamTestETH}, []filterKey{binanceMA})
+	if err := hub.register(s); err != nil {
+		t.Fatal(err)
+	}
+
+	block := streamTestBlock(
+		dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestETH, Value: 1800, Time: streamTestTime},
+		dia.FilterPoint{Name: "MA120", Source: dia.BinanceExchange, Asset: streamTestETH, Value: 1810, Time: streamTestTime},
+		dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestUSDC, Value: 1, Time: streamTestTime},
+		dia.FilterPoint{Name: dia.FilterKing, Source: dia.BinanceExchange, Asset: streamTestETH, Value: 1805, Time: streamTestTime},
+	)
+	hub.Publish(block)
+	events := receive(s)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", events)
+	}
+	if events[0].Type != EventQuotation || events[0].Quotation.Price != 1800 || events[0].Quotation.Source != dia.Diadata {
+		t.Errorf("expected quotation 1800 by %s, got %+v", dia.Diadata, events[0])
+	}
+	if events[1].Type != EventFilterPoint || events[1].FilterPoint.Value != 1810 {
+		t.Errorf("expected filter point 1810, got %+v", events[1])
+	}
+
+	// Points repeated in a subsequent filtersBlock are not sent again.
+	hub.Publish(block)
+	if events = receive(s); len(events) != 0 {
+		t.Errorf("expected no events for repeated points, got %v", events)
+	}
+
+	hub.Publish(streamTestBlock(dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestETH, Value: 1820, Time: streamTestTime.Add(time.Minute)}))
+	if events = receive(s); len(events) != 1 || events[0].Quotation.Price != 1820 {
+		t.Errorf("expected quotation 1820, got %v", events)
+	}
+}
+
+func TestHubSubscribe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := []struct {
+		name    string
+		query   string
+		assets  int
+		filters int
+		valid   bool
+	}{
+		{name: "asset and filter", query: "asset=Ethereum:0x01&filter=MA120::Ethereum:0x01", assets: 1, filters: 1, valid: true},
+		{name: "filter per exchange", query: "filter=MA120:Binance:Ethereum:0x01&filter=MA120:Kraken:Ethereum:0x01", filters: 2, valid: true},
+		{name: "no subscription"},
+		{name: "invalid asset", query: "asset=Ethereum"},
+		{name: "invalid filter", query: "filter=MA120:Ethereum:0x01"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hub := NewHub()
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("GET", "/stream/sse?"+c.query, nil)
+			s, err := hub.subscribe(ctx)
+			if !c.valid {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(s.assets) != c.assets || len(s.filters) != c.filters {
+				t.Errorf("expected %d assets and %d filters, got %v and %v", c.assets, c.filters, s.assets, s.filters)
+			}
+			if _, ok := hub.subscribers[s]; !ok {
+				t.Error("subscriber not registered")
+			}
+		})
+	}
+
+	hub := NewHub()
+	s := newSubscriber()
+	for i := 0; i <= maxSubscriptions; i++ {
+		s.assets[assetKey(dia.ETHEREUM, fmt.Sprintf("0x%02x", i))] = struct{}{}
+	}
+	if err := hub.register(s); err == nil {
+		t.Errorf("expected error for more than %d subscriptions", maxSubscriptions)
+	}
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub := NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	filterPoints, err := hub.SubscribeFilterPoints(ctx, "MA120", "", []dia.Asset{streamTestETH})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Publish(streamTestBlock(dia.FilterPoint{Name: "MA120", Asset: streamTestETH, Value: 1800, Time: streamTestTime}))
+	select {
+	case fp := <-filterPoints:
+		if fp.Value != 1800 {
+			t.Errorf("expected filter point 1800, got %v", fp.Value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("filter point not received")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-filterPoints:
+		if ok {
+			t.Error("expected closed channel after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	if len(hub.subscribers) != 0 {
+		t.Errorf("expected no subscribers after cancel, got %d", len(hub.subscribers))
+	}
+}
+
+func TestHubSlowClient(t *testing.T) {
+	hub := NewHub()
+	s := streamTestSubscriber([]dia.Asset{streamTestETH}, nil)
+	if err := hub.register(s); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2*eventBufferSize; i++ {
+			hub.Publish(streamTestBlock(dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestETH, Value: float64(i), Time: streamTestTime.Add(time.Duration(i) * time.Second)}))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publishing blocked by slow client")
+	}
+
+	// Events beyond the buffer are dropped, the buffered ones are kept in order.
+	events := receive(s)
+	if len(events) != eventBufferSize {
+		t.Fatalf("expected %d buffered events, got %d", eventBufferSize, len(events))
+	}
+	if events[0].Quotation.Price != 0 || events[eventBufferSize-1].Quotation.Price != eventBufferSize-1 {
+		t.Errorf("expected the first %d events, got prices %v to %v", eventBufferSize, events[0].Quotation.Price, events[eventBufferSize-1].Quotation.Price)
+	}
+}
+
+func TestHubPrune(t *testing.T) {
+	hub := NewHub()
+	hub.Publish(streamTestBlock(
+		dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestETH, Value: 1800, Time: streamTestTime},
+		dia.FilterPoint{Name: dia.FilterKing, Asset: streamTestUSDC, Value: 1, Time: streamTestTime},
+	))
+	ethKey := filterKey{filter: dia.FilterKing, asset: assetKey(streamTestETH.Blockchain, streamTestETH.Address)}
+	usdcKey := filterKey{filter: dia.FilterKing, asset: assetKey(streamTestUSDC.Blockchain, streamTestUSDC.Address)}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	now := time.Now()
+	// USDC was last contained in a filtersBlock lastTimesTTL ago.
+	hub.lastTimes[usdcKey] = lastPoint{time: streamTestTime, seen: now.Add(-lastTimesTTL)}
+	hub.prune(now)
+	if _, ok := hub.lastTimes[ethKey]; len(hub.lastTimes) != 1 || !ok {
+		t.Errorf("expected only the filter seen within ttl, got %v", hub.lastTimes)
+	}
+}
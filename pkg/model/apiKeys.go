@@ -0,0 +1,216 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	apiKeyTable      = "apikey"
+	apiKeyUsageTable = "apikeyusage"
+
+	keyAPIKeyRate  = "dia_apikey_rate_"
+	keyAPIKeyUsage = "dia_apikey_usage_"
+
+	apiKeyBytes      = 32
+	apiKeyPrefixSize = 8
+	// Daily usage counters are kept in redis long enough to be saved in postgres after the day is over.
+	apiKeyUsageTTL = 48 * time.Hour
+)
+
+// ErrAPIKeyNotFound is returned for keys that are not stored.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// HashAPIKey returns the hash under which @key is stored.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateAPIKey stores a new random key with the properties of @apiKey and returns the key along with
+// the stored properties. The key is returned only once, as only its hash is stored.
+func (rdb *RelDB) CreateAPIKey(apiKey dia.APIKey) (key string, stored dia.APIKey, err error) {
+	buf := make([]byte, apiKeyBytes)
+	_, err = rand.Read(buf)
+	if err != nil {
+		return
+	}
+	key = hex.EncodeToString(buf)
+
+	var validUntil *time.Time
+	if !apiKey.ValidUntil.IsZero() {
+		validUntil = &apiKey.ValidUntil
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (key_hash,key_prefix,owner,scopes,rate_limit,daily_quota,active,valid_until)
+	VALUES ($1,$2,$3,$4,$5,$6,true,$7)
+	RETURNING apikey_id,created_at`, apiKeyTable)
+	stored = apiKey
+	stored.Prefix = key[:apiKeyPrefixSize]
+	stored.Active = true
+	err = rdb.postgresClient.QueryRow(context.Background(), query,
+		HashAPIKey(key),
+		stored.Prefix,
+		apiKey.Owner,
+		apiKey.Scopes,
+		apiKey.RateLimit,
+		apiKey.DailyQuota,
+		validUntil,
+	).Scan(&stored.ID, &stored.CreatedAt)
+	if err != nil {
+		return "", dia.APIKey{}, err
+	}
+	return
+}
+
+// UpdateAPIKey sets scopes, limits, state and expiry of the key with ID @apiKey.ID.
+func (rdb *RelDB) UpdateAPIKey(apiKey dia.APIKey) error {
+	var validUntil *time.Time
+	if !apiKey.ValidUntil.IsZero() {
+		validUntil = &apiKey.ValidUntil
+	}
+	query := fmt.Sprintf("UPDATE %s SET scopes=$1,rate_limit=$2,daily_quota=$3,active=$4,valid_until=$5 WHERE apikey_id=$6", apiKeyTable)
+	tag, err := rdb.postgresClient.Exec(context.Background(), query,
+		apiKey.Scopes,
+		apiKey.RateLimit,
+		apiKey.DailyQuota,
+		apiKey.Active,
+		validUntil,
+		apiKey.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// GetAPIKey returns the properties of @key.
+func (rdb *RelDB) GetAPIKey(key string) (dia.APIKey, error) {
+	query := fmt.Sprintf("SELECT apikey_id,key_prefix,owner,scopes,rate_limit,daily_quota,active,created_at,valid_until FROM %s WHERE key_hash=$1", apiKeyTable)
+	apiKey, err := scanAPIKey(rdb.postgresClient.QueryRow(context.Background(), query, HashAPIKey(key)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apiKey, ErrAPIKeyNotFound
+	}
+	return apiKey, err
+}
+
+// GetAPIKeyByID returns the properties of the key with @id.
+func (rdb *RelDB) GetAPIKeyByID(id string) (dia.APIKey, error) {
+	query := fmt.Sprintf("SELECT apikey_id,key_prefix,owner,scopes,rate_limit,daily_quota,active,created_at,valid_until FROM %s WHERE apikey_id=$1", apiKeyTable)
+	apiKey, err := scanAPIKey(rdb.postgresClient.QueryRow(context.Background(), query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apiKey, ErrAPIKeyNotFound
+	}
+	return apiKey, err
+}
+
+// GetAPIKeys returns the properties of all keys of @owner. An empty @owner returns the keys of all owners.
+func (rdb *RelDB) GetAPIKeys(owner string) (apiKeys []dia.APIKey, err error) {
+	query := fmt.Sprintf("SELECT apikey_id,key_prefix,owner,scopes,rate_limit,daily_quota,active,created_at,valid_until FROM %s WHERE owner=$1 OR $1='' ORDER BY created_at", apiKeyTable)
+	rows, err := rdb.postgresClient.Query(context.Background(), query, owner)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var apiKey dia.APIKey
+		apiKey, err = scanAPIKey(rows)
+		if err != nil {
+			return []dia.APIKey{}, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return
+}
+
+func scanAPIKey(row pgx.Row) (apiKey dia.APIKey, err error) {
+	var validUntil *time.Time
+	err = row.Scan(
+		&apiKey.ID,
+		&apiKey.Prefix,
+		&apiKey.Owner,
+		&apiKey.Scopes,
+		&apiKey.RateLimit,
+		&apiKey.DailyQuota,
+		&apiKey.Active,
+		&apiKey.CreatedAt,
+		&validUntil,
+	)
+	if validUntil != nil {
+		apiKey.ValidUntil = *validUntil
+	}
+	return
+}
+
+// IncrAPIKeyRate increments the number of requests of the key with @id in the minute of @t
+// and returns the incremented number.
+func (rdb *RelDB) IncrAPIKeyRate(id string, t time.Time) (int64, error) {
+	return rdb.incrWithTTL(keyAPIKeyRate+id+"_"+t.UTC().Format("200601021504"), 2*time.Minute)
+}
+
+// IncrAPIKeyUsage increments the number of requests of the key with @id on the day of @t
+// and returns the incremented number.
+func (rdb *RelDB) IncrAPIKeyUsage(id string, t time.Time) (int64, error) {
+	return rdb.incrWithTTL(getKeyAPIKeyUsage(id, t), apiKeyUsageTTL)
+}
+
+// incrWithTTL increments the counter @key and sets its expiry to @ttl in one transaction,
+// such that no counter is left without expiry. Returns the incremented number.
+func (rdb *RelDB) incrWithTTL(key string, ttl time.Duration) (int64, error) {
+	pipe := rdb.redisClient.TxPipeline()
+	incr := pipe.Incr(key)
+	pipe.Expire(key, ttl)
+	_, err := pipe.Exec()
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// SaveAPIKeyUsage copies the number of requests of the key with @id on the day of @t from redis to postgres.
+func (rdb *RelDB) SaveAPIKeyUsage(id string, t time.Time) error {
+	requests, err := rdb.redisClient.Get(getKeyAPIKeyUsage(id, t)).Int64()
+	if err != nil {
+		return err
+	}
+	// Several servers save the same counter, so the stored number is never decreased.
+	query := fmt.Sprintf(`INSERT INTO %s (apikey_id,day,requests) VALUES ($1,$2,$3)
+	ON CONFLICT (apikey_id,day) DO UPDATE SET requests=GREATEST(%s.requests,$3)`, apiKeyUsageTable, apiKeyUsageTable)
+	_, err = rdb.postgresClient.Exec(context.Background(), query, id, t.UTC().Format("2006-01-02"), requests)
+	return err
+}
+
+// GetAPIKeyUsage returns the daily number of requests of the key with @id in [@starttime, @endtime].
+func (rdb *RelDB) GetAPIKeyUsage(id string, starttime time.Time, endtime time.Time) (usage []dia.APIKeyUsage, err error) {
+	query := fmt.Sprintf("SELECT apikey_id,day,requests FROM %s WHERE apikey_id=$1 AND day>=$2 AND day<=$3 ORDER BY day", apiKeyUsageTable)
+	rows, err := rdb.postgresClient.Query(context.Background(), query, id, starttime.UTC().Format("2006-01-02"), endtime.UTC().Format("2006-01-02"))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u dia.APIKeyUsage
+		err = rows.Scan(&u.KeyID, &u.Day, &u.Requests)
+		if err != nil {
+			return []dia.APIKeyUsage{}, err
+		}
+		usage = append(usage, u)
+	}
+	return
+}
+
+func getKeyAPIKeyUsage(id string, t time.Time) string {
+	return keyAPIKeyUsage + id + "_" + t.UTC().Format("20060102")
+}
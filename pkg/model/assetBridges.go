@@ -0,0 +1,133 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+)
+
+const (
+	// AssetBridgesFile is the json file in the config folder containing the default bridging table.
+	AssetBridgesFile = "bridges/assetBridges"
+
+	keyActiveAssetBridges = "dia_assetbridges_active"
+)
+
+type assetBridgesConfig struct {
+	Bridges []dia.AssetBridge `json:"Bridges"`
+}
+
+// SetAssetBridge stores @bridge in postgres, replacing the bridge with the same source asset and exchanges.
+func (rdb *RelDB) SetAssetBridge(bridge dia.AssetBridge) error {
+	query := fmt.Sprintf(`INSERT INTO %s (source_address,source_blockchain,destination_symbol,destination_address,destination_blockchain,exchanges)
+	VALUES ($1,$2,$3,$4,$5,$6)
+	ON CONFLICT (source_address,source_blockchain,exchanges)
+	DO UPDATE SET destination_symbol=$3,destination_address=$4,destination_blockchain=$5`, assetBridgeTable)
+	_, err := rdb.postgresClient.Exec(context.Background(), query,
+		bridge.Source.Address,
+		bridge.Source.Blockchain,
+		bridge.Destination.Symbol,
+		bridge.Destination.Address,
+		bridge.Destination.Blockchain,
+		bridge.SortedExchanges(),
+	)
+	return err
+}
+
+// GetAssetBridges returns all bridges stored in postgres.
+func (rdb *RelDB) GetAssetBridges() (bridges []dia.AssetBridge, err error) {
+	query := fmt.Sprintf("SELECT source_address,source_blockchain,destination_symbol,destination_address,destination_blockchain,exchanges FROM %s", assetBridgeTable)
+	rows, err := rdb.postgresClient.Query(context.Background(), query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bridge dia.AssetBridge
+		err = rows.Scan(
+			&bridge.Source.Address,
+			&bridge.Source.Blockchain,
+			&bridge.Destination.Symbol,
+			&bridge.Destination.Address,
+			&bridge.Destination.Blockchain,
+			&bridge.Exchanges,
+		)
+		if err != nil {
+			return []dia.AssetBridge{}, err
+		}
+		bridges = append(bridges, bridge)
+	}
+	return
+}
+
+// SetActiveAssetBridges stores @bridges in redis as the bridging table used by the tradesBlockService.
+func (datastore *DB) SetActiveAssetBridges(bridges []dia.AssetBridge) error {
+	content, err := json.Marshal(bridges)
+	if err != nil {
+		return err
+	}
+	return datastore.redisClient.Set(keyActiveAssetBridges, content, 0).Err()
+}
+
+// GetActiveAssetBridges returns the bridging table used by the tradesBlockService.
+// redis.Nil is returned if no table is stored.
+func (datastore *DB) GetActiveAssetBridges() (bridges []dia.AssetBridge, err error) {
+	content, err := datastore.redisClient.Get(keyActiveAssetBridges).Bytes()
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(content, &bridges)
+	return
+}
+
+// GetAssetBridgesFromConfig returns the bridges listed in the json file @filename in the config folder.
+func GetAssetBridgesFromConfig(filename string) ([]dia.AssetBridge, error) {
+	content, err := configCollectors.ReadJSONFromConfig(filename)
+	if err != nil {
+		return []dia.AssetBridge{}, err
+	}
+	var config assetBridgesConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return []dia.AssetBridge{}, err
+	}
+	return config.Bridges, nil
+}
+
+// LoadAssetBridges returns the bridging table stored in @relDB.
+// If no bridges are stored, the bridges from AssetBridgesFile in the config folder are used.
+func LoadAssetBridges(relDB RelDatastore) (*dia.AssetBridges, error) {
+	bridges, err := relDB.GetAssetBridges()
+	if err != nil {
+		return nil, err
+	}
+	if len(bridges) == 0 {
+		log.Info("no asset bridges in postgres, loading bridges from config file ", AssetBridgesFile)
+		bridges, err = GetAssetBridgesFromConfig(AssetBridgesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dia.NewAssetBridges(bridges), nil
+}
+
+// InitAssetBridges returns the bridging table from the json file @filename in the config folder if it is non-empty.
+// Otherwise the bridging table is loaded from postgres.
+func InitAssetBridges(filename string) (*dia.AssetBridges, error) {
+	if filename != "" {
+		bridges, err := GetAssetBridgesFromConfig(filename)
+		if err != nil {
+			return nil, err
+		}
+		return dia.NewAssetBridges(bridges), nil
+	}
+	relDB, err := NewRelDataStore()
+	if err != nil {
+		return nil, err
+	}
+	return LoadAssetBridges(relDB)
+}
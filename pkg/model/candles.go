@@ -0,0 +1,320 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	clientInfluxdb "github.com/influxdata/influxdb1-client/v2"
+)
+
+const influxDbCandlesTable = "candles"
+
+// candleResolutions are the supported resolutions of candles in the notation of influx.
+var candleResolutions = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// CandleResolution returns the duration of a candle with @resolution such as 1m, 5m, 1h or 1d.
+func CandleResolution(resolution string) (time.Duration, error) {
+	duration, ok := candleResolutions[resolution]
+	if !ok {
+		return 0, fmt.Errorf("unsupported resolution %s. Use one of 1m, 5m, 1h and 1d", resolution)
+	}
+	return duration, nil
+}
+
+// GetCandles returns the candles of @asset with @resolution in [@starttime, @endtime), computed from the trades
+// on @exchanges against @baseassets. Empty @exchanges and @baseassets denote all exchanges and base assets.
+// Prices are the estimated USD prices of the trades or, if @nativePrice is true, the prices in terms of the
+// base asset. Native prices require exactly one base asset.
+func (datastore *DB) GetCandles(
+	asset dia.Asset,
+	baseassets []dia.Asset,
+	exchanges []string,
+	resolution string,
+	nativePrice bool,
+	starttime time.Time,
+	endtime time.Time,
+) ([]dia.Candle, error) {
+	if _, err := CandleResolution(resolution); err != nil {
+		return []dia.Candle{}, err
+	}
+	if nativePrice && len(baseassets) != 1 {
+		return []dia.Candle{}, errors.New("native prices require exactly one base asset")
+	}
+	priceField := "estimatedUSDPrice"
+	if nativePrice {
+		priceField = "price"
+	}
+
+	query := fmt.Sprintf(`
+	SELECT FIRST(p),MAX(p),MIN(p),LAST(p),SUM(v),SUM(vusd),COUNT(p)
+	FROM (
+		SELECT %s AS p, ABS(volume) AS v, ABS(volume*estimatedUSDPrice) AS vusd
+		FROM %s
+		WHERE quotetokenaddress='%s' AND quotetokenblockchain='%s' %s
+		AND estimatedUSDPrice > 0
+		AND time >= %d AND time < %d
+		)
+	WHERE time >= %d AND time < %d
+	GROUP BY time(%s) fill(none)`,
+		priceField,
+		influxDbTradesTable,
+		escapeInfluxString(asset.Address),
+		escapeInfluxString(asset.Blockchain),
+		candleTradesFilter(exchanges, baseassets),
+		starttime.UnixNano(),
+		endtime.UnixNano(),
+		starttime.UnixNano(),
+		endtime.UnixNano(),
+		resolution,
+	)
+	res, err := queryInfluxDB(datastore.influxClient, query)
+	if err != nil {
+		return []dia.Candle{}, err
+	}
+
+	var exchange string
+	if len(exchanges) == 1 {
+		exchange = exchanges[0]
+	}
+	var candles []dia.Candle
+	if len(res) > 0 && len(res[0].Series) > 0 {
+		for _, row := range res[0].Series[0].Values {
+			candle, err := parseCandleRow(row)
+			if err != nil {
+				return candles, err
+			}
+			candle.Asset = asset
+			candle.Exchange = exchange
+			candle.Resolution = resolution
+			candles = append(candles, candle)
+		}
+	}
+	return candles, nil
+}
+
+// ComputeCandles returns the candles with @resolution in [@starttime, @endtime) of all assets traded
+// in this time range, both per exchange and across all exchanges. Prices are estimated USD prices.
+func (datastore *DB) ComputeCandles(resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error) {
+	if _, err := CandleResolution(resolution); err != nil {
+		return []dia.Candle{}, err
+	}
+
+	var candles []dia.Candle
+	for _, grouping := range []string{`"quotetokenaddress","quotetokenblockchain"`, `"quotetokenaddress","quotetokenblockchain","exchange"`} {
+		query := fmt.Sprintf(`
+		SELECT FIRST(p),MAX(p),MIN(p),LAST(p),SUM(v),SUM(vusd),COUNT(p)
+		FROM (
+			SELECT estimatedUSDPrice AS p, ABS(volume) AS v, ABS(volume*estimatedUSDPrice) AS vusd
+			FROM %s
+			WHERE estimatedUSDPrice > 0
+			AND time >= %d AND time < %d
+			)
+		WHERE time >= %d AND time < %d
+		GROUP BY time(%s),%s fill(none)`,
+			influxDbTradesTable,
+			starttime.UnixNano(),
+			endtime.UnixNano(),
+			starttime.UnixNano(),
+			endtime.UnixNano(),
+			resolution,
+			grouping,
+		)
+		res, err := queryInfluxDB(datastore.influxClient, query)
+		if err != nil {
+			return candles, err
+		}
+		if len(res) == 0 {
+			continue
+		}
+		for _, series := range res[0].Series {
+			for _, row := range series.Values {
+				candle, err := parseCandleRow(row)
+				if err != nil {
+					log.Warnf("parse candle of %v: %v", series.Tags, err)
+					continue
+				}
+				candle.Asset = dia.Asset{Address: series.Tags["quotetokenaddress"], Blockchain: series.Tags["quotetokenblockchain"]}
+				candle.Exchange = series.Tags["exchange"]
+				candle.Resolution = resolution
+				candles = append(candles, candle)
+			}
+		}
+	}
+	return candles, nil
+}
+
+// SaveCandleInflux adds @candle to the influx batch. Saving a candle twice overwrites the first one.
+func (datastore *DB) SaveCandleInflux(candle dia.Candle) error {
+	tags := map[string]string{
+		"quotetokenaddress":    candle.Asset.Address,
+		"quotetokenblockchain": candle.Asset.Blockchain,
+		"exchange":             candle.Exchange,
+		"resolution":           candle.Resolution,
+	}
+	fields := map[string]interface{}{
+		"open":      candle.Open,
+		"high":      candle.High,
+		"low":       candle.Low,
+		"close":     candle.Close,
+		"volume":    candle.Volume,
+		"volumeUSD": candle.VolumeUSD,
+		"numTrades": candle.NumTrades,
+	}
+	pt, err := clientInfluxdb.NewPoint(influxDbCandlesTable, tags, fields, candle.Time)
+	if err != nil {
+		log.Errorln("SaveCandleInflux:", err)
+		return err
+	}
+	datastore.addPoint(pt)
+	return nil
+}
+
+// GetStoredCandles returns the candles of @asset on @exchange with @resolution in [@starttime, @endtime)
+// as saved by SaveCandleInflux. An empty @exchange denotes candles across all exchanges.
+func (datastore *DB) GetStoredCandles(asset dia.Asset, exchange string, resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error) {
+	query := fmt.Sprintf(`
+	SELECT open,high,low,close,volume,volumeUSD,numTrades
+	FROM %s
+	WHERE quotetokenaddress='%s' AND quotetokenblockchain='%s' AND exchange='%s' AND resolution='%s'
+	AND time >= %d AND time < %d
+	ORDER BY ASC`,
+		influxDbCandlesTable,
+		escapeInfluxString(asset.Address),
+		escapeInfluxString(asset.Blockchain),
+		escapeInfluxString(exchange),
+		escapeInfluxString(resolution),
+		starttime.UnixNano(),
+		endtime.UnixNano(),
+	)
+	res, err := queryInfluxDB(datastore.influxClient, query)
+	if err != nil {
+		return []dia.Candle{}, err
+	}
+
+	var candles []dia.Candle
+	if len(res) > 0 && len(res[0].Series) > 0 {
+		for _, row := range res[0].Series[0].Values {
+			candle, err := parseCandleRow(row)
+			if err != nil {
+				return candles, err
+			}
+			candle.Asset = asset
+			candle.Exchange = exchange
+			candle.Resolution = resolution
+			candles = append(candles, candle)
+		}
+	}
+	return candles, nil
+}
+
+// parseCandleRow parses an influx row of the form [time,open,high,low,close,volume,volumeUSD,numTrades].
+func parseCandleRow(row []interface{}) (candle dia.Candle, err error) {
+	if len(row) < 8 {
+		err = errors.New("incomplete candle")
+		return
+	}
+	candle.Time, err = time.Parse(time.RFC3339, row[0].(string))
+	if err != nil {
+		return
+	}
+	values := []*float64{&candle.Open, &candle.High, &candle.Low, &candle.Close, &candle.Volume, &candle.VolumeUSD}
+	for i, value := range values {
+		num, ok := row[i+1].(json.Number)
+		if !ok {
+			err = fmt.Errorf("missing value in column %d", i+1)
+			return
+		}
+		*value, err = num.Float64()
+		if err != nil {
+			return
+		}
+	}
+	num, ok := row[7].(json.Number)
+	if !ok {
+		err = errors.New("missing number of trades")
+		return
+	}
+	candle.NumTrades, err = num.Int64()
+	return
+}
+
+// candleTradesFilter returns the where clause restricting trades to @exchanges and @baseassets.
+// All values are escaped, as they are given by the request.
+func candleTradesFilter(exchanges []string, baseassets []dia.Asset) string {
+	var filter string
+	if len(exchanges) > 0 {
+		var conditions []string
+		for _, exchange := range exchanges {
+			conditions = append(conditions, fmt.Sprintf("exchange='%s'", escapeInfluxString(exchange)))
+		}
+		filter += "AND (" + strings.Join(conditions, " OR ") + ") "
+	}
+	if len(baseassets) > 0 {
+		var conditions []string
+		for _, baseasset := range baseassets {
+			conditions = append(conditions, fmt.Sprintf("(basetokenaddress='%s' AND basetokenblockchain='%s')", escapeInfluxString(baseasset.Address), escapeInfluxString(baseasset.Blockchain)))
+		}
+		filter += "AND (" + strings.Join(conditions, " OR ") + ") "
+	}
+	return filter
+}
+
+// escapeInfluxString escapes @s for use in a single quoted string literal of an influx query.
+func escapeInfluxString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// aggregateCandles returns the candles with @resolution of @trades, which must be sorted in ascending order.
+func aggregateCandles(trades []dia.Trade, resolution string, nativePrice bool) ([]dia.Candle, error) {
+	duration, err := CandleResolution(resolution)
+	if err != nil {
+		return []dia.Candle{}, err
+	}
+	var candles []dia.Candle
+	for _, t := range trades {
+		price := t.EstimatedUSDPrice
+		if nativePrice {
+			price = t.Price
+		}
+		if t.EstimatedUSDPrice <= 0 {
+			continue
+		}
+		volume := t.Volume
+		if volume < 0 {
+			volume = -volume
+		}
+
+		begin := t.Time.UTC().Truncate(duration)
+		if len(candles) == 0 || !candles[len(candles)-1].Time.Equal(begin) {
+			candles = append(candles, dia.Candle{
+				Asset:      dia.Asset{Address: t.QuoteToken.Address, Blockchain: t.QuoteToken.Blockchain},
+				Resolution: resolution,
+				Time:       begin,
+				Open:       price,
+				High:       price,
+				Low:        price,
+			})
+		}
+		candle := &candles[len(candles)-1]
+		if price > candle.High {
+			candle.High = price
+		}
+		if price < candle.Low {
+			candle.Low = price
+		}
+		candle.Close = price
+		candle.Volume += volume
+		candle.VolumeUSD += volume * t.EstimatedUSDPrice
+		candle.NumTrades++
+	}
+	return candles, nil
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+)
+
+func TestAggregateCandles(t *testing.T) {
+	trades := []dia.Trade{
+		memoryTestTrade("Binance", 1800, memoryTestTime.Add(10*time.Second)),
+		memoryTestTrade("Binance", 1820, memoryTestTime.Add(20*time.Second)),
+		memoryTestTrade("Binance", 1790, memoryTestTime.Add(30*time.Second)),
+		memoryTestTrade("Binance", 1810, memoryTestTime.Add(40*time.Second)),
+		// Trades without estimated USD price are ignored.
+		memoryTestTrade("Binance", 0, memoryTestTime.Add(50*time.Second)),
+		memoryTestTrade("Binance", 1830, memoryTestTime.Add(3*time.Minute)),
+	}
+	// Sells have negative volume.
+	trades[1].Volume = -2
+
+	candles, err := aggregateCandles(trades, "1m", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 candles, got %v", candles)
+	}
+	expected := dia.Candle{
+		Asset:      dia.Asset{Address: memoryTestETH.Address, Blockchain: memoryTestETH.Blockchain},
+		Resolution: "1m",
+		Time:       memoryTestTime,
+		Open:       1800,
+		High:       1820,
+		Low:        1790,
+		Close:      1810,
+		Volume:     5,
+		VolumeUSD:  1800 + 2*1820 + 1790 + 1810,
+		NumTrades:  4,
+	}
+	if candles[0] != expected {
+		t.Errorf("expected candle %+v, got %+v", expected, candles[0])
+	}
+	// Minutes without trades have no candle.
+	if !candles[1].Time.Equal(memoryTestTime.Add(3*time.Minute)) || candles[1].Open != 1830 || candles[1].NumTrades != 1 {
+		t.Errorf("expected candle at minute 3 with open 1830, got %+v", candles[1])
+	}
+
+	// Native prices are the prices in terms of the base asset, volumes stay in USD.
+	trades[0].Price = 1
+	native, err := aggregateCandles(trades[:1], "1m", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(native) != 1 || native[0].Open != 1 || native[0].VolumeUSD != 1800 {
+		t.Errorf("expected native candle with open 1 and USD volume 1800, got %v", native)
+	}
+
+	if _, err = aggregateCandles(trades, "2m", false); err == nil {
+		t.Error("expected error for unsupported resolution")
+	}
+}
+
+func TestParseCandleRow(t *testing.T) {
+	row := []interface{}{"2023-05-04T10:00:00Z", json.Number("1800"), json.Number("1820"), json.Number("1790"), json.Number("1810"), json.Number("5"), json.Number("9050"), json.Number("4")}
+	candle, err := parseCandleRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := dia.Candle{Time: memoryTestTime, Open: 1800, High: 1820, Low: 1790, Close: 1810, Volume: 5, VolumeUSD: 9050, NumTrades: 4}
+	if candle != expected {
+		t.Errorf("expected candle %+v, got %+v", expected, candle)
+	}
+
+	invalid := map[string][]interface{}{
+		"incomplete row":   row[:7],
+		"invalid time":     {"yesterday", json.Number("1800"), json.Number("1820"), json.Number("1790"), json.Number("1810"), json.Number("5"), json.Number("9050"), json.Number("4")},
+		"missing value":    {"2023-05-04T10:00:00Z", nil, json.Number("1820"), json.Number("1790"), json.Number("1810"), json.Number("5"), json.Number("9050"), json.Number("4")},
+		"fractional count": {"2023-05-04T10:00:00Z", json.Number("1800"), json.Number("1820"), json.Number("1790"), json.Number("1810"), json.Number("5"), json.Number("9050"), json.Number("4.5")},
+	}
+	for name, row := range invalid {
+		if _, err := parseCandleRow(row); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCandleTradesFilter(t *testing.T) {
+	filter := candleTradesFilter([]string{"Binance", `x' OR exchange=~/.*/ OR exchange='`}, []dia.Asset{{Address: `0x01\`, Blockchain: dia.ETHEREUM}})
+	expected := `AND (exchange='Binance' OR exchange='x\' OR exchange=~/.*/ OR exchange=\'') AND ((basetokenaddress='0x01\\' AND basetokenblockchain='Ethereum')) `
+	if filter != expected {
+		t.Errorf("expected filter %s, got %s", expected, filter)
+	}
+}
@@ -41,6 +41,7 @@ type Datastore interface {
 	SaveTradeInfluxToTable(t *dia.Trade, table string) error
 	GetTradeInflux(dia.Asset, string, time.Time, time.Duration) (*dia.Trade, error)
 	SaveFilterInflux(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error
+	SaveFilterInfluxToTable(filter string, asset dia.Asset, exchange string, value float64, t time.Time, table string) error
 	GetFilterAllExchanges(filter string, address string, blockchain string, starttime time.Time, endtime time.Time) ([]AssetQuotation, error)
 	GetLastTrades(asset dia.Asset, exchange string, timestamp time.Time, maxTrades int, fullAsset bool) ([]dia.Trade, error)
 	GetAllTrades(t time.Time, maxTrades int) ([]dia.Trade, error)
@@ -61,6 +62,8 @@ type Datastore interface {
 	GetFilterPoints(filter string, exchange string, symbol string, scale string, starttime time.Time, endtime time.Time) (*Points, error)
 	GetFilterPointsAsset(filter string, exchange string, address string, blockchain string, starttime time.Time, endtime time.Time) (*Points, error)
 	SetFilter(filterName string, asset dia.Asset, exchange string, value float64, t time.Time) error
+	SetRejectedSources(filterName string, asset dia.Asset, exchange string, sources []string, t time.Time) error
+	GetRejectedSources(filterName string, asset dia.Asset, exchange string, starttime time.Time, endtime time.Time) ([]dia.FilterPoint, error)
 	GetLastPriceBefore(asset dia.Asset, filter string, exchange string, timestamp time.Time) (Price, error)
 	SetAvailablePairs(exchange string, pairs []dia.ExchangePair) error
 	GetAvailablePairs(exchange string) ([]dia.ExchangePair, error)
@@ -77,6 +80,16 @@ type Datastore interface {
 	GetVolumesAllExchanges(asset dia.Asset, starttime time.Time, endtime time.Time) (exchVolumes dia.ExchangeVolumesList, err error)
 	GetExchangePairVolumes(asset dia.Asset, starttime time.Time, endtime time.Time) (map[string][]dia.PairVolume, error)
 
+	// Asset bridge methods
+	SetActiveAssetBridges(bridges []dia.AssetBridge) error
+	GetActiveAssetBridges() ([]dia.AssetBridge, error)
+
+	// Candle methods
+	GetCandles(asset dia.Asset, baseassets []dia.Asset, exchanges []string, resolution string, nativePrice bool, starttime time.Time, endtime time.Time) ([]dia.Candle, error)
+	ComputeCandles(resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error)
+	SaveCandleInflux(candle dia.Candle) error
+	GetStoredCandles(asset dia.Asset, exchange string, resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error)
+
 	// New Asset pricing methods: 23/02/2021
 	SetAssetPriceUSD(asset dia.Asset, price float64, timestamp time.Time) error
 	GetAssetPriceUSD(asset dia.Asset, timestamp time.Time) (float64, error)
@@ -151,6 +164,7 @@ const (
 	influxDbName                      = "dia"
 	influxDbTradesTable               = "trades"
 	influxDbFiltersTable              = "filters"
+	influxDbRejectedSourcesTable      = "filterRejectedSources"
 	influxDbFiatQuotationsTable       = "fiat"
 	influxDbSupplyTable               = "supplies"
 	influxDbDEXPoolTable              = "DEXPools"
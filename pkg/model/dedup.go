@@ -0,0 +1,89 @@
+package models
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/db"
+	"github.com/go-redis/redis"
+)
+
+const (
+	keyDedupPrefix = "dedup_"
+
+	// DedupStoreMemory and DedupStoreRedis are the types of dedup stores available in NewDedupStore.
+	DedupStoreMemory = "memory"
+	DedupStoreRedis  = "redis"
+)
+
+// RedisDedupStore is a DedupStore in redis. It is shared by all instances of a service using the same
+// namespace and survives restarts.
+type RedisDedupStore struct {
+	redisClient *redis.Client
+	namespace   string
+	ttl         time.Duration
+}
+
+// NewRedisDedupStore returns a redis store keeping keys in @namespace for @ttl.
+func NewRedisDedupStore(namespace string, ttl time.Duration) *RedisDedupStore {
+	return &RedisDedupStore{
+		redisClient: db.GetRedisClient(),
+		namespace:   namespace,
+		ttl:         ttl,
+	}
+}
+
+// SeenBefore records @key for the store's ttl and returns true if @key was already recorded.
+func (s *RedisDedupStore) SeenBefore(key string) (bool, error) {
+	added, err := s.redisClient.SetNX(s.redisKey(key), 1, s.ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return !added, nil
+}
+
+// Seen returns true if @key was recorded within the store's ttl.
+func (s *RedisDedupStore) Seen(key string) (bool, error) {
+	n, err := s.redisClient.Exists(s.redisKey(key)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Record records @keys for the store's ttl.
+func (s *RedisDedupStore) Record(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	pipe := s.redisClient.Pipeline()
+	for _, key := range keys {
+		pipe.Set(s.redisKey(key), 1, s.ttl)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+// redisKey hashes @key in order to bound the memory used in redis.
+func (s *RedisDedupStore) redisKey(key string) string {
+	hash := sha1.Sum([]byte(key))
+	return keyDedupPrefix + s.namespace + "_" + hex.EncodeToString(hash[:])
+}
+
+// NewDedupStore returns a dedup store of type @storeType keeping keys for @ttl.
+// @namespace separates the keys of different services in redis. Returns nil if @storeType is empty.
+func NewDedupStore(storeType string, namespace string, ttl time.Duration) (dia.DedupStore, error) {
+	switch storeType {
+	case "":
+		return nil, nil
+	case DedupStoreMemory:
+		return dia.NewMemoryDedupStore(ttl), nil
+	case DedupStoreRedis:
+		return NewRedisDedupStore(namespace, ttl), nil
+	default:
+		return nil, errors.New("unknown dedup store " + storeType)
+	}
+}
@@ -0,0 +1,337 @@
+package embedded
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	recordsFilePrefix = "records-"
+	recordsFileSuffix = ".jsonl"
+	recordsDayLayout  = "2006-01-02"
+	// maxRecordSize is the maximal size of a single line in a records file.
+	maxRecordSize = 10 * 1024 * 1024
+	// Default tables as the influx measurements of models.DB.
+	tradesTable          = "trades"
+	filtersTable         = "filters"
+	rejectedSourcesTable = "filterRejectedSources"
+)
+
+// FileDB is a Datastore for self-contained deployments. Trades, filter values and asset quotations
+// are appended as json lines to one file per day in a directory and served by the embedded MemoryDB.
+// On start, all records within the retention period are loaded. Prune removes older records from memory and
+// older files from disk. All other data, such as supplies, is kept in memory only.
+type FileDB struct {
+	*models.MemoryDB
+	dir       string
+	retention time.Duration
+
+	mu      sync.Mutex
+	day     string
+	file    *os.File
+	writer  *bufio.Writer
+	records int
+}
+
+// fileRecord is a line of a records file. Exactly one of Trade, Filter and Quotation is set.
+type fileRecord struct {
+	Table     string                 `json:"table,omitempty"`
+	Trade     *dia.Trade             `json:"trade,omitempty"`
+	Filter    *fileFilterPoint       `json:"filter,omitempty"`
+	Quotation *models.AssetQuotation `json:"quotation,omitempty"`
+	// Cache is true if the quotation was also written to the cache.
+	Cache bool `json:"cache,omitempty"`
+}
+
+type fileFilterPoint struct {
+	Name     string    `json:"name"`
+	Asset    dia.Asset `json:"asset"`
+	Exchange string    `json:"exchange"`
+	Value    float64   `json:"value"`
+	Time     time.Time `json:"time"`
+	// RejectedSources is only set in the rejected sources table.
+	RejectedSources []string `json:"rejectedSources,omitempty"`
+}
+
+// NewFileDataStore returns a datastore writing records to @dir. Records older than @retention are discarded.
+// If @retention is 0, records are kept forever.
+func NewFileDataStore(dir string, retention time.Duration) (*FileDB, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	fdb := &FileDB{
+		MemoryDB:  models.NewMemoryDataStore(),
+		dir:       dir,
+		retention: retention,
+	}
+	fdb.Prune()
+	days, err := fdb.recordDays()
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		err = fdb.load(day)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fdb, nil
+}
+
+// recordDays returns the days of all records files in ascending order.
+func (fdb *FileDB) recordDays() ([]string, error) {
+	files, err := ioutil.ReadDir(fdb.dir)
+	if err != nil {
+		return nil, err
+	}
+	var days []string
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasPrefix(name, recordsFilePrefix) && strings.HasSuffix(name, recordsFileSuffix) {
+			days = append(days, strings.TrimSuffix(strings.TrimPrefix(name, recordsFilePrefix), recordsFileSuffix))
+		}
+	}
+	sort.Strings(days)
+	return days, nil
+}
+
+func (fdb *FileDB) recordsFile(day string) string {
+	return filepath.Join(fdb.dir, recordsFilePrefix+day+recordsFileSuffix)
+}
+
+// load adds all records of @day within the retention period to memory.
+func (fdb *FileDB) load(day string) error {
+	file, err := os.Open(fdb.recordsFile(day))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	var count int
+	for scanner.Scan() {
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// The last line may be incomplete after a crash.
+			log.Warnf("skip invalid record in %s: %v", fdb.recordsFile(day), err)
+			continue
+		}
+		if fdb.expired(record.time()) {
+			continue
+		}
+		if err := fdb.apply(record); err != nil {
+			return err
+		}
+		count++
+	}
+	log.Infof("loaded %d records from %s", count, fdb.recordsFile(day))
+	return scanner.Err()
+}
+
+func (record fileRecord) time() time.Time {
+	switch {
+	case record.Trade != nil:
+		return record.Trade.Time
+	case record.Filter != nil:
+		return record.Filter.Time
+	case record.Quotation != nil:
+		return record.Quotation.Time
+	}
+	return time.Time{}
+}
+
+func (fdb *FileDB) expired(t time.Time) bool {
+	return fdb.retention > 0 && t.Before(time.Now().Add(-fdb.retention))
+}
+
+// apply writes @record to memory.
+func (fdb *FileDB) apply(record fileRecord) error {
+	switch {
+	case record.Trade != nil:
+		return fdb.MemoryDB.SaveTradeInfluxToTable(record.Trade, record.Table)
+	case record.Filter != nil && record.Table == rejectedSourcesTable:
+		fp := record.Filter
+		return fdb.MemoryDB.SetRejectedSources(fp.Name, fp.Asset, fp.Exchange, fp.RejectedSources, fp.Time)
+	case record.Filter != nil:
+		fp := record.Filter
+		return fdb.MemoryDB.SaveFilterInfluxToTable(fp.Name, fp.Asset, fp.Exchange, fp.Value, fp.Time, record.Table)
+	case record.Quotation != nil:
+		if record.Cache {
+			return fdb.MemoryDB.SetAssetQuotation(record.Quotation)
+		}
+		return fdb.MemoryDB.AddAssetQuotationsToBatch([]*models.AssetQuotation{record.Quotation})
+	}
+	return nil
+}
+
+// write writes @record to memory and appends it to the records file of the current day.
+func (fdb *FileDB) write(record fileRecord) error {
+	if err := fdb.apply(record); err != nil {
+		return err
+	}
+	content, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	fdb.mu.Lock()
+	defer fdb.mu.Unlock()
+	day := time.Now().UTC().Format(recordsDayLayout)
+	if day != fdb.day {
+		if err := fdb.closeFile(); err != nil {
+			log.Error("close records file: ", err)
+		}
+		fdb.file, err = os.OpenFile(fdb.recordsFile(day), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		fdb.writer = bufio.NewWriter(fdb.file)
+		fdb.day = day
+	}
+	if _, err := fdb.writer.Write(append(content, '\n')); err != nil {
+		return err
+	}
+	fdb.records++
+	return nil
+}
+
+// closeFile flushes and closes the current records file. fdb.mu must be held.
+func (fdb *FileDB) closeFile() error {
+	if fdb.file == nil {
+		return nil
+	}
+	err := fdb.writer.Flush()
+	if errClose := fdb.file.Close(); err == nil {
+		err = errClose
+	}
+	fdb.file, fdb.writer, fdb.day = nil, nil, ""
+	return err
+}
+
+// Flush writes buffered records to disk.
+func (fdb *FileDB) Flush() error {
+	fdb.mu.Lock()
+	defer fdb.mu.Unlock()
+	if fdb.writer == nil || fdb.records == 0 {
+		return nil
+	}
+	fdb.records = 0
+	if err := fdb.writer.Flush(); err != nil {
+		return err
+	}
+	return fdb.file.Sync()
+}
+
+// Close flushes and closes the records file.
+func (fdb *FileDB) Close() error {
+	fdb.mu.Lock()
+	defer fdb.mu.Unlock()
+	return fdb.closeFile()
+}
+
+// Prune removes records older than the retention period from memory and records files
+// of days entirely before the retention period from disk.
+func (fdb *FileDB) Prune() {
+	if fdb.retention <= 0 {
+		return
+	}
+	before := time.Now().Add(-fdb.retention)
+	fdb.MemoryDB.Prune(before)
+
+	days, err := fdb.recordDays()
+	if err != nil {
+		log.Error("list records files: ", err)
+		return
+	}
+	for _, day := range days {
+		dayTime, err := time.Parse(recordsDayLayout, day)
+		if err != nil || !dayTime.AddDate(0, 0, 1).Before(before) {
+			continue
+		}
+		if err := os.Remove(fdb.recordsFile(day)); err != nil {
+			log.Error("remove records file: ", err)
+		} else {
+			log.Info("removed records file ", fdb.recordsFile(day))
+		}
+	}
+}
+
+// SaveTradeInflux stores a trade in the trades table.
+func (fdb *FileDB) SaveTradeInflux(t *dia.Trade) error {
+	return fdb.SaveTradeInfluxToTable(t, tradesTable)
+}
+
+// SaveTradeInfluxToTable stores a trade in @table.
+func (fdb *FileDB) SaveTradeInfluxToTable(t *dia.Trade, table string) error {
+	trade := *t
+	return fdb.write(fileRecord{Table: table, Trade: &trade})
+}
+
+// SetFilter stores a filter point.
+func (fdb *FileDB) SetFilter(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return fdb.SaveFilterInflux(filter, asset, exchange, value, t)
+}
+
+// SaveFilterInflux stores a filter point in the filters table.
+func (fdb *FileDB) SaveFilterInflux(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return fdb.SaveFilterInfluxToTable(filter, asset, exchange, value, t, filtersTable)
+}
+
+// SaveFilterInfluxToTable stores a filter point in @table.
+func (fdb *FileDB) SaveFilterInfluxToTable(filter string, asset dia.Asset, exchange string, value float64, t time.Time, table string) error {
+	return fdb.write(fileRecord{
+		Table:  table,
+		Filter: &fileFilterPoint{Name: filter, Asset: asset, Exchange: exchange, Value: value, Time: t},
+	})
+}
+
+// SetRejectedSources stores the @sources that were discarded by @filter at time @t.
+func (fdb *FileDB) SetRejectedSources(filter string, asset dia.Asset, exchange string, sources []string, t time.Time) error {
+	return fdb.write(fileRecord{
+		Table:  rejectedSourcesTable,
+		Filter: &fileFilterPoint{Name: filter, Asset: asset, Exchange: exchange, Time: t, RejectedSources: sources},
+	})
+}
+
+// SetAssetPriceUSD stores the price of @asset as quotation by DIA.
+func (fdb *FileDB) SetAssetPriceUSD(asset dia.Asset, price float64, timestamp time.Time) error {
+	return fdb.SetAssetQuotation(&models.AssetQuotation{
+		Asset:  asset,
+		Price:  price,
+		Source: dia.Diadata,
+		Time:   timestamp,
+	})
+}
+
+// SetAssetQuotation stores @quotation and writes it to the cache.
+func (fdb *FileDB) SetAssetQuotation(quotation *models.AssetQuotation) error {
+	q := *quotation
+	return fdb.write(fileRecord{Quotation: &q, Cache: true})
+}
+
+// AddAssetQuotationsToBatch stores @quotations without updating the cache.
+func (fdb *FileDB) AddAssetQuotationsToBatch(quotations []*models.AssetQuotation) error {
+	for _, quotation := range quotations {
+		q := *quotation
+		if err := fdb.write(fileRecord{Quotation: &q}); err != nil {
+			return fmt.Errorf("write quotation of %s: %v", q.Asset.Symbol, err)
+		}
+	}
+	return nil
+}
+
+var _ models.Datastore = (*FileDB)(nil)
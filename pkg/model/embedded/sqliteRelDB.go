@@ -0,0 +1,289 @@
+package embedded
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	models "github.com/diadata-org/diadata/pkg/model"
+	_ "github.com/mattn/go-sqlite3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Tables of the SQLite database. Each row holds the json encoded entry under its unique key.
+const (
+	assetTable         = "asset"
+	assetVolumeTable   = "assetvolume"
+	exchangeTable      = "exchange"
+	exchangepairTable  = "exchangepair"
+	poolTable          = "pool"
+	blockchainTable    = "blockchain"
+	chainconfigTable   = "chainconfig"
+	assetBridgeTable   = "assetbridge"
+	scraperStateTable  = "scraperstate"
+	scraperConfigTable = "scraperconfig"
+)
+
+// sqliteTables are created on start and loaded in this order, as exchange pairs, volumes and pools refer to assets.
+var sqliteTables = []string{
+	assetTable,
+	assetVolumeTable,
+	exchangeTable,
+	exchangepairTable,
+	poolTable,
+	blockchainTable,
+	chainconfigTable,
+	assetBridgeTable,
+	scraperStateTable,
+	scraperConfigTable,
+}
+
+// SQLiteRelDB is a RelDatastore for self-contained deployments. Assets, asset volumes, exchanges,
+// exchange pairs, pools, blockchains, chain configs, asset bridges and scraper states are persisted
+// in a SQLite database. Reads are served by the embedded MemoryRelDB which is loaded from the database on start.
+// Exchange symbols, block data and NFT data are kept in memory only.
+type SQLiteRelDB struct {
+	*models.MemoryRelDB
+	db *sql.DB
+}
+
+type sqliteAssetVolume struct {
+	Asset  dia.Asset
+	Volume float64
+	Time   time.Time
+}
+
+// NewSQLiteRelDataStore opens the SQLite database in @path, creating it if necessary, and loads its content.
+func NewSQLiteRelDataStore(path string) (*SQLiteRelDB, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite does not support concurrent writers.
+	db.SetMaxOpenConns(1)
+	for _, table := range sqliteTables {
+		_, err = db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, content TEXT NOT NULL)", table))
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	srdb := &SQLiteRelDB{
+		MemoryRelDB: models.NewMemoryRelDataStore(),
+		db:          db,
+	}
+	err = srdb.load()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return srdb, nil
+}
+
+// Close closes the database.
+func (srdb *SQLiteRelDB) Close() error {
+	return srdb.db.Close()
+}
+
+// load fills the embedded MemoryRelDB with all rows of the database.
+func (srdb *SQLiteRelDB) load() error {
+	for _, table := range sqliteTables {
+		rows, err := srdb.db.Query(fmt.Sprintf("SELECT id, content FROM %s ORDER BY rowid", table))
+		if err != nil {
+			return err
+		}
+		var count int
+		for rows.Next() {
+			var id, content string
+			if err = rows.Scan(&id, &content); err != nil {
+				break
+			}
+			if err = srdb.loadRow(table, id, []byte(content)); err != nil {
+				err = fmt.Errorf("load %s %s: %v", table, id, err)
+				break
+			}
+			count++
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		log.Infof("loaded %d rows from %s", count, table)
+	}
+	return nil
+}
+
+func (srdb *SQLiteRelDB) loadRow(table string, id string, content []byte) error {
+	switch table {
+	case assetTable:
+		var asset dia.Asset
+		if err := json.Unmarshal(content, &asset); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetAsset(asset)
+	case assetVolumeTable:
+		var volume sqliteAssetVolume
+		if err := json.Unmarshal(content, &volume); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetAssetVolume24H(volume.Asset, volume.Volume, volume.Time)
+	case exchangeTable:
+		var exchange dia.Exchange
+		if err := json.Unmarshal(content, &exchange); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetExchange(exchange)
+	case exchangepairTable:
+		var pair dia.ExchangePair
+		if err := json.Unmarshal(content, &pair); err != nil {
+			return err
+		}
+		// The caching layer is not persisted. Pairs are cached on load, as scrapers look them up in the cache.
+		return srdb.MemoryRelDB.SetExchangePair(pair.Exchange, pair, true)
+	case poolTable:
+		var pool dia.Pool
+		if err := json.Unmarshal(content, &pool); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetPool(pool)
+	case blockchainTable:
+		var blockchain dia.BlockChain
+		if err := json.Unmarshal(content, &blockchain); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetBlockchain(blockchain)
+	case chainconfigTable:
+		var chainconfig dia.ChainConfig
+		if err := json.Unmarshal(content, &chainconfig); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetChainConfig(chainconfig)
+	case assetBridgeTable:
+		var bridge dia.AssetBridge
+		if err := json.Unmarshal(content, &bridge); err != nil {
+			return err
+		}
+		return srdb.MemoryRelDB.SetAssetBridge(bridge)
+	case scraperStateTable:
+		return srdb.MemoryRelDB.SetScraperState(context.Background(), id, json.RawMessage(content))
+	case scraperConfigTable:
+		return srdb.MemoryRelDB.SetScraperConfig(context.Background(), id, json.RawMessage(content))
+	}
+	return nil
+}
+
+// put writes @value json encoded into @table under @id. Existing rows are replaced unless @keepExisting is true.
+func (srdb *SQLiteRelDB) put(table string, id string, value interface{}, keepExisting bool) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	statement := "INSERT OR REPLACE"
+	if keepExisting {
+		statement = "INSERT OR IGNORE"
+	}
+	_, err = srdb.db.Exec(fmt.Sprintf("%s INTO %s (id, content) VALUES (?, ?)", statement, table), id, string(content))
+	return err
+}
+
+func assetID(asset dia.Asset) string {
+	return asset.Blockchain + "_" + asset.Address
+}
+
+// SetAsset stores @asset if no asset with the same address and blockchain exists.
+func (srdb *SQLiteRelDB) SetAsset(asset dia.Asset) error {
+	if err := srdb.MemoryRelDB.SetAsset(asset); err != nil {
+		return err
+	}
+	return srdb.put(assetTable, assetID(asset), asset, true)
+}
+
+// SetAssetVolume24H stores the 24h volume of @asset. @asset must exist.
+func (srdb *SQLiteRelDB) SetAssetVolume24H(asset dia.Asset, volume float64, timestamp time.Time) error {
+	if err := srdb.MemoryRelDB.SetAssetVolume24H(asset, volume, timestamp); err != nil {
+		return err
+	}
+	return srdb.put(assetVolumeTable, assetID(asset), sqliteAssetVolume{Asset: asset, Volume: volume, Time: timestamp}, false)
+}
+
+// SetExchange stores @exchange, overwriting an existing exchange with the same name.
+func (srdb *SQLiteRelDB) SetExchange(exchange dia.Exchange) error {
+	if err := srdb.MemoryRelDB.SetExchange(exchange); err != nil {
+		return err
+	}
+	return srdb.put(exchangeTable, exchange.Name, exchange, false)
+}
+
+// SetExchangePair stores @pair. If cache==true, it is also cached.
+func (srdb *SQLiteRelDB) SetExchangePair(exchange string, pair dia.ExchangePair, cache bool) error {
+	if err := srdb.MemoryRelDB.SetExchangePair(exchange, pair, cache); err != nil {
+		return err
+	}
+	pair.Exchange = exchange
+	return srdb.put(exchangepairTable, exchange+"_"+pair.ForeignName, pair, false)
+}
+
+// SetExchangePairCache caches @pair and stores it, as the cache is restored from the stored pairs.
+func (srdb *SQLiteRelDB) SetExchangePairCache(exchange string, pair dia.ExchangePair) error {
+	if err := srdb.MemoryRelDB.SetExchangePairCache(exchange, pair); err != nil {
+		return err
+	}
+	pair.Exchange = exchange
+	return srdb.put(exchangepairTable, exchange+"_"+pair.ForeignName, pair, false)
+}
+
+// SetPool stores the most recent state of @pool.
+func (srdb *SQLiteRelDB) SetPool(pool dia.Pool) error {
+	if err := srdb.MemoryRelDB.SetPool(pool); err != nil {
+		return err
+	}
+	return srdb.put(poolTable, pool.Blockchain.Name+"_"+pool.Address, pool, false)
+}
+
+// SetBlockchain stores @blockchain, overwriting an existing blockchain with the same name.
+func (srdb *SQLiteRelDB) SetBlockchain(blockchain dia.BlockChain) error {
+	if err := srdb.MemoryRelDB.SetBlockchain(blockchain); err != nil {
+		return err
+	}
+	return srdb.put(blockchainTable, blockchain.Name, blockchain, false)
+}
+
+// SetChainConfig stores @chainconfig.
+func (srdb *SQLiteRelDB) SetChainConfig(chainconfig dia.ChainConfig) error {
+	if err := srdb.MemoryRelDB.SetChainConfig(chainconfig); err != nil {
+		return err
+	}
+	return srdb.put(chainconfigTable, chainconfig.ChainID, chainconfig, false)
+}
+
+// SetAssetBridge stores @bridge, replacing a bridge with the same source asset and exchanges.
+func (srdb *SQLiteRelDB) SetAssetBridge(bridge dia.AssetBridge) error {
+	if err := srdb.MemoryRelDB.SetAssetBridge(bridge); err != nil {
+		return err
+	}
+	return srdb.put(assetBridgeTable, bridge.ID(), bridge, false)
+}
+
+// SetScraperState stores the json encoded @state of @scraperName.
+func (srdb *SQLiteRelDB) SetScraperState(ctx context.Context, scraperName string, state models.ScraperState) error {
+	if err := srdb.MemoryRelDB.SetScraperState(ctx, scraperName, state); err != nil {
+		return err
+	}
+	return srdb.put(scraperStateTable, scraperName, state, false)
+}
+
+// SetScraperConfig stores the json encoded @config of @scraperName.
+func (srdb *SQLiteRelDB) SetScraperConfig(ctx context.Context, scraperName string, config models.ScraperConfig) error {
+	if err := srdb.MemoryRelDB.SetScraperConfig(ctx, scraperName, config); err != nil {
+		return err
+	}
+	return srdb.put(scraperConfigTable, scraperName, config, false)
+}
+
+var _ models.RelDatastore = (*SQLiteRelDB)(nil)
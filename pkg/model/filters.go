@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/diadata-org/diadata/pkg/dia"
@@ -226,7 +227,13 @@ func getKeyFilterSymbolAndExchangeZSET(filter string, asset dia.Asset, exchange
 }
 
 // SaveFilterInflux stores a filter point in influx.
+// Wrapper around SaveFilterInfluxToTable.
 func (datastore *DB) SaveFilterInflux(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return datastore.SaveFilterInfluxToTable(filter, asset, exchange, value, t, influxDbFiltersTable)
+}
+
+// SaveFilterInfluxToTable stores a filter point in influx into @table.
+func (datastore *DB) SaveFilterInfluxToTable(filter string, asset dia.Asset, exchange string, value float64, t time.Time, table string) error {
 	// Create a point and add to batch
 	tags := map[string]string{
 		"filter":     filter,
@@ -239,7 +246,7 @@ func (datastore *DB) SaveFilterInflux(filter string, asset dia.Asset, exchange s
 		"value":        value,
 		"allExchanges": exchange == "",
 	}
-	pt, err := clientInfluxdb.NewPoint(influxDbFiltersTable, tags, fields, t)
+	pt, err := clientInfluxdb.NewPoint(table, tags, fields, t)
 	if err != nil {
 		log.Errorln("new filter influx:", err)
 	} else {
@@ -248,6 +255,61 @@ func (datastore *DB) SaveFilterInflux(filter string, asset dia.Asset, exchange s
 	return err
 }
 
+// SetRejectedSources stores the @sources that were discarded by @filter when computing the value
+// of @asset on @exchange at time @t.
+func (datastore *DB) SetRejectedSources(filter string, asset dia.Asset, exchange string, sources []string, t time.Time) error {
+	tags := map[string]string{
+		"filter":     filter,
+		"symbol":     asset.Symbol,
+		"address":    asset.Address,
+		"blockchain": asset.Blockchain,
+		"exchange":   exchange,
+	}
+	fields := map[string]interface{}{
+		"sources": strings.Join(sources, ","),
+	}
+	pt, err := clientInfluxdb.NewPoint(influxDbRejectedSourcesTable, tags, fields, t)
+	if err != nil {
+		log.Errorln("new rejected sources influx:", err)
+	} else {
+		datastore.addPoint(pt)
+	}
+	return err
+}
+
+// GetRejectedSources returns the filter points of @filter for @asset on @exchange in (@starttime, @endtime]
+// at which sources were rejected, sorted by time in descending order. Only Name, Asset, Time, Source and
+// RejectedSources are set.
+func (datastore *DB) GetRejectedSources(filter string, asset dia.Asset, exchange string, starttime time.Time, endtime time.Time) ([]dia.FilterPoint, error) {
+	var filterPoints []dia.FilterPoint
+	q := fmt.Sprintf("SELECT time,symbol,sources FROM %s"+
+		" WHERE filter='%s' AND exchange='%s' AND address='%s' AND blockchain='%s' AND time>%d AND time<=%d ORDER BY DESC",
+		influxDbRejectedSourcesTable, filter, exchange, asset.Address, asset.Blockchain, starttime.UnixNano(), endtime.UnixNano())
+
+	res, err := queryInfluxDB(datastore.influxClient, q)
+	if err != nil {
+		return filterPoints, err
+	}
+	if len(res) == 0 || len(res[0].Series) == 0 {
+		return filterPoints, nil
+	}
+	for _, row := range res[0].Series[0].Values {
+		fp := dia.FilterPoint{Name: filter, Asset: asset, Source: exchange}
+		fp.Time, err = time.Parse(time.RFC3339, row[0].(string))
+		if err != nil {
+			return filterPoints, err
+		}
+		if symbol, ok := row[1].(string); ok {
+			fp.Asset.Symbol = symbol
+		}
+		if sources, ok := row[2].(string); ok && sources != "" {
+			fp.RejectedSources = strings.Split(sources, ",")
+		}
+		filterPoints = append(filterPoints, fp)
+	}
+	return filterPoints, nil
+}
+
 func (datastore *DB) setZSETValue(key string, value float64, unixTime int64, maxWindow int64) error {
 	if datastore.redisClient == nil {
 		return nil
@@ -0,0 +1,1647 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers"
+	"github.com/diadata-org/diadata/pkg/utils"
+	"github.com/go-redis/redis"
+	influxModels "github.com/influxdata/influxdb1-client/models"
+	clientInfluxdb "github.com/influxdata/influxdb1-client/v2"
+)
+
+// MemoryDB is an in-memory implementation of Datastore. It holds trades, filters, asset quotations,
+// supplies and related data in memory such that services can be tested without redis and influx.
+// Influx measurements are emulated by tables, cache misses are reported as redis.Nil as in DB.
+// Methods relying on influx aggregations that are not emulated return an error.
+type MemoryDB struct {
+	mu sync.RWMutex
+
+	trades          map[string][]dia.Trade
+	filters         map[string][]memoryFilterPoint
+	filterIndex     map[string]map[memoryFilterKey]int
+	quotations      map[string][]AssetQuotation
+	quotationCache  map[string]AssetQuotation
+	supplies        map[string][]dia.Supply
+	synthSupplies   map[string][]dia.SynthAssetSupply
+	lastTradeTimes  map[string]time.Time
+	availablePairs  map[string][]dia.ExchangePair
+	fiatQuotations  []FiatQuotation
+	fiatCache       map[string]FiatQuotation
+	pools           []dia.Pool
+	candles         map[string]dia.Candle
+	activeBridges   []dia.AssetBridge
+	interestRates   map[string][]InterestRate
+	foreignQuotes   []ForeignQuotation
+	stockQuotations []StockQuotation
+	vwapFirefly     map[string][]memoryValue
+	currencyChange  *Change
+
+	diaTotalSupply       float64
+	diaCirculatingSupply float64
+}
+
+// memoryFilterPoint is a filter value as stored in the filters measurement.
+type memoryFilterPoint struct {
+	filter   string
+	asset    dia.Asset
+	exchange string
+	value    float64
+	time     time.Time
+	// rejectedSources is only set in the rejected sources table.
+	rejectedSources []string
+}
+
+// memoryFilterKey identifies a point in the filters measurement. As in influx, a point with the key
+// of a stored point overwrites it.
+type memoryFilterKey struct {
+	filter   string
+	asset    dia.Asset
+	exchange string
+	time     int64
+}
+
+func (fp memoryFilterPoint) key() memoryFilterKey {
+	return memoryFilterKey{filter: fp.filter, asset: fp.asset, exchange: fp.exchange, time: fp.time.UnixNano()}
+}
+
+type memoryValue struct {
+	value float64
+	time  time.Time
+}
+
+// NewMemoryDataStore returns an empty in-memory datastore.
+func NewMemoryDataStore() *MemoryDB {
+	return &MemoryDB{
+		trades:         make(map[string][]dia.Trade),
+		filters:        make(map[string][]memoryFilterPoint),
+		filterIndex:    make(map[string]map[memoryFilterKey]int),
+		quotations:     make(map[string][]AssetQuotation),
+		quotationCache: make(map[string]AssetQuotation),
+		supplies:       make(map[string][]dia.Supply),
+		synthSupplies:  make(map[string][]dia.SynthAssetSupply),
+		lastTradeTimes: make(map[string]time.Time),
+		availablePairs: make(map[string][]dia.ExchangePair),
+		fiatCache:      make(map[string]FiatQuotation),
+		candles:        make(map[string]dia.Candle),
+		interestRates:  make(map[string][]InterestRate),
+		vwapFirefly:    make(map[string][]memoryValue),
+	}
+}
+
+func errMemoryNotSupported(method string) error {
+	return fmt.Errorf("%s is not supported by the in-memory datastore", method)
+}
+
+func memoryAssetKey(asset dia.Asset) string {
+	return asset.Blockchain + "_" + asset.Address
+}
+
+// ------------------------------------------------------------------------------
+// GENERAL
+// ------------------------------------------------------------------------------
+
+// SetInfluxClient is a no-op for the in-memory datastore.
+func (mdb *MemoryDB) SetInfluxClient(url string) {}
+
+// Flush is a no-op, all writes are immediately visible.
+func (mdb *MemoryDB) Flush() error {
+	return nil
+}
+
+// ExecuteRedisPipe is a no-op, all writes are immediately visible.
+func (mdb *MemoryDB) ExecuteRedisPipe() error {
+	return nil
+}
+
+// FlushRedisPipe is a no-op, all writes are immediately visible.
+func (mdb *MemoryDB) FlushRedisPipe() error {
+	return nil
+}
+
+// Prune removes trades, filter points and asset quotations before @before, such that long running
+// processes keep a bounded amount of data. Cached quotations are kept.
+func (mdb *MemoryDB) Prune(before time.Time) {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	for table, trades := range mdb.trades {
+		kept := trades[:0]
+		for _, t := range trades {
+			if !t.Time.Before(before) {
+				kept = append(kept, t)
+			}
+		}
+		mdb.trades[table] = kept
+	}
+	for table, points := range mdb.filters {
+		kept := points[:0]
+		for _, fp := range points {
+			if !fp.time.Before(before) {
+				kept = append(kept, fp)
+			}
+		}
+		mdb.filters[table] = kept
+		mdb.filterIndex[table] = make(map[memoryFilterKey]int)
+		for i, fp := range kept {
+			mdb.filterIndex[table][fp.key()] = i
+		}
+	}
+	for key, quotations := range mdb.quotations {
+		kept := quotations[:0]
+		for _, aq := range quotations {
+			if !aq.Time.Before(before) {
+				kept = append(kept, aq)
+			}
+		}
+		mdb.quotations[key] = kept
+	}
+	for key, candle := range mdb.candles {
+		if candle.Time.Before(before) {
+			delete(mdb.candles, key)
+		}
+	}
+}
+
+// CopyInfluxMeasurements copies all trades resp. filters from @tableOrigin into @tableDestination
+// in the time-range (@timeInit, @timeFinal]. Database names are ignored.
+func (mdb *MemoryDB) CopyInfluxMeasurements(dbOrigin string, dbDestination string, tableOrigin string, tableDestination string, timeInit time.Time, timeFinal time.Time) (numCopiedRows int64, err error) {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	for _, t := range mdb.trades[tableOrigin] {
+		if t.Time.After(timeInit) && !t.Time.After(timeFinal) {
+			mdb.trades[tableDestination] = append(mdb.trades[tableDestination], t)
+			numCopiedRows++
+		}
+	}
+	for _, fp := range mdb.filters[tableOrigin] {
+		if fp.time.After(timeInit) && !fp.time.After(timeFinal) {
+			mdb.addFilterPoint(tableDestination, fp)
+			numCopiedRows++
+		}
+	}
+	return
+}
+
+// ------------------------------------------------------------------------------
+// TRADES
+// ------------------------------------------------------------------------------
+
+// SaveTradeInflux stores a trade in the trades table.
+func (mdb *MemoryDB) SaveTradeInflux(t *dia.Trade) error {
+	return mdb.SaveTradeInfluxToTable(t, influxDbTradesTable)
+}
+
+// SaveTradeInfluxToTable stores a trade in @table.
+func (mdb *MemoryDB) SaveTradeInfluxToTable(t *dia.Trade, table string) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.trades[table] = append(mdb.trades[table], *t)
+	return nil
+}
+
+// selectTrades returns all trades from @table for which @match returns true, sorted by time in
+// ascending order or in descending order if @desc is true.
+func (mdb *MemoryDB) selectTrades(table string, match func(t dia.Trade) bool, desc bool) []dia.Trade {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var trades []dia.Trade
+	for _, t := range mdb.trades[table] {
+		if match(t) {
+			trades = append(trades, t)
+		}
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		if desc {
+			return trades[i].Time.After(trades[j].Time)
+		}
+		return trades[i].Time.Before(trades[j].Time)
+	})
+	return trades
+}
+
+func isQuoteToken(t dia.Trade, address string, blockchain string) bool {
+	return t.QuoteToken.Address == address && t.QuoteToken.Blockchain == blockchain
+}
+
+// GetTradeInflux returns the latest trade of @asset on @exchange in the time-range [endtime-window, endtime).
+func (mdb *MemoryDB) GetTradeInflux(asset dia.Asset, exchange string, endtime time.Time, window time.Duration) (*dia.Trade, error) {
+	starttime := endtime.Add(-window)
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		return isQuoteToken(t, asset.Address, asset.Blockchain) &&
+			(exchange == "" || t.Source == exchange) &&
+			!t.Time.Before(starttime) && t.Time.Before(endtime)
+	}, true)
+	if len(trades) == 0 {
+		return &dia.Trade{}, errors.New("parsing trade from database")
+	}
+	return &trades[0], nil
+}
+
+// GetOldTradesFromInflux returns all trades from @table done on @exchange in [@timeInit, @timeFinal).
+// If @verified is false, address and blockchain of both assets are omitted as in DB.
+func (mdb *MemoryDB) GetOldTradesFromInflux(table string, exchange string, verified bool, timeInit, timeFinal time.Time) ([]dia.Trade, error) {
+	trades := mdb.selectTrades(table, func(t dia.Trade) bool {
+		return (exchange == "" || t.Source == exchange) && !t.Time.Before(timeInit) && t.Time.Before(timeFinal)
+	}, false)
+	if len(trades) == 0 {
+		return []dia.Trade{}, errors.New("no trades in time range")
+	}
+	if !verified {
+		for i := range trades {
+			trades[i].QuoteToken = dia.Asset{}
+			trades[i].BaseToken = dia.Asset{}
+			trades[i].VerifiedPair = false
+		}
+	}
+	return trades, nil
+}
+
+func (mdb *MemoryDB) GetTradesByExchangesAndBaseAssets(asset dia.Asset, baseassets []dia.Asset, exchanges []string, startTime, endTime time.Time, maxTrades int) ([]dia.Trade, error) {
+	return mdb.GetTradesByExchangesFull(asset, baseassets, exchanges, false, startTime, endTime, maxTrades)
+}
+
+// GetTradesByExchangesFull returns trades of @asset with positive USD price in (@startTime, @endTime].
+// As in DB, @baseassets are only taken into account if @exchanges is not empty.
+func (mdb *MemoryDB) GetTradesByExchangesFull(
+	asset dia.Asset,
+	baseassets []dia.Asset,
+	exchanges []string,
+	returnBasetoken bool,
+	startTime time.Time,
+	endTime time.Time,
+	maxTrades int,
+) ([]dia.Trade, error) {
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		if !isQuoteToken(t, asset.Address, asset.Blockchain) || t.EstimatedUSDPrice <= 0 {
+			return false
+		}
+		if !t.Time.After(startTime) || t.Time.After(endTime) {
+			return false
+		}
+		if len(exchanges) > 0 {
+			if !utils.Contains(&exchanges, t.Source) {
+				return false
+			}
+			if len(baseassets) > 0 {
+				var found bool
+				for _, baseasset := range baseassets {
+					if t.BaseToken.Address == baseasset.Address && t.BaseToken.Blockchain == baseasset.Blockchain {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+		return true
+	}, maxTrades > 0)
+	if maxTrades > 0 && len(trades) > maxTrades {
+		trades = trades[:maxTrades]
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("no trades found")
+	}
+	if !returnBasetoken {
+		for i := range trades {
+			trades[i].BaseToken.Address = ""
+			trades[i].BaseToken.Blockchain = ""
+		}
+	}
+	return trades, nil
+}
+
+// GetTradesByExchangesBatched returns the trades for all time-ranges [startTimes[i], endTimes[i]].
+func (mdb *MemoryDB) GetTradesByExchangesBatched(
+	asset dia.Asset,
+	baseassets []dia.Asset,
+	exchanges []string,
+	startTimes []time.Time,
+	endTimes []time.Time,
+	maxTrades int,
+) ([]dia.Trade, error) {
+	return mdb.GetTradesByExchangesBatchedFull(asset, baseassets, exchanges, false, startTimes, endTimes, maxTrades)
+}
+
+// GetTradesByExchangesBatchedFull returns the trades for all time-ranges [startTimes[i], endTimes[i]].
+func (mdb *MemoryDB) GetTradesByExchangesBatchedFull(
+	asset dia.Asset,
+	baseassets []dia.Asset,
+	exchanges []string,
+	returnBasetoken bool,
+	startTimes []time.Time,
+	endTimes []time.Time,
+	maxTrades int,
+) ([]dia.Trade, error) {
+	if len(startTimes) != len(endTimes) {
+		return []dia.Trade{}, errors.New("number of start times must equal number of end times")
+	}
+	var allTrades []dia.Trade
+	for i := range startTimes {
+		trades, err := mdb.GetTradesByExchangesFull(asset, baseassets, exchanges, returnBasetoken, startTimes[i], endTimes[i], maxTrades)
+		if err != nil {
+			continue
+		}
+		allTrades = append(allTrades, trades...)
+	}
+	if len(allTrades) == 0 {
+		return nil, fmt.Errorf("no trades found")
+	}
+	return allTrades, nil
+}
+
+// GetAllTrades returns at most @maxTrades trades with timestamp > @t.
+func (mdb *MemoryDB) GetAllTrades(t time.Time, maxTrades int) ([]dia.Trade, error) {
+	trades := mdb.selectTrades(influxDbTradesTable, func(trade dia.Trade) bool {
+		return trade.Time.After(t)
+	}, false)
+	if len(trades) > maxTrades {
+		trades = trades[:maxTrades]
+	}
+	return trades, nil
+}
+
+// GetLastTrades returns the last @maxTrades of @asset on @exchange within 10 days before @timestamp.
+// If exchange is empty string it returns trades from all exchanges.
+func (mdb *MemoryDB) GetLastTrades(asset dia.Asset, exchange string, timestamp time.Time, maxTrades int, fullAsset bool) ([]dia.Trade, error) {
+	starttime := timestamp.AddDate(0, 0, -10)
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		if (asset != dia.Asset{} || exchange == "") && !isQuoteToken(t, asset.Address, asset.Blockchain) {
+			return false
+		}
+		return (exchange == "" || t.Source == exchange) &&
+			t.EstimatedUSDPrice > 0 &&
+			t.Time.Before(timestamp) && t.Time.After(starttime)
+	}, true)
+	if len(trades) > maxTrades {
+		trades = trades[:maxTrades]
+	}
+	if len(trades) == 0 {
+		err := fmt.Errorf("Empty response for %s on %s", asset.Symbol, exchange)
+		log.Error(err)
+		return trades, err
+	}
+	for i := range trades {
+		trades[i].QuoteToken = asset
+		if !fullAsset {
+			trades[i].BaseToken.Address = ""
+			trades[i].BaseToken.Blockchain = ""
+		}
+	}
+	return trades, nil
+}
+
+// GetNumTradesExchange24H returns the number of trades on @exchange in the last 24 hours.
+func (mdb *MemoryDB) GetNumTradesExchange24H(exchange string) (int64, error) {
+	endtime := time.Now()
+	return mdb.GetNumTrades(exchange, "", "", endtime.AddDate(0, 0, -1), endtime)
+}
+
+// GetNumTrades returns the number of trades on @exchange for asset with @address and @blockchain in (@starttime, @endtime].
+// If @address and @blockchain are empty, it returns all trades on @exchange in the given-time range.
+func (mdb *MemoryDB) GetNumTrades(exchange string, address string, blockchain string, starttime time.Time, endtime time.Time) (int64, error) {
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		if address != "" && blockchain != "" && !isQuoteToken(t, address, blockchain) {
+			return false
+		}
+		return t.Source == exchange && t.Time.After(starttime) && !t.Time.After(endtime)
+	}, false)
+	return int64(len(trades)), nil
+}
+
+func (mdb *MemoryDB) GetNumTradesSeries(asset dia.Asset, exchange string, starttime time.Time, endtime time.Time, grouping string) ([]int64, error) {
+	return []int64{}, errMemoryNotSupported("GetNumTradesSeries")
+}
+
+// GetFirstTradeDate returns the time of the earliest trade in @table.
+func (mdb *MemoryDB) GetFirstTradeDate(table string) (time.Time, error) {
+	trades := mdb.selectTrades(table, func(t dia.Trade) bool { return true }, false)
+	if len(trades) == 0 {
+		return time.Time{}, errors.New("no trade found")
+	}
+	return trades[0].Time, nil
+}
+
+// GetActiveExchangesAndPairs returns all exchanges and pairs with at least @numTradesThreshold verified trades
+// of the asset given by @address and @blockchain in (@starttime, @endtime].
+func (mdb *MemoryDB) GetActiveExchangesAndPairs(
+	address string,
+	blockchain string,
+	numTradesThreshold int64,
+	starttime time.Time,
+	endtime time.Time,
+) (map[string][]dia.Pair, map[string]int64, error) {
+	exchangepairmap := make(map[string][]dia.Pair)
+	pairCountTradesMap := make(map[string]int64)
+
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		return isQuoteToken(t, address, blockchain) && t.VerifiedPair && t.Time.After(starttime) && !t.Time.After(endtime)
+	}, false)
+
+	counts := make(map[string]int64)
+	pairs := make(map[string]dia.Pair)
+	exchanges := make(map[string]string)
+	var identifiers []string
+	for _, t := range trades {
+		pair := dia.Pair{
+			QuoteToken: dia.Asset{Blockchain: blockchain, Address: address},
+			BaseToken:  dia.Asset{Blockchain: t.BaseToken.Blockchain, Address: t.BaseToken.Address},
+		}
+		identifier := pair.PairExchangeIdentifier(t.Source)
+		if _, ok := counts[identifier]; !ok {
+			identifiers = append(identifiers, identifier)
+			pairs[identifier] = pair
+			exchanges[identifier] = t.Source
+		}
+		counts[identifier]++
+	}
+	for _, identifier := range identifiers {
+		if counts[identifier] >= numTradesThreshold {
+			exchangepairmap[exchanges[identifier]] = append(exchangepairmap[exchanges[identifier]], pairs[identifier])
+			pairCountTradesMap[identifier] = counts[identifier]
+		}
+	}
+	return exchangepairmap, pairCountTradesMap, nil
+}
+
+// GetExchangePairVolumes returns the USD volume of @asset per exchange and pair in (@starttime, @endtime].
+func (mdb *MemoryDB) GetExchangePairVolumes(asset dia.Asset, starttime time.Time, endtime time.Time) (map[string][]dia.PairVolume, error) {
+	volumeMap := make(map[string][]dia.PairVolume)
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		return isQuoteToken(t, asset.Address, asset.Blockchain) && t.Time.After(starttime) && !t.Time.After(endtime)
+	}, false)
+
+	indices := make(map[string]int)
+	for _, t := range trades {
+		pair := dia.Pair{
+			QuoteToken: dia.Asset{Blockchain: asset.Blockchain, Address: asset.Address},
+			BaseToken:  dia.Asset{Blockchain: t.BaseToken.Blockchain, Address: t.BaseToken.Address},
+		}
+		identifier := pair.PairExchangeIdentifier(t.Source)
+		if _, ok := indices[identifier]; !ok {
+			indices[identifier] = len(volumeMap[t.Source])
+			volumeMap[t.Source] = append(volumeMap[t.Source], dia.PairVolume{Pair: pair})
+		}
+		volumeMap[t.Source][indices[identifier]].Volume += math.Abs(t.EstimatedUSDPrice * t.Volume)
+	}
+	return volumeMap, nil
+}
+
+// GetLastTradeTimeForExchange returns the time of the last trade of @asset on @exchange.
+func (mdb *MemoryDB) GetLastTradeTimeForExchange(asset dia.Asset, exchange string) (*time.Time, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	t, ok := mdb.lastTradeTimes[getKeyLastTradeTimeForExchange(asset, exchange)]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return &t, nil
+}
+
+// SetLastTradeTimeForExchange stores the time of the last trade of @asset on @exchange with precision of seconds.
+func (mdb *MemoryDB) SetLastTradeTimeForExchange(asset dia.Asset, exchange string, t time.Time) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.lastTradeTimes[getKeyLastTradeTimeForExchange(asset, exchange)] = time.Unix(t.Unix(), 0)
+	return nil
+}
+
+// GetSymbols returns the symbols of all assets with a FilterKing value on @exchange.
+// If @exchange is empty, symbols with a value across all exchanges are returned.
+func (mdb *MemoryDB) GetSymbols(exchange string) ([]string, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var result []string
+	seen := make(map[string]struct{})
+	for _, fp := range mdb.filters[influxDbFiltersTable] {
+		if fp.filter != dia.FilterKing || fp.exchange != exchange {
+			continue
+		}
+		if _, ok := seen[fp.asset.Symbol]; !ok {
+			seen[fp.asset.Symbol] = struct{}{}
+			result = append(result, fp.asset.Symbol)
+		}
+	}
+	return result, nil
+}
+
+// SetAvailablePairs stores the available pairs of @exchange.
+func (mdb *MemoryDB) SetAvailablePairs(exchange string, pairs []dia.ExchangePair) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.availablePairs[exchange] = pairs
+	return nil
+}
+
+// GetAvailablePairs returns the available pairs of @exchange.
+func (mdb *MemoryDB) GetAvailablePairs(exchange string) ([]dia.ExchangePair, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	pairs, ok := mdb.availablePairs[exchange]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return pairs, nil
+}
+
+// ------------------------------------------------------------------------------
+// FILTERS
+// ------------------------------------------------------------------------------
+
+// SetFilter stores a filter point.
+func (mdb *MemoryDB) SetFilter(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return mdb.SaveFilterInflux(filter, asset, exchange, value, t)
+}
+
+// SaveFilterInflux stores a filter point in the filters table.
+func (mdb *MemoryDB) SaveFilterInflux(filter string, asset dia.Asset, exchange string, value float64, t time.Time) error {
+	return mdb.SaveFilterInfluxToTable(filter, asset, exchange, value, t, influxDbFiltersTable)
+}
+
+// SaveFilterInfluxToTable stores a filter point in @table.
+func (mdb *MemoryDB) SaveFilterInfluxToTable(filter string, asset dia.Asset, exchange string, value float64, t time.Time, table string) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.addFilterPoint(table, memoryFilterPoint{
+		filter:   filter,
+		asset:    asset,
+		exchange: exchange,
+		value:    value,
+		time:     t,
+	})
+	return nil
+}
+
+// addFilterPoint adds @fp to @table or overwrites the stored point with the same key.
+func (mdb *MemoryDB) addFilterPoint(table string, fp memoryFilterPoint) {
+	index, ok := mdb.filterIndex[table]
+	if !ok {
+		index = make(map[memoryFilterKey]int)
+		mdb.filterIndex[table] = index
+	}
+	if i, ok := index[fp.key()]; ok {
+		mdb.filters[table][i] = fp
+		return
+	}
+	index[fp.key()] = len(mdb.filters[table])
+	mdb.filters[table] = append(mdb.filters[table], fp)
+}
+
+// SetRejectedSources stores the @sources that were discarded by @filter at time @t.
+func (mdb *MemoryDB) SetRejectedSources(filter string, asset dia.Asset, exchange string, sources []string, t time.Time) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.addFilterPoint(influxDbRejectedSourcesTable, memoryFilterPoint{
+		filter:          filter,
+		asset:           asset,
+		exchange:        exchange,
+		time:            t,
+		rejectedSources: append([]string(nil), sources...),
+	})
+	return nil
+}
+
+// GetRejectedSources returns the filter points of @filter for @asset on @exchange in (@starttime, @endtime]
+// at which sources were rejected, sorted by time in descending order.
+func (mdb *MemoryDB) GetRejectedSources(filter string, asset dia.Asset, exchange string, starttime time.Time, endtime time.Time) ([]dia.FilterPoint, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var filterPoints []dia.FilterPoint
+	for _, fp := range mdb.filters[influxDbRejectedSourcesTable] {
+		if fp.filter != filter || fp.exchange != exchange ||
+			fp.asset.Address != asset.Address || fp.asset.Blockchain != asset.Blockchain ||
+			!fp.time.After(starttime) || fp.time.After(endtime) {
+			continue
+		}
+		filterPoints = append(filterPoints, dia.FilterPoint{
+			Name:            fp.filter,
+			Asset:           fp.asset,
+			Time:            fp.time,
+			Source:          fp.exchange,
+			RejectedSources: append([]string(nil), fp.rejectedSources...),
+		})
+	}
+	sort.SliceStable(filterPoints, func(i, j int) bool {
+		return filterPoints[i].Time.After(filterPoints[j].Time)
+	})
+	return filterPoints, nil
+}
+
+// selectFilterPoints returns all filter points for which @match returns true, sorted by time in descending order.
+func (mdb *MemoryDB) selectFilterPoints(match func(fp memoryFilterPoint) bool) []memoryFilterPoint {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var points []memoryFilterPoint
+	for _, fp := range mdb.filters[influxDbFiltersTable] {
+		if match(fp) {
+			points = append(points, fp)
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].time.After(points[j].time)
+	})
+	return points
+}
+
+// filterPointsResult returns @points as influx result with the given @columns.
+func filterPointsResult(points []memoryFilterPoint, columns []string) []clientInfluxdb.Result {
+	if len(points) == 0 {
+		return []clientInfluxdb.Result{{}}
+	}
+	row := influxModels.Row{Name: influxDbFiltersTable, Columns: columns}
+	for _, fp := range points {
+		var values []interface{}
+		for _, column := range columns {
+			switch column {
+			case "time":
+				values = append(values, fp.time.UTC().Format(time.RFC3339Nano))
+			case "address":
+				values = append(values, fp.asset.Address)
+			case "blockchain":
+				values = append(values, fp.asset.Blockchain)
+			case "exchange":
+				values = append(values, fp.exchange)
+			case "filter":
+				values = append(values, fp.filter)
+			case "symbol":
+				values = append(values, fp.asset.Symbol)
+			case "value":
+				values = append(values, json.Number(strconv.FormatFloat(fp.value, 'f', -1, 64)))
+			}
+		}
+		row.Values = append(row.Values, values)
+	}
+	return []clientInfluxdb.Result{{Series: []influxModels.Row{row}}}
+}
+
+// GetFilterPointsAsset returns filter points of the asset given by @address and @blockchain on @exchange
+// in (@starttime, @endtime] in the same format as DB.
+func (mdb *MemoryDB) GetFilterPointsAsset(filter string, exchange string, address string, blockchain string, starttime time.Time, endtime time.Time) (*Points, error) {
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == filter && fp.exchange == exchange &&
+			fp.asset.Address == address && fp.asset.Blockchain == blockchain &&
+			fp.time.After(starttime) && !fp.time.After(endtime)
+	})
+	return &Points{
+		DataPoints: filterPointsResult(points, []string{"time", "address", "blockchain", "exchange", "filter", "symbol", "value"}),
+	}, nil
+}
+
+// GetFilterPoints returns filter points of the asset with @symbol and the largest volume in (@starttime, @endtime).
+// Downsampled tables given by @scale are not supported.
+func (mdb *MemoryDB) GetFilterPoints(filter string, exchange string, symbol string, scale string, starttime time.Time, endtime time.Time) (*Points, error) {
+	if scale != "" {
+		return &Points{}, errMemoryNotSupported("GetFilterPoints with scale")
+	}
+	topAsset, err := mdb.GetTopAssetByVolume(symbol, nil)
+	if err != nil {
+		return &Points{}, err
+	}
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == filter && fp.exchange == exchange &&
+			fp.asset.Address == topAsset.Address && fp.asset.Blockchain == topAsset.Blockchain &&
+			fp.time.After(starttime) && fp.time.Before(endtime)
+	})
+	return &Points{
+		DataPoints: filterPointsResult(points, []string{"time", "exchange", "filter", "symbol", "value"}),
+	}, nil
+}
+
+// GetFilterAllExchanges returns the most recent value of @filter in the given time-range for each exchange
+// the asset given by @address and @blockchain has a filter value on.
+func (mdb *MemoryDB) GetFilterAllExchanges(
+	filter string,
+	address string,
+	blockchain string,
+	starttime time.Time,
+	endtime time.Time,
+) (assetQuotations []AssetQuotation, err error) {
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == filter && fp.exchange != "" &&
+			fp.asset.Address == address && fp.asset.Blockchain == blockchain &&
+			fp.time.After(starttime) && !fp.time.After(endtime)
+	})
+	seen := make(map[string]struct{})
+	for _, fp := range points {
+		if _, ok := seen[fp.exchange]; ok {
+			continue
+		}
+		seen[fp.exchange] = struct{}{}
+		assetQuotations = append(assetQuotations, AssetQuotation{
+			Asset:  dia.Asset{Address: address, Blockchain: blockchain, Symbol: fp.asset.Symbol},
+			Price:  fp.value,
+			Source: fp.exchange,
+			Time:   fp.time,
+		})
+	}
+	sort.Slice(assetQuotations, func(i, j int) bool {
+		return assetQuotations[i].Source < assetQuotations[j].Source
+	})
+	return
+}
+
+// GetLastPriceBefore mirrors DB.GetLastPriceBefore which returns the first value of @filter after @timestamp.
+func (mdb *MemoryDB) GetLastPriceBefore(asset dia.Asset, filter string, exchange string, timestamp time.Time) (Price, error) {
+	price := Price{
+		Symbol: asset.Symbol,
+		Name:   helpers.NameForSymbol(asset.Symbol),
+	}
+	now := time.Now()
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == filter && fp.exchange == exchange &&
+			fp.asset.Address == asset.Address && fp.asset.Blockchain == asset.Blockchain &&
+			fp.time.After(timestamp) && fp.time.Before(now)
+	})
+	if len(points) == 0 {
+		log.Errorln("Empty response GetLastFilterPointBefore")
+		return price, nil
+	}
+	price.Price = points[len(points)-1].value
+	price.Time = points[len(points)-1].time
+	return price, nil
+}
+
+// ------------------------------------------------------------------------------
+// VOLUMES
+// ------------------------------------------------------------------------------
+
+// GetVolumeInflux returns the sum of all volume filter values of @asset on @exchange in (@starttime, @endtime].
+// If @asset is empty, the volume of all assets on @exchange is returned.
+func (mdb *MemoryDB) GetVolumeInflux(asset dia.Asset, exchange string, starttime time.Time, endtime time.Time) (*float64, error) {
+	if endtime.IsZero() {
+		endtime = time.Now()
+		starttime = endtime.AddDate(0, 0, -1)
+	}
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		if fp.filter != volumeKey || fp.exchange != exchange || !fp.time.After(starttime) || fp.time.After(endtime) {
+			return false
+		}
+		return asset == (dia.Asset{}) || (fp.asset.Address == asset.Address && fp.asset.Blockchain == asset.Blockchain)
+	})
+	var volume float64
+	for _, fp := range points {
+		volume += fp.value
+	}
+	return &volume, nil
+}
+
+func (mdb *MemoryDB) Get24HoursAssetVolume(asset dia.Asset) (*float64, error) {
+	endtime := time.Now()
+	return mdb.GetVolumeInflux(asset, "", endtime.AddDate(0, 0, -1), endtime)
+}
+
+func (mdb *MemoryDB) Get24HoursExchangeVolume(exchange string) (*float64, error) {
+	endtime := time.Now()
+	return mdb.GetVolumeInflux(dia.Asset{}, exchange, endtime.AddDate(0, 0, -1), endtime)
+}
+
+// GetVolumesAllExchanges returns the volume of @asset on each exchange in (@starttime, @endtime].
+func (mdb *MemoryDB) GetVolumesAllExchanges(asset dia.Asset, starttime time.Time, endtime time.Time) (exchVolumes dia.ExchangeVolumesList, err error) {
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == volumeKey && fp.exchange != "" &&
+			fp.asset.Address == asset.Address && fp.asset.Blockchain == asset.Blockchain &&
+			fp.time.After(starttime) && !fp.time.After(endtime)
+	})
+	volumes := make(map[string]float64)
+	for _, fp := range points {
+		volumes[fp.exchange] += fp.value
+	}
+	for exchange, volume := range volumes {
+		exchVolumes.Volumes = append(exchVolumes.Volumes, dia.ExchangeVolume{Exchange: exchange, Volume: volume})
+	}
+	sort.Slice(exchVolumes.Volumes, func(i, j int) bool {
+		return exchVolumes.Volumes[i].Exchange < exchVolumes.Volumes[j].Exchange
+	})
+	if len(exchVolumes.Volumes) > 0 {
+		exchVolumes.Timestamp = starttime
+	}
+	return
+}
+
+// GetAssetsWithVOLInflux returns all assets with a volume value across all exchanges after @timeInit.
+func (mdb *MemoryDB) GetAssetsWithVOLInflux(timeInit time.Time) ([]dia.Asset, error) {
+	points := mdb.selectFilterPoints(func(fp memoryFilterPoint) bool {
+		return fp.filter == volumeKey && fp.exchange == "" && fp.time.After(timeInit)
+	})
+	var assets []dia.Asset
+	seen := make(map[string]struct{})
+	for _, fp := range points {
+		if _, ok := seen[memoryAssetKey(fp.asset)]; !ok {
+			seen[memoryAssetKey(fp.asset)] = struct{}{}
+			assets = append(assets, fp.asset)
+		}
+	}
+	return assets, nil
+}
+
+// ------------------------------------------------------------------------------
+// ASSET QUOTATIONS
+// ------------------------------------------------------------------------------
+
+// SetAssetPriceUSD stores the price of @asset as quotation by DIA.
+func (mdb *MemoryDB) SetAssetPriceUSD(asset dia.Asset, price float64, timestamp time.Time) error {
+	return mdb.SetAssetQuotation(&AssetQuotation{
+		Asset:  asset,
+		Price:  price,
+		Source: dia.Diadata,
+		Time:   timestamp,
+	})
+}
+
+// GetAssetPriceUSDLatest returns the latest price of @asset.
+func (mdb *MemoryDB) GetAssetPriceUSDLatest(asset dia.Asset) (price float64, err error) {
+	quotation, err := mdb.GetAssetQuotationLatest(asset)
+	if err != nil {
+		return
+	}
+	price = quotation.Price
+	return
+}
+
+// GetAssetPriceUSD returns the latest USD price of @asset before @timestamp.
+func (mdb *MemoryDB) GetAssetPriceUSD(asset dia.Asset, timestamp time.Time) (price float64, err error) {
+	quotation, err := mdb.GetAssetQuotation(asset, timestamp)
+	if err != nil {
+		return
+	}
+	price = quotation.Price
+	return
+}
+
+// AddAssetQuotationsToBatch stores @quotations without updating the cache.
+func (mdb *MemoryDB) AddAssetQuotationsToBatch(quotations []*AssetQuotation) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	for _, quotation := range quotations {
+		key := memoryAssetKey(quotation.Asset)
+		mdb.quotations[key] = append(mdb.quotations[key], *quotation)
+	}
+	return nil
+}
+
+// SetAssetQuotation stores @quotation and writes it to the cache.
+func (mdb *MemoryDB) SetAssetQuotation(quotation *AssetQuotation) error {
+	err := mdb.AddAssetQuotationsToBatch([]*AssetQuotation{quotation})
+	if err != nil {
+		return err
+	}
+	_, err = mdb.SetAssetQuotationCache(quotation, false)
+	return err
+}
+
+// GetAssetQuotationLatest returns the latest full quotation for @asset.
+func (mdb *MemoryDB) GetAssetQuotationLatest(asset dia.Asset) (*AssetQuotation, error) {
+	quotation, err := mdb.GetAssetQuotationCache(asset)
+	if err == nil {
+		return quotation, nil
+	}
+	return mdb.GetAssetQuotation(asset, time.Now())
+}
+
+// selectAssetQuotations returns all quotations of @asset for which @match returns true, sorted by time in descending order.
+func (mdb *MemoryDB) selectAssetQuotations(asset dia.Asset, match func(aq AssetQuotation) bool) []AssetQuotation {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var quotations []AssetQuotation
+	for _, aq := range mdb.quotations[memoryAssetKey(asset)] {
+		if match(aq) {
+			quotations = append(quotations, aq)
+		}
+	}
+	sort.SliceStable(quotations, func(i, j int) bool {
+		return quotations[i].Time.After(quotations[j].Time)
+	})
+	return quotations
+}
+
+// GetAssetQuotation returns the latest full quotation for @asset before @timestamp.
+func (mdb *MemoryDB) GetAssetQuotation(asset dia.Asset, timestamp time.Time) (*AssetQuotation, error) {
+	quotations := mdb.selectAssetQuotations(asset, func(aq AssetQuotation) bool {
+		return !aq.Time.After(timestamp)
+	})
+	if len(quotations) == 0 {
+		return &AssetQuotation{}, errors.New("no assetQuotation in DB")
+	}
+	quotation := quotations[0]
+	quotation.Asset = asset
+	quotation.Source = dia.Diadata
+	return &quotation, nil
+}
+
+// GetAssetQuotations returns all assetQuotations for @asset in (@starttime, @endtime].
+func (mdb *MemoryDB) GetAssetQuotations(asset dia.Asset, starttime time.Time, endtime time.Time) ([]AssetQuotation, error) {
+	quotations := mdb.selectAssetQuotations(asset, func(aq AssetQuotation) bool {
+		return aq.Time.After(starttime) && !aq.Time.After(endtime)
+	})
+	if len(quotations) == 0 {
+		return []AssetQuotation{}, errors.New("no assetQuotation in DB")
+	}
+	for i := range quotations {
+		quotations[i].Asset = asset
+		quotations[i].Source = dia.Diadata
+	}
+	return quotations, nil
+}
+
+// SetAssetQuotationCache stores @quotation in the cache.
+// If @check is true, it is only written if there is no more recent quotation in the cache.
+func (mdb *MemoryDB) SetAssetQuotationCache(quotation *AssetQuotation, check bool) (bool, error) {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	key := memoryAssetKey(quotation.Asset)
+	if check {
+		if cachestate, ok := mdb.quotationCache[key]; ok && quotation.Time.Before(cachestate.Time) {
+			return false, nil
+		}
+	}
+	mdb.quotationCache[key] = *quotation
+	return true, nil
+}
+
+// GetAssetQuotationCache returns the latest quotation for @asset from the cache.
+func (mdb *MemoryDB) GetAssetQuotationCache(asset dia.Asset) (*AssetQuotation, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	quotation, ok := mdb.quotationCache[memoryAssetKey(asset)]
+	if !ok {
+		return &AssetQuotation{}, redis.Nil
+	}
+	return &quotation, nil
+}
+
+// GetAssetPriceUSDCache returns the latest price of @asset from the cache.
+func (mdb *MemoryDB) GetAssetPriceUSDCache(asset dia.Asset) (price float64, err error) {
+	quotation, err := mdb.GetAssetQuotationCache(asset)
+	if err != nil {
+		return
+	}
+	price = quotation.Price
+	return
+}
+
+// GetSortedAssetQuotations returns quotations for all assets in @assets, sorted by 24h volume
+// in descending order.
+func (mdb *MemoryDB) GetSortedAssetQuotations(assets []dia.Asset) ([]AssetQuotation, error) {
+	var quotations []AssetQuotation
+	var volumes []float64
+	for _, asset := range assets {
+		quotation, err := mdb.GetAssetQuotationLatest(asset)
+		if err != nil {
+			continue
+		}
+		volume, err := mdb.Get24HoursAssetVolume(asset)
+		if err != nil {
+			continue
+		}
+		quotations = append(quotations, *quotation)
+		volumes = append(volumes, *volume)
+	}
+	if len(quotations) == 0 {
+		return quotations, errors.New("no quotations available")
+	}
+	indices := make([]int, len(quotations))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return volumes[indices[i]] > volumes[indices[j]]
+	})
+	var quotationsSorted []AssetQuotation
+	for _, i := range indices {
+		quotationsSorted = append(quotationsSorted, quotations[i])
+	}
+	return quotationsSorted, nil
+}
+
+// GetAssetsMarketCap returns the actual market cap of @asset.
+func (mdb *MemoryDB) GetAssetsMarketCap(asset dia.Asset) (float64, error) {
+	price, err := mdb.GetAssetPriceUSDLatest(asset)
+	if err != nil {
+		return 0, err
+	}
+	supply, err := mdb.GetSupplyCache(asset)
+	if err != nil {
+		return 0, err
+	}
+	return price * supply.CirculatingSupply, nil
+}
+
+// assetsBySymbol returns all assets with @symbol that have a quotation or a filter value.
+func (mdb *MemoryDB) assetsBySymbol(symbol string) []dia.Asset {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var assets []dia.Asset
+	seen := make(map[string]struct{})
+	add := func(asset dia.Asset) {
+		if asset.Symbol != symbol {
+			return
+		}
+		if _, ok := seen[memoryAssetKey(asset)]; !ok {
+			seen[memoryAssetKey(asset)] = struct{}{}
+			assets = append(assets, asset)
+		}
+	}
+	for _, quotations := range mdb.quotations {
+		for _, aq := range quotations {
+			add(aq.Asset)
+		}
+	}
+	for _, fp := range mdb.filters[influxDbFiltersTable] {
+		add(fp.asset)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return memoryAssetKey(assets[i]) < memoryAssetKey(assets[j])
+	})
+	return assets
+}
+
+// GetTopAssetByVolume returns the asset with highest overall volume among all assets with symbol @symbol.
+// In contrast to DB, assets are taken from the in-memory datastore and @relDB is ignored.
+func (mdb *MemoryDB) GetTopAssetByVolume(symbol string, relDB *RelDB) (topAsset dia.Asset, err error) {
+	assets := mdb.assetsBySymbol(symbol)
+	if len(assets) == 0 {
+		err = errors.New("no matching asset")
+		return
+	}
+	volume := -1.0
+	for _, asset := range assets {
+		value, _ := mdb.GetVolumeInflux(asset, "", time.Time{}.Add(1), time.Unix(math.MaxInt32, 0))
+		if *value > volume {
+			volume = *value
+			topAsset = asset
+		}
+	}
+	return
+}
+
+// GetTopAssetByMcap returns the asset with highest market cap among all assets with symbol @symbol.
+// In contrast to DB, assets are taken from the in-memory datastore and @relDB is ignored.
+func (mdb *MemoryDB) GetTopAssetByMcap(symbol string, relDB *RelDB) (topAsset dia.Asset, err error) {
+	assets := mdb.assetsBySymbol(symbol)
+	if len(assets) == 0 {
+		err = errors.New("no matching asset")
+		return
+	}
+	var mcap float64
+	var found bool
+	for _, asset := range assets {
+		value, errMcap := mdb.GetAssetsMarketCap(asset)
+		if errMcap != nil {
+			continue
+		}
+		if !found || value > mcap {
+			mcap = value
+			topAsset = asset
+			found = true
+		}
+	}
+	if !found {
+		err = errors.New("no market cap available")
+	}
+	return
+}
+
+// ------------------------------------------------------------------------------
+// SUPPLIES
+// ------------------------------------------------------------------------------
+
+// SetSupply stores @supply.
+func (mdb *MemoryDB) SetSupply(supply *dia.Supply) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	key := memoryAssetKey(supply.Asset)
+	mdb.supplies[key] = append(mdb.supplies[key], *supply)
+	return nil
+}
+
+// GetSupplyCache returns the latest supply of @asset.
+func (mdb *MemoryDB) GetSupplyCache(asset dia.Asset) (dia.Supply, error) {
+	supplies, err := mdb.GetSupplyInflux(asset, time.Time{}, time.Time{})
+	if err != nil {
+		return dia.Supply{}, redis.Nil
+	}
+	return supplies[0], nil
+}
+
+// GetSupplyInflux returns all supplies of @asset in (@starttime, @endtime) in descending order.
+// If one of the times is zero, only the latest supply is returned.
+func (mdb *MemoryDB) GetSupplyInflux(asset dia.Asset, starttime time.Time, endtime time.Time) ([]dia.Supply, error) {
+	latest := starttime.IsZero() || endtime.IsZero()
+	mdb.mu.RLock()
+	var supplies []dia.Supply
+	for _, supply := range mdb.supplies[memoryAssetKey(asset)] {
+		if latest || (supply.Time.After(starttime) && supply.Time.Before(endtime)) {
+			supplies = append(supplies, supply)
+		}
+	}
+	mdb.mu.RUnlock()
+	sort.SliceStable(supplies, func(i, j int) bool {
+		return supplies[i].Time.After(supplies[j].Time)
+	})
+	if latest && len(supplies) > 1 {
+		supplies = supplies[:1]
+	}
+	if len(supplies) == 0 {
+		return []dia.Supply{}, errors.New("no supply found")
+	}
+	return supplies, nil
+}
+
+// GetSupply returns the supplies of the asset with @symbol and the largest volume.
+// In contrast to DB, @relDB is ignored.
+func (mdb *MemoryDB) GetSupply(symbol string, starttime, endtime time.Time, relDB *RelDB) ([]dia.Supply, error) {
+	topAsset, err := mdb.GetTopAssetByVolume(symbol, relDB)
+	if err != nil {
+		return []dia.Supply{}, err
+	}
+	return mdb.GetSupplyInflux(topAsset, starttime, endtime)
+}
+
+// GetLatestSupply returns the latest supply of the asset with @symbol and the largest volume.
+func (mdb *MemoryDB) GetLatestSupply(symbol string, relDB *RelDB) (*dia.Supply, error) {
+	supplies, err := mdb.GetSupply(symbol, time.Time{}, time.Time{}, relDB)
+	if err != nil {
+		return &dia.Supply{}, err
+	}
+	return &supplies[0], nil
+}
+
+func (mdb *MemoryDB) SetDiaTotalSupply(totalSupply float64) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.diaTotalSupply = totalSupply
+	return nil
+}
+
+func (mdb *MemoryDB) GetDiaTotalSupply() (float64, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	return mdb.diaTotalSupply, nil
+}
+
+func (mdb *MemoryDB) SetDiaCirculatingSupply(circulatingSupply float64) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.diaCirculatingSupply = circulatingSupply
+	return nil
+}
+
+func (mdb *MemoryDB) GetDiaCirculatingSupply() (float64, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	return mdb.diaCirculatingSupply, nil
+}
+
+func (mdb *MemoryDB) SaveSynthSupplyInflux(t *dia.SynthAssetSupply) error {
+	return mdb.SaveSynthSupplyInfluxToTable(t, influxDbSynthSupplyTable)
+}
+
+func (mdb *MemoryDB) SaveSynthSupplyInfluxToTable(t *dia.SynthAssetSupply, table string) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.synthSupplies[table] = append(mdb.synthSupplies[table], *t)
+	return nil
+}
+
+// GetSynthSupplyInflux returns at most @limit synth supplies in (@starttime, @endtime] in descending order.
+// @protocol and the underlying's @address are optional.
+func (mdb *MemoryDB) GetSynthSupplyInflux(blockchain, protocol, address string, limit int, starttime, endtime time.Time) ([]dia.SynthAssetSupply, error) {
+	mdb.mu.RLock()
+	var r []dia.SynthAssetSupply
+	for _, s := range mdb.synthSupplies[influxDbSynthSupplyTable] {
+		if s.Asset.Blockchain != blockchain || (protocol != "" && s.Protocol != protocol) {
+			continue
+		}
+		if address != "" && address != "0x0000000000000000000000000000000000000000" && s.AssetUnderlying.Address != address {
+			continue
+		}
+		if s.Time.After(starttime) && !s.Time.After(endtime) {
+			r = append(r, s)
+		}
+	}
+	mdb.mu.RUnlock()
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].Time.After(r[j].Time)
+	})
+	if limit > 0 && len(r) > limit {
+		r = r[:limit]
+	}
+	return r, nil
+}
+
+// GetSynthAssets returns the addresses of all synth assets of @protocol on @blockchain.
+func (mdb *MemoryDB) GetSynthAssets(blockchain, protocol string) (r []string, err error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	seen := make(map[string]struct{})
+	for _, s := range mdb.synthSupplies[influxDbSynthSupplyTable] {
+		if s.Asset.Blockchain != blockchain || s.Protocol != protocol {
+			continue
+		}
+		if _, ok := seen[s.Asset.Address]; !ok {
+			seen[s.Asset.Address] = struct{}{}
+			r = append(r, s.Asset.Address)
+		}
+	}
+	if len(r) == 0 {
+		err = fmt.Errorf("Empty response for")
+	}
+	return
+}
+
+// ------------------------------------------------------------------------------
+// FIAT, POOLS, RATES AND FOREIGN QUOTATIONS
+// ------------------------------------------------------------------------------
+
+func (mdb *MemoryDB) SetBatchFiatPriceInflux(fqs []*FiatQuotation) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	for _, fq := range fqs {
+		mdb.fiatQuotations = append(mdb.fiatQuotations, *fq)
+	}
+	return nil
+}
+
+func (mdb *MemoryDB) SetSingleFiatPriceRedis(fiatQuotation *FiatQuotation) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.fiatCache[fiatQuotation.QuoteCurrency+"_"+fiatQuotation.BaseCurrency] = *fiatQuotation
+	return nil
+}
+
+func (mdb *MemoryDB) SetCurrencyChange(cc *Change) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.currencyChange = cc
+	return nil
+}
+
+func (mdb *MemoryDB) GetCurrencyChange() (*Change, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	if mdb.currencyChange == nil {
+		return &Change{}, redis.Nil
+	}
+	return mdb.currencyChange, nil
+}
+
+// SetActiveAssetBridges stores @bridges as the bridging table used by the tradesBlockService.
+func (mdb *MemoryDB) SetActiveAssetBridges(bridges []dia.AssetBridge) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.activeBridges = append([]dia.AssetBridge{}, bridges...)
+	return nil
+}
+
+// GetActiveAssetBridges returns the bridging table used by the tradesBlockService.
+// redis.Nil is returned if no table is stored.
+func (mdb *MemoryDB) GetActiveAssetBridges() ([]dia.AssetBridge, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	if mdb.activeBridges == nil {
+		return []dia.AssetBridge{}, redis.Nil
+	}
+	return append([]dia.AssetBridge{}, mdb.activeBridges...), nil
+}
+
+// GetCandles returns the candles of @asset with @resolution in [@starttime, @endtime) computed from the
+// trades on @exchanges against @baseassets.
+func (mdb *MemoryDB) GetCandles(
+	asset dia.Asset,
+	baseassets []dia.Asset,
+	exchanges []string,
+	resolution string,
+	nativePrice bool,
+	starttime time.Time,
+	endtime time.Time,
+) ([]dia.Candle, error) {
+	if nativePrice && len(baseassets) != 1 {
+		return []dia.Candle{}, errors.New("native prices require exactly one base asset")
+	}
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		if !isQuoteToken(t, asset.Address, asset.Blockchain) {
+			return false
+		}
+		if t.Time.Before(starttime) || !t.Time.Before(endtime) {
+			return false
+		}
+		if len(exchanges) > 0 && !utils.Contains(&exchanges, t.Source) {
+			return false
+		}
+		if len(baseassets) > 0 {
+			for _, baseasset := range baseassets {
+				if t.BaseToken.Address == baseasset.Address && t.BaseToken.Blockchain == baseasset.Blockchain {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	}, false)
+	candles, err := aggregateCandles(trades, resolution, nativePrice)
+	if err != nil {
+		return candles, err
+	}
+	if len(exchanges) == 1 {
+		for i := range candles {
+			candles[i].Exchange = exchanges[0]
+		}
+	}
+	return candles, nil
+}
+
+// ComputeCandles returns the candles with @resolution in [@starttime, @endtime) of all assets traded
+// in this time range, both per exchange and across all exchanges.
+func (mdb *MemoryDB) ComputeCandles(resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error) {
+	trades := mdb.selectTrades(influxDbTradesTable, func(t dia.Trade) bool {
+		return !t.Time.Before(starttime) && t.Time.Before(endtime)
+	}, false)
+
+	groups := make(map[string][]dia.Trade)
+	exchanges := make(map[string]string)
+	for _, t := range trades {
+		assetKey := memoryAssetKey(t.QuoteToken)
+		groups[assetKey] = append(groups[assetKey], t)
+		exchangeKey := assetKey + "_" + t.Source
+		groups[exchangeKey] = append(groups[exchangeKey], t)
+		exchanges[exchangeKey] = t.Source
+	}
+	var candles []dia.Candle
+	for key, group := range groups {
+		groupCandles, err := aggregateCandles(group, resolution, false)
+		if err != nil {
+			return candles, err
+		}
+		for i := range groupCandles {
+			groupCandles[i].Exchange = exchanges[key]
+		}
+		candles = append(candles, groupCandles...)
+	}
+	return candles, nil
+}
+
+// SaveCandleInflux stores @candle. Saving a candle twice overwrites the first one.
+func (mdb *MemoryDB) SaveCandleInflux(candle dia.Candle) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.candles[memoryCandleKey(candle.Asset, candle.Exchange, candle.Resolution)+"_"+strconv.FormatInt(candle.Time.UnixNano(), 10)] = candle
+	return nil
+}
+
+// GetStoredCandles returns the stored candles of @asset on @exchange with @resolution in [@starttime, @endtime).
+func (mdb *MemoryDB) GetStoredCandles(asset dia.Asset, exchange string, resolution string, starttime time.Time, endtime time.Time) ([]dia.Candle, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	prefix := memoryCandleKey(asset, exchange, resolution) + "_"
+	candles := []dia.Candle{}
+	for key, candle := range mdb.candles {
+		if strings.HasPrefix(key, prefix) && !candle.Time.Before(starttime) && candle.Time.Before(endtime) {
+			candles = append(candles, candle)
+		}
+	}
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+	return candles, nil
+}
+
+func memoryCandleKey(asset dia.Asset, exchange string, resolution string) string {
+	return memoryAssetKey(asset) + "_" + exchange + "_" + resolution
+}
+
+func (mdb *MemoryDB) SavePoolInflux(p dia.Pool) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.pools = append(mdb.pools, p)
+	return nil
+}
+
+// GetPoolInflux returns all states of the pool with @poolAddress in [@starttime, @endtime) in descending order.
+func (mdb *MemoryDB) GetPoolInflux(poolAddress string, starttime time.Time, endtime time.Time) ([]dia.Pool, error) {
+	mdb.mu.RLock()
+	pools := []dia.Pool{}
+	for _, p := range mdb.pools {
+		if p.Address == poolAddress && !p.Time.Before(starttime) && p.Time.Before(endtime) {
+			pools = append(pools, p)
+		}
+	}
+	mdb.mu.RUnlock()
+	sort.SliceStable(pools, func(i, j int) bool {
+		return pools[i].Time.After(pools[j].Time)
+	})
+	return pools, nil
+}
+
+func (mdb *MemoryDB) SetInterestRate(ir *InterestRate) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.interestRates[ir.Symbol] = append(mdb.interestRates[ir.Symbol], *ir)
+	return nil
+}
+
+// GetInterestRate returns the latest rate of @symbol effective on or before @date given as 2006-01-02.
+func (mdb *MemoryDB) GetInterestRate(symbol, date string) (*InterestRate, error) {
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return &InterestRate{}, err
+	}
+	rates, _ := mdb.GetInterestRateRange(symbol, "0001-01-01", day.Format("2006-01-02"))
+	if len(rates) == 0 {
+		return &InterestRate{}, redis.Nil
+	}
+	return rates[len(rates)-1], nil
+}
+
+// GetInterestRateRange returns all rates of @symbol effective between @dateInit and @dateFinal, sorted by effective date.
+func (mdb *MemoryDB) GetInterestRateRange(symbol, dateInit, dateFinal string) ([]*InterestRate, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	allValues := []*InterestRate{}
+	for i := range mdb.interestRates[symbol] {
+		ir := mdb.interestRates[symbol][i]
+		effectiveDate := ir.EffectiveDate.Format("2006-01-02")
+		if effectiveDate >= dateInit && effectiveDate <= dateFinal {
+			allValues = append(allValues, &ir)
+		}
+	}
+	sort.SliceStable(allValues, func(i, j int) bool {
+		return allValues[i].EffectiveDate.Before(allValues[j].EffectiveDate)
+	})
+	return allValues, nil
+}
+
+func (mdb *MemoryDB) GetRatesMeta() ([]InterestRateMeta, error) {
+	return []InterestRateMeta{}, errMemoryNotSupported("GetRatesMeta")
+}
+
+func (mdb *MemoryDB) GetCompoundedIndex(symbol string, date time.Time, daysPerYear int, rounding int) (*InterestRate, error) {
+	return &InterestRate{}, errMemoryNotSupported("GetCompoundedIndex")
+}
+
+func (mdb *MemoryDB) GetCompoundedIndexRange(symbol string, dateInit, dateFinal time.Time, daysPerYear int, rounding int) ([]*InterestRate, error) {
+	return []*InterestRate{}, errMemoryNotSupported("GetCompoundedIndexRange")
+}
+
+func (mdb *MemoryDB) GetCompoundedAvg(symbol string, date time.Time, calDays, daysPerYear int, rounding int) (*InterestRate, error) {
+	return &InterestRate{}, errMemoryNotSupported("GetCompoundedAvg")
+}
+
+func (mdb *MemoryDB) GetCompoundedAvgRange(symbol string, dateInit, dateFinal time.Time, calDays, daysPerYear int, rounding int) ([]*InterestRate, error) {
+	return []*InterestRate{}, errMemoryNotSupported("GetCompoundedAvgRange")
+}
+
+func (mdb *MemoryDB) GetCompoundedAvgDIARange(symbol string, dateInit, dateFinal time.Time, calDays, daysPerYear int, rounding int) ([]*InterestRate, error) {
+	return []*InterestRate{}, errMemoryNotSupported("GetCompoundedAvgDIARange")
+}
+
+func (mdb *MemoryDB) SaveForeignQuotationInflux(fq ForeignQuotation) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.foreignQuotes = append(mdb.foreignQuotes, fq)
+	return nil
+}
+
+// selectForeignQuotations returns all quotations of @symbol from @source in [@starttime, @endtime) in descending order.
+func (mdb *MemoryDB) selectForeignQuotations(symbol, source string, starttime, endtime time.Time) []ForeignQuotation {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	var quotations []ForeignQuotation
+	for _, fq := range mdb.foreignQuotes {
+		if fq.Symbol == symbol && fq.Source == source && !fq.Time.Before(starttime) && fq.Time.Before(endtime) {
+			quotations = append(quotations, fq)
+		}
+	}
+	sort.SliceStable(quotations, func(i, j int) bool {
+		return quotations[i].Time.After(quotations[j].Time)
+	})
+	return quotations
+}
+
+// GetForeignQuotationInflux returns the latest quotation of @symbol from @source before @timestamp.
+func (mdb *MemoryDB) GetForeignQuotationInflux(symbol, source string, timestamp time.Time) (ForeignQuotation, error) {
+	quotations := mdb.selectForeignQuotations(symbol, source, time.Time{}, timestamp)
+	if len(quotations) == 0 {
+		return ForeignQuotation{}, errors.New("no foreign quotation found")
+	}
+	return quotations[0], nil
+}
+
+// GetForeignPriceYesterday returns the average price of @symbol from @source on the previous day.
+func (mdb *MemoryDB) GetForeignPriceYesterday(symbol, source string) (float64, error) {
+	now := time.Now()
+	timeFinal := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	quotations := mdb.selectForeignQuotations(symbol, source, timeFinal.AddDate(0, 0, -1), timeFinal)
+	if len(quotations) == 0 {
+		return 0, errors.New("no data available from yesterday")
+	}
+	var price float64
+	for _, fq := range quotations {
+		price += fq.Price
+	}
+	return price / float64(len(quotations)), nil
+}
+
+// GetForeignSymbolsInflux returns all symbols with a quotation from @source.
+func (mdb *MemoryDB) GetForeignSymbolsInflux(source string) (symbols []string, err error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	seen := make(map[string]struct{})
+	for _, fq := range mdb.foreignQuotes {
+		if fq.Source != source {
+			continue
+		}
+		if _, ok := seen[fq.Symbol]; !ok {
+			seen[fq.Symbol] = struct{}{}
+			symbols = append(symbols, fq.Symbol)
+		}
+	}
+	return
+}
+
+func (mdb *MemoryDB) SetVWAPFirefly(foreignName string, value float64, timestamp time.Time) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.vwapFirefly[foreignName] = append(mdb.vwapFirefly[foreignName], memoryValue{value: value, time: timestamp})
+	return nil
+}
+
+// GetVWAPFirefly returns all values for @foreignName in (@starttime, @endtime] in descending order.
+func (mdb *MemoryDB) GetVWAPFirefly(foreignName string, starttime time.Time, endtime time.Time) (values []float64, timestamps []time.Time, err error) {
+	mdb.mu.RLock()
+	var points []memoryValue
+	for _, v := range mdb.vwapFirefly[foreignName] {
+		if v.time.After(starttime) && !v.time.After(endtime) {
+			points = append(points, v)
+		}
+	}
+	mdb.mu.RUnlock()
+	if len(points) == 0 {
+		err = errors.New("no data available in given time range")
+		return
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].time.After(points[j].time)
+	})
+	for _, v := range points {
+		values = append(values, v.value)
+		timestamps = append(timestamps, v.time)
+	}
+	return
+}
+
+func (mdb *MemoryDB) SaveIndexEngineTimeInflux(tags map[string]string, fields map[string]interface{}, timestamp time.Time) error {
+	return errMemoryNotSupported("SaveIndexEngineTimeInflux")
+}
+
+func (mdb *MemoryDB) GetBenchmarkedIndexValuesInflux(symbol string, starttime time.Time, endtime time.Time) (BenchmarkedIndex, error) {
+	return BenchmarkedIndex{}, errMemoryNotSupported("GetBenchmarkedIndexValuesInflux")
+}
+
+func (mdb *MemoryDB) SetStockQuotation(sq StockQuotation) error {
+	mdb.mu.Lock()
+	defer mdb.mu.Unlock()
+	mdb.stockQuotations = append(mdb.stockQuotations, sq)
+	return nil
+}
+
+// GetStockQuotation returns all quotations of @symbol from @source in (@timeInit, @timeFinal] in descending order.
+func (mdb *MemoryDB) GetStockQuotation(source string, symbol string, timeInit time.Time, timeFinal time.Time) ([]StockQuotation, error) {
+	mdb.mu.RLock()
+	var quotations []StockQuotation
+	for _, sq := range mdb.stockQuotations {
+		if sq.Source == source && sq.Symbol == symbol && sq.Time.After(timeInit) && !sq.Time.After(timeFinal) {
+			quotations = append(quotations, sq)
+		}
+	}
+	mdb.mu.RUnlock()
+	sort.SliceStable(quotations, func(i, j int) bool {
+		return quotations[i].Time.After(quotations[j].Time)
+	})
+	return quotations, nil
+}
+
+// GetStockSymbols returns all stocks with a quotation mapped onto their source.
+func (mdb *MemoryDB) GetStockSymbols() (map[Stock]string, error) {
+	mdb.mu.RLock()
+	defer mdb.mu.RUnlock()
+	stocks := make(map[Stock]string)
+	for _, sq := range mdb.stockQuotations {
+		stocks[Stock{Symbol: sq.Symbol, Name: sq.Name, ISIN: sq.ISIN}] = sq.Source
+	}
+	return stocks, nil
+}
+
+var _ Datastore = (*MemoryDB)(nil)
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/go-redis/redis"
+)
+
+var (
+	memoryTestTime = time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	memoryTestETH  = dia.Asset{Symbol: "ETH", Address: "0x0000000000000000000000000000000000000000", Blockchain: dia.ETHEREUM}
+	memoryTestUSDC = dia.Asset{Symbol: "USDC", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Blockchain: dia.ETHEREUM}
+)
+
+func memoryTestTrade(source string, price float64, t time.Time) dia.Trade {
+	return dia.Trade{
+		Symbol:            memoryTestETH.Symbol,
+		Pair:              "ETH-USDC",
+		QuoteToken:        memoryTestETH,
+		BaseToken:         memoryTestUSDC,
+		Price:             price,
+		EstimatedUSDPrice: price,
+		Volume:            1,
+		Source:            source,
+		Time:              t,
+		VerifiedPair:      true,
+	}
+}
+
+func TestMemoryDBTrades(t *testing.T) {
+	mdb := NewMemoryDataStore()
+	trades := []dia.Trade{
+		memoryTestTrade("UniswapV2", 1800, memoryTestTime.Add(2*time.Second)),
+		memoryTestTrade("Binance", 1810, memoryTestTime.Add(1*time.Second)),
+		memoryTestTrade("UniswapV2", 1820, memoryTestTime.Add(3*time.Second)),
+	}
+	for i := range trades {
+		if err := mdb.SaveTradeInflux(&trades[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldTrades, err := mdb.GetOldTradesFromInflux(influxDbTradesTable, "", true, memoryTestTime, memoryTestTime.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oldTrades) != 3 {
+		t.Fatalf("expected 3 trades, got %d", len(oldTrades))
+	}
+	for i := 1; i < len(oldTrades); i++ {
+		if oldTrades[i].Time.Before(oldTrades[i-1].Time) {
+			t.Errorf("expected trades in ascending order, got %v before %v", oldTrades[i-1].Time, oldTrades[i].Time)
+		}
+	}
+	if oldTrades[0] != trades[1] {
+		t.Errorf("expected trade %v, got %v", trades[1], oldTrades[0])
+	}
+
+	unverified, err := mdb.GetOldTradesFromInflux(influxDbTradesTable, "UniswapV2", false, memoryTestTime, memoryTestTime.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unverified) != 2 {
+		t.Fatalf("expected 2 trades on UniswapV2, got %d", len(unverified))
+	}
+	if (unverified[0].QuoteToken != dia.Asset{} || unverified[0].VerifiedPair) {
+		t.Errorf("expected unverified trade without assets, got %v", unverified[0])
+	}
+
+	trade, err := mdb.GetTradeInflux(memoryTestETH, "UniswapV2", memoryTestTime.Add(time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade.Price != 1820 {
+		t.Errorf("expected latest trade with price 1820, got %v", trade.Price)
+	}
+
+	if _, err = mdb.GetOldTradesFromInflux(influxDbTradesTable, "", true, memoryTestTime.Add(time.Hour), memoryTestTime.Add(2*time.Hour)); err == nil {
+		t.Error("expected error for time range without trades")
+	}
+
+	mdb.Prune(memoryTestTime.Add(3 * time.Second))
+	remaining, err := mdb.GetOldTradesFromInflux(influxDbTradesTable, "", true, memoryTestTime, memoryTestTime.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Price != 1820 {
+		t.Errorf("expected only the last trade after pruning, got %v", remaining)
+	}
+}
+
+func TestMemoryDBAssetQuotations(t *testing.T) {
+	mdb := NewMemoryDataStore()
+
+	if _, err := mdb.GetAssetQuotationCache(memoryTestETH); err != redis.Nil {
+		t.Errorf("expected redis.Nil for missing cache entry, got %v", err)
+	}
+	if _, err := mdb.GetAssetQuotation(memoryTestETH, memoryTestTime); err == nil {
+		t.Error("expected error for missing quotation")
+	}
+
+	for i, price := range []float64{1800, 1810, 1820} {
+		err := mdb.SetAssetPriceUSD(memoryTestETH, price, memoryTestTime.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := mdb.GetAssetQuotationLatest(memoryTestETH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Price != 1820 || latest.Source != dia.Diadata {
+		t.Errorf("expected latest quotation 1820 by %s, got %v by %s", dia.Diadata, latest.Price, latest.Source)
+	}
+
+	price, err := mdb.GetAssetPriceUSD(memoryTestETH, memoryTestTime.Add(90*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != 1810 {
+		t.Errorf("expected price 1810 before timestamp, got %v", price)
+	}
+
+	quotations, err := mdb.GetAssetQuotations(memoryTestETH, memoryTestTime, memoryTestTime.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotations) != 2 || quotations[0].Price != 1820 || quotations[1].Price != 1810 {
+		t.Errorf("expected quotations 1820 and 1810 in descending order, got %v", quotations)
+	}
+
+	// An older quotation does not overwrite the cache if checked.
+	ok, err := mdb.SetAssetQuotationCache(&AssetQuotation{Asset: memoryTestETH, Price: 1700, Time: memoryTestTime}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("expected older quotation not to be cached")
+	}
+	if price, _ = mdb.GetAssetPriceUSDCache(memoryTestETH); price != 1820 {
+		t.Errorf("expected cached price 1820, got %v", price)
+	}
+}
+
+func TestMemoryDBFilters(t *testing.T) {
+	mdb := NewMemoryDataStore()
+	for i, value := range []float64{1800, 1810} {
+		if err := mdb.SetFilter("MAIR120", memoryTestETH, "", value, memoryTestTime.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A point at the same time overwrites the stored point.
+	if err := mdb.SetFilter("MAIR120", memoryTestETH, "", 1815, memoryTestTime.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := mdb.GetFilterPointsAsset("MAIR120", "", memoryTestETH.Address, memoryTestETH.Blockchain, memoryTestTime.Add(-time.Minute), memoryTestTime.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points.DataPoints) != 1 || len(points.DataPoints[0].Series) != 1 {
+		t.Fatalf("expected a single series, got %v", points.DataPoints)
+	}
+	// Columns: time, address, blockchain, exchange, filter, symbol, value.
+	values := points.DataPoints[0].Series[0].Values
+	if len(values) != 2 {
+		t.Fatalf("expected two filter points, got %v", values)
+	}
+	for i, expected := range []string{"1815", "1800"} {
+		if value := values[i][6].(json.Number).String(); value != expected {
+			t.Errorf("expected filter value %s at position %d, got %s", expected, i, value)
+		}
+	}
+
+	rejected, err := mdb.GetRejectedSources("MAIR120", memoryTestETH, "", memoryTestTime.Add(-time.Minute), memoryTestTime.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rejected) != 0 {
+		t.Errorf("expected no rejected sources, got %v", rejected)
+	}
+}
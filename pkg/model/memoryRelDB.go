@@ -0,0 +1,1049 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/go-redis/redis"
+	"github.com/jackc/pgx/v4"
+)
+
+// MemoryRelDB is an in-memory implementation of RelDatastore. It holds assets, exchange pairs,
+// exchanges, pools and blockchains in memory such that services can be tested without postgres and redis.
+// Missing entries are reported as pgx.ErrNoRows resp. redis.Nil for the caching layer as in RelDB.
+// NFT and oracle builder methods are not supported.
+type MemoryRelDB struct {
+	mu       sync.RWMutex
+	pagesize uint32
+
+	// assets are kept in insertion order, assetIDs maps asset keys onto their index in assets.
+	assets          []dia.Asset
+	assetIDs        map[string]int
+	assetVolumes    map[string]memoryValue
+	assetCache      map[string]dia.Asset
+	exchangePairs   map[string]dia.ExchangePair
+	pairCache       map[string]dia.ExchangePair
+	exchangeSymbols []memoryExchangeSymbol
+	exchanges       map[string]dia.Exchange
+	pools           map[string]dia.Pool
+	blockchains     map[string]dia.BlockChain
+	chainConfigs    []dia.ChainConfig
+	assetBridges    []dia.AssetBridge
+	blockData       map[string]map[int64]dia.BlockData
+	scraperStates   map[string][]byte
+	scraperConfigs  map[string][]byte
+}
+
+type memoryExchangeSymbol struct {
+	symbol   string
+	exchange string
+	verified bool
+	assetID  string
+}
+
+// NewMemoryRelDataStore returns an empty in-memory relational datastore.
+func NewMemoryRelDataStore() *MemoryRelDB {
+	return &MemoryRelDB{
+		pagesize:       32,
+		assetIDs:       make(map[string]int),
+		assetVolumes:   make(map[string]memoryValue),
+		assetCache:     make(map[string]dia.Asset),
+		exchangePairs:  make(map[string]dia.ExchangePair),
+		pairCache:      make(map[string]dia.ExchangePair),
+		exchanges:      make(map[string]dia.Exchange),
+		pools:          make(map[string]dia.Pool),
+		blockchains:    make(map[string]dia.BlockChain),
+		blockData:      make(map[string]map[int64]dia.BlockData),
+		scraperStates:  make(map[string][]byte),
+		scraperConfigs: make(map[string][]byte),
+	}
+}
+
+func memoryExchangePairKey(exchange string, foreignName string) string {
+	return exchange + "_" + foreignName
+}
+
+func hasPrefixFold(s string, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
+
+// ------------------------------------------------------------------------------
+// ASSETS
+// ------------------------------------------------------------------------------
+
+// SetAsset stores @asset if no asset with the same address and blockchain exists.
+func (mrdb *MemoryRelDB) SetAsset(asset dia.Asset) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	if _, ok := mrdb.assetIDs[memoryAssetKey(asset)]; ok {
+		return nil
+	}
+	mrdb.assetIDs[memoryAssetKey(asset)] = len(mrdb.assets)
+	mrdb.assets = append(mrdb.assets, asset)
+	return nil
+}
+
+// GetAssetID returns the unique identifier of @asset.
+func (mrdb *MemoryRelDB) GetAssetID(asset dia.Asset) (string, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	index, ok := mrdb.assetIDs[memoryAssetKey(asset)]
+	if !ok {
+		return "", pgx.ErrNoRows
+	}
+	return strconv.Itoa(index + 1), nil
+}
+
+// GetAsset returns the asset with @address on @blockchain.
+func (mrdb *MemoryRelDB) GetAsset(address, blockchain string) (dia.Asset, error) {
+	return mrdb.GetAssetByID(func() string {
+		ID, _ := mrdb.GetAssetID(dia.Asset{Address: address, Blockchain: blockchain})
+		return ID
+	}())
+}
+
+// GetAssetByID returns an asset by its identifier.
+func (mrdb *MemoryRelDB) GetAssetByID(ID string) (dia.Asset, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	index, err := strconv.Atoi(ID)
+	if err != nil || index < 1 || index > len(mrdb.assets) {
+		return dia.Asset{}, pgx.ErrNoRows
+	}
+	return mrdb.assets[index-1], nil
+}
+
+// selectAssets returns all assets for which @match returns true in insertion order.
+func (mrdb *MemoryRelDB) selectAssets(match func(asset dia.Asset) bool) (assets []dia.Asset) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, asset := range mrdb.assets {
+		if match(asset) {
+			assets = append(assets, asset)
+		}
+	}
+	return
+}
+
+// GetAllAssets returns all assets on @blockchain.
+func (mrdb *MemoryRelDB) GetAllAssets(blockchain string) ([]dia.Asset, error) {
+	return mrdb.selectAssets(func(asset dia.Asset) bool {
+		return asset.Blockchain == blockchain
+	}), nil
+}
+
+// GetAssetsBySymbolName returns all assets with positive volume whose symbol resp. name begins with
+// @symbol resp. @name (case insensitive), sorted by volume in descending order.
+func (mrdb *MemoryRelDB) GetAssetsBySymbolName(symbol, name string) ([]dia.Asset, error) {
+	assetVolumes := mrdb.sortedAssetVolumes(func(asset dia.Asset, volume memoryValue) bool {
+		if volume.value <= 0 {
+			return false
+		}
+		if name == "" {
+			return hasPrefixFold(asset.Symbol, symbol)
+		}
+		if symbol == "" {
+			return hasPrefixFold(asset.Name, name)
+		}
+		return hasPrefixFold(asset.Symbol, symbol) || hasPrefixFold(asset.Name, name)
+	})
+	var assets []dia.Asset
+	for _, av := range assetVolumes {
+		assets = append(assets, av.Asset)
+	}
+	return assets, nil
+}
+
+// GetFiatAssetBySymbol returns the fiat asset with @symbol.
+func (mrdb *MemoryRelDB) GetFiatAssetBySymbol(symbol string) (dia.Asset, error) {
+	assets := mrdb.selectAssets(func(asset dia.Asset) bool {
+		return asset.Symbol == symbol && asset.Blockchain == "Fiat"
+	})
+	if len(assets) == 0 {
+		return dia.Asset{}, pgx.ErrNoRows
+	}
+	return assets[0], nil
+}
+
+// IdentifyAsset returns all assets which match the non-null fields in @asset.
+func (mrdb *MemoryRelDB) IdentifyAsset(asset dia.Asset) ([]dia.Asset, error) {
+	return mrdb.selectAssets(func(a dia.Asset) bool {
+		return (asset.Symbol == "" || a.Symbol == asset.Symbol) &&
+			(asset.Name == "" || a.Name == asset.Name) &&
+			(asset.Address == "" || strings.EqualFold(a.Address, asset.Address)) &&
+			(asset.Decimals == 0 || a.Decimals == asset.Decimals) &&
+			(asset.Blockchain == "" || a.Blockchain == asset.Blockchain)
+	}), nil
+}
+
+// GetPage returns assets per page number. @hasNextPage is true iff there is a non-empty next page.
+func (mrdb *MemoryRelDB) GetPage(pageNumber uint32) (assets []dia.Asset, hasNextPage bool, err error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	start := int(mrdb.pagesize * pageNumber)
+	if start >= len(mrdb.assets) {
+		return
+	}
+	end := start + int(mrdb.pagesize)
+	if end > len(mrdb.assets) {
+		end = len(mrdb.assets)
+	}
+	assets = append(assets, mrdb.assets[start:end]...)
+	hasNextPage = end < len(mrdb.assets)
+	return
+}
+
+// Count returns the number of assets.
+func (mrdb *MemoryRelDB) Count() (uint32, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	return uint32(len(mrdb.assets)), nil
+}
+
+// SetAssetVolume24H stores the 24h volume of @asset. @asset must exist.
+func (mrdb *MemoryRelDB) SetAssetVolume24H(asset dia.Asset, volume float64, timestamp time.Time) error {
+	if _, err := mrdb.GetAssetID(asset); err != nil {
+		return err
+	}
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.assetVolumes[memoryAssetKey(asset)] = memoryValue{value: volume, time: timestamp}
+	return nil
+}
+
+// GetLastAssetVolume24H returns the last 24h volume of @asset.
+func (mrdb *MemoryRelDB) GetLastAssetVolume24H(asset dia.Asset) (float64, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	volume, ok := mrdb.assetVolumes[memoryAssetKey(asset)]
+	if !ok {
+		return 0, pgx.ErrNoRows
+	}
+	return volume.value, nil
+}
+
+// sortedAssetVolumes returns all assets with volume for which @match returns true, sorted by volume in descending order.
+func (mrdb *MemoryRelDB) sortedAssetVolumes(match func(asset dia.Asset, volume memoryValue) bool) []dia.AssetVolume {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	var assetVolumes []dia.AssetVolume
+	for _, asset := range mrdb.assets {
+		volume, ok := mrdb.assetVolumes[memoryAssetKey(asset)]
+		if ok && match(asset, volume) {
+			assetVolumes = append(assetVolumes, dia.AssetVolume{Asset: asset, Volume: volume.value})
+		}
+	}
+	sort.SliceStable(assetVolumes, func(i, j int) bool {
+		return assetVolumes[i].Volume > assetVolumes[j].Volume
+	})
+	return assetVolumes
+}
+
+// GetAssetsWithVOL returns the first @numAssets assets with volume after skipping @skip, sorted by volume in descending order.
+// If @numAssets==0, 100 assets are returned. If @onlycex is true, only assets with a verified symbol on a centralized exchange are returned.
+func (mrdb *MemoryRelDB) GetAssetsWithVOL(numAssets int64, skip int64, onlycex bool, blockchain string) ([]dia.AssetVolume, error) {
+	if numAssets == 0 {
+		numAssets = 100
+	}
+	var cexAssets map[string]struct{}
+	if onlycex {
+		cexAssets = make(map[string]struct{})
+		mrdb.mu.RLock()
+		for _, es := range mrdb.exchangeSymbols {
+			if es.assetID != "" && mrdb.exchanges[es.exchange].Centralized {
+				cexAssets[es.assetID] = struct{}{}
+			}
+		}
+		mrdb.mu.RUnlock()
+	}
+	assetVolumes := mrdb.sortedAssetVolumes(func(asset dia.Asset, volume memoryValue) bool {
+		if blockchain != "" && asset.Blockchain != blockchain {
+			return false
+		}
+		if onlycex {
+			ID, _ := mrdb.GetAssetID(asset)
+			_, ok := cexAssets[ID]
+			return ok
+		}
+		return true
+	})
+	if skip >= int64(len(assetVolumes)) {
+		return []dia.AssetVolume{}, nil
+	}
+	assetVolumes = assetVolumes[skip:]
+	if numAssets < int64(len(assetVolumes)) {
+		assetVolumes = assetVolumes[:numAssets]
+	}
+	return assetVolumes, nil
+}
+
+// GetAssetsWithVolByBlockchain returns all assets with volume timestamp in (@starttime,@endtime], sorted by volume.
+// If blockchain is a non-empty string it only returns assets from @blockchain.
+func (mrdb *MemoryRelDB) GetAssetsWithVolByBlockchain(starttime time.Time, endtime time.Time, blockchain string) ([]dia.AssetVolume, error) {
+	return mrdb.sortedAssetVolumes(func(asset dia.Asset, volume memoryValue) bool {
+		return (blockchain == "" || asset.Blockchain == blockchain) && volume.time.After(starttime) && !volume.time.After(endtime)
+	}), nil
+}
+
+// GetAssetSource returns all exchanges @asset is traded on.
+// For @cex true, only CEXes are returned. Otherwise only DEXes.
+func (mrdb *MemoryRelDB) GetAssetSource(asset dia.Asset, cex bool) (exchanges []string, err error) {
+	ID, err := mrdb.GetAssetID(asset)
+	if err != nil {
+		return []string{}, nil
+	}
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	seen := make(map[string]struct{})
+	add := func(exchange string) {
+		if _, ok := seen[exchange]; !ok {
+			seen[exchange] = struct{}{}
+			exchanges = append(exchanges, exchange)
+		}
+	}
+	if cex {
+		for _, es := range mrdb.exchangeSymbols {
+			if es.assetID == ID {
+				add(es.exchange)
+			}
+		}
+	} else {
+		for _, pool := range mrdb.pools {
+			for _, av := range pool.Assetvolumes {
+				if av.Asset.Address == asset.Address && av.Asset.Blockchain == asset.Blockchain {
+					add(pool.Exchange.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(exchanges)
+	return
+}
+
+// ------------------------------------------------------------------------------
+// EXCHANGE PAIRS AND SYMBOLS
+// ------------------------------------------------------------------------------
+
+// SetExchangePair stores @pair. The underlying assets are only set if they exist.
+// If cache==true, it is also cached.
+func (mrdb *MemoryRelDB) SetExchangePair(exchange string, pair dia.ExchangePair, cache bool) error {
+	if _, err := mrdb.GetAssetID(pair.UnderlyingPair.QuoteToken); err != nil {
+		pair.UnderlyingPair.QuoteToken = dia.Asset{}
+	}
+	if _, err := mrdb.GetAssetID(pair.UnderlyingPair.BaseToken); err != nil {
+		pair.UnderlyingPair.BaseToken = dia.Asset{}
+	}
+	pair.Exchange = exchange
+	mrdb.mu.Lock()
+	mrdb.exchangePairs[memoryExchangePairKey(exchange, pair.ForeignName)] = pair
+	mrdb.mu.Unlock()
+	if cache {
+		return mrdb.SetExchangePairCache(exchange, pair)
+	}
+	return nil
+}
+
+// GetExchangePair returns the unique exchange pair given by @exchange and @foreignname.
+func (mrdb *MemoryRelDB) GetExchangePair(exchange string, foreignname string) (dia.ExchangePair, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	pair, ok := mrdb.exchangePairs[memoryExchangePairKey(exchange, foreignname)]
+	if !ok {
+		return dia.ExchangePair{}, pgx.ErrNoRows
+	}
+	return pair, nil
+}
+
+// selectExchangePairs returns all exchange pairs for which @match returns true, sorted by exchange and foreign name.
+func (mrdb *MemoryRelDB) selectExchangePairs(match func(pair dia.ExchangePair) bool) (pairs []dia.ExchangePair) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, pair := range mrdb.exchangePairs {
+		if match(pair) {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return memoryExchangePairKey(pairs[i].Exchange, pairs[i].ForeignName) < memoryExchangePairKey(pairs[j].Exchange, pairs[j].ForeignName)
+	})
+	return
+}
+
+// GetExchangePairSymbols returns all exchange pairs on @exchange with symbol and foreign name.
+func (mrdb *MemoryRelDB) GetExchangePairSymbols(exchange string) ([]dia.ExchangePair, error) {
+	pairs := mrdb.selectExchangePairs(func(pair dia.ExchangePair) bool {
+		return pair.Exchange == exchange
+	})
+	for i := range pairs {
+		pairs[i] = dia.ExchangePair{Exchange: exchange, Symbol: pairs[i].Symbol, ForeignName: pairs[i].ForeignName}
+	}
+	return pairs, nil
+}
+
+func hasUnderlyingPair(pair dia.ExchangePair) bool {
+	return pair.UnderlyingPair.QuoteToken != (dia.Asset{}) && pair.UnderlyingPair.BaseToken != (dia.Asset{})
+}
+
+// GetPairsForExchange returns all pairs with underlying assets on a centralized @exchange.
+func (mrdb *MemoryRelDB) GetPairsForExchange(exchange dia.Exchange, filterVerified bool, verified bool) ([]dia.ExchangePair, error) {
+	if GetExchangeType(exchange) != "CEX" {
+		return []dia.ExchangePair{}, errors.New("query only feasible for centralized exchanges.")
+	}
+	pairs := mrdb.selectExchangePairs(func(pair dia.ExchangePair) bool {
+		return pair.Exchange == exchange.Name && hasUnderlyingPair(pair) && (!filterVerified || pair.Verified == verified)
+	})
+	for i := range pairs {
+		pairs[i].Symbol = pairs[i].UnderlyingPair.QuoteToken.Symbol
+	}
+	return pairs, nil
+}
+
+// GetPairsForAsset returns all pairs with @asset as quote or base token.
+func (mrdb *MemoryRelDB) GetPairsForAsset(asset dia.Asset, filterVerified bool, verified bool) ([]dia.ExchangePair, error) {
+	pairs := mrdb.selectExchangePairs(func(pair dia.ExchangePair) bool {
+		if !hasUnderlyingPair(pair) || (filterVerified && pair.Verified != verified) {
+			return false
+		}
+		quotetoken, basetoken := pair.UnderlyingPair.QuoteToken, pair.UnderlyingPair.BaseToken
+		return (quotetoken.Address == asset.Address && quotetoken.Blockchain == asset.Blockchain) ||
+			(basetoken.Address == asset.Address && basetoken.Blockchain == asset.Blockchain)
+	})
+	for i := range pairs {
+		pairs[i].Symbol = pairs[i].UnderlyingPair.QuoteToken.Symbol
+	}
+	return pairs, nil
+}
+
+// GetNumPairs returns the number of exchangepairs/pools on @exchange.
+func (mrdb *MemoryRelDB) GetNumPairs(exchange dia.Exchange) (numPairs int, err error) {
+	switch GetExchangeType(exchange) {
+	case "CEX":
+		pairs, err := mrdb.GetExchangePairSymbols(exchange.Name)
+		return len(pairs), err
+	case "DEX":
+		pools, err := mrdb.GetAllPoolAddrsExchange(exchange.Name, float64(0))
+		return len(pools), err
+	}
+	return
+}
+
+// SetExchangeSymbol stores @symbol on @exchange if not yet stored.
+func (mrdb *MemoryRelDB) SetExchangeSymbol(exchange string, symbol string) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	for _, es := range mrdb.exchangeSymbols {
+		if es.exchange == exchange && es.symbol == symbol {
+			return nil
+		}
+	}
+	mrdb.exchangeSymbols = append(mrdb.exchangeSymbols, memoryExchangeSymbol{symbol: symbol, exchange: exchange})
+	return nil
+}
+
+// GetExchangeSymbols returns all symbols traded on @exchange.
+// If @exchange is the empty string, all symbols are returned.
+// If @substring is not the empty string, all symbols that begin with @substring (case insensitive) are returned.
+func (mrdb *MemoryRelDB) GetExchangeSymbols(exchange string, substring string) (symbols []string, err error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, es := range mrdb.exchangeSymbols {
+		if (exchange == "" || es.exchange == exchange) && hasPrefixFold(es.symbol, substring) {
+			symbols = append(symbols, es.symbol)
+		}
+	}
+	return
+}
+
+// GetUnverifiedExchangeSymbols returns all symbols from @exchange which haven't been verified yet.
+func (mrdb *MemoryRelDB) GetUnverifiedExchangeSymbols(exchange string) (symbols []string, err error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, es := range mrdb.exchangeSymbols {
+		if es.exchange == exchange && !es.verified {
+			symbols = append(symbols, es.symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return
+}
+
+// VerifyExchangeSymbol verifies @symbol on @exchange and maps it uniquely to @assetID.
+// It returns true if symbol,exchange is present and succesfully updated.
+func (mrdb *MemoryRelDB) VerifyExchangeSymbol(exchange string, symbol string, assetID string) (bool, error) {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	var success bool
+	for i := range mrdb.exchangeSymbols {
+		if mrdb.exchangeSymbols[i].exchange == exchange && mrdb.exchangeSymbols[i].symbol == symbol {
+			mrdb.exchangeSymbols[i].verified = true
+			mrdb.exchangeSymbols[i].assetID = assetID
+			success = true
+		}
+	}
+	return success, nil
+}
+
+// GetExchangeSymbolAssetID returns the ID of the unique asset associated to @symbol on @exchange
+// in case the symbol is verified. An empty string if not.
+func (mrdb *MemoryRelDB) GetExchangeSymbolAssetID(exchange string, symbol string) (string, bool, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, es := range mrdb.exchangeSymbols {
+		if es.exchange == exchange && es.symbol == symbol {
+			return es.assetID, es.verified, nil
+		}
+	}
+	return "", false, pgx.ErrNoRows
+}
+
+// ------------------------------------------------------------------------------
+// EXCHANGES, POOLS AND BLOCKCHAINS
+// ------------------------------------------------------------------------------
+
+// SetExchange stores @exchange, overwriting an existing exchange with the same name.
+func (mrdb *MemoryRelDB) SetExchange(exchange dia.Exchange) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.exchanges[exchange.Name] = exchange
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetExchange(name string) (dia.Exchange, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	exchange, ok := mrdb.exchanges[name]
+	if !ok {
+		return dia.Exchange{}, pgx.ErrNoRows
+	}
+	return exchange, nil
+}
+
+// GetAllExchanges returns all exchanges sorted by name.
+func (mrdb *MemoryRelDB) GetAllExchanges() (exchanges []dia.Exchange, err error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, exchange := range mrdb.exchanges {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Slice(exchanges, func(i, j int) bool {
+		return exchanges[i].Name < exchanges[j].Name
+	})
+	return
+}
+
+// GetExchangeNames returns the names of all available exchanges.
+func (mrdb *MemoryRelDB) GetExchangeNames() (allExchanges []string, err error) {
+	exchanges, err := mrdb.GetAllExchanges()
+	if err != nil {
+		return
+	}
+	for _, exchange := range exchanges {
+		allExchanges = append(allExchanges, exchange.Name)
+	}
+	return
+}
+
+// SetPool stores the most recent state of @pool.
+func (mrdb *MemoryRelDB) SetPool(pool dia.Pool) error {
+	if len(pool.Assetvolumes) < 2 {
+		return errors.New("not enough asset data on pool")
+	}
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.pools[pool.Blockchain.Name+"_"+pool.Address] = pool
+	return nil
+}
+
+// GetPoolByAddress returns the most recent pool data, i.e. liquidity.
+func (mrdb *MemoryRelDB) GetPoolByAddress(blockchain string, address string) (dia.Pool, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	pool, ok := mrdb.pools[blockchain+"_"+address]
+	if !ok {
+		return dia.Pool{Blockchain: dia.BlockChain{Name: blockchain}, Address: address}, nil
+	}
+	return pool, nil
+}
+
+// GetAllPoolsExchange returns all pools on @exchange with the assets having liquidity of at least @liquiThreshold.
+func (mrdb *MemoryRelDB) GetAllPoolsExchange(exchange string, liquiThreshold float64) (pools []dia.Pool, err error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	for _, pool := range mrdb.pools {
+		if pool.Exchange.Name != exchange {
+			continue
+		}
+		var assetvolumes []dia.AssetVolume
+		for _, av := range pool.Assetvolumes {
+			if av.Volume >= liquiThreshold {
+				assetvolumes = append(assetvolumes, av)
+			}
+		}
+		if len(assetvolumes) > 0 {
+			pool.Assetvolumes = assetvolumes
+			pools = append(pools, pool)
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Address < pools[j].Address
+	})
+	return
+}
+
+// GetAllPoolAddrsExchange returns the addresses of all pools on @exchange with an asset
+// having liquidity of at least @liquiThreshold.
+func (mrdb *MemoryRelDB) GetAllPoolAddrsExchange(exchange string, liquiThreshold float64) (addresses []string, err error) {
+	pools, err := mrdb.GetAllPoolsExchange(exchange, liquiThreshold)
+	for _, pool := range pools {
+		addresses = append(addresses, pool.Address)
+	}
+	return
+}
+
+func (mrdb *MemoryRelDB) SetBlockchain(blockchain dia.BlockChain) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.blockchains[blockchain.Name] = blockchain
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetBlockchain(name string) (dia.BlockChain, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	blockchain, ok := mrdb.blockchains[name]
+	if !ok {
+		return dia.BlockChain{}, pgx.ErrNoRows
+	}
+	return blockchain, nil
+}
+
+// GetAllBlockchains returns all blockchains sorted by name.
+// If fullAsset=true it returns the complete native token as asset, otherwise only its symbol string.
+func (mrdb *MemoryRelDB) GetAllBlockchains(fullAsset bool) ([]dia.BlockChain, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	var blockchains []dia.BlockChain
+	for _, blockchain := range mrdb.blockchains {
+		if fullAsset {
+			blockchain.NativeToken.Blockchain = blockchain.Name
+		} else {
+			blockchain.NativeToken = dia.Asset{Symbol: blockchain.NativeToken.Symbol}
+		}
+		blockchains = append(blockchains, blockchain)
+	}
+	sort.Slice(blockchains, func(i, j int) bool {
+		return blockchains[i].Name < blockchains[j].Name
+	})
+	return blockchains, nil
+}
+
+func (mrdb *MemoryRelDB) SetChainConfig(chainconfig dia.ChainConfig) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.chainConfigs = append(mrdb.chainConfigs, chainconfig)
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetAllChainConfig() ([]dia.ChainConfig, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	return append([]dia.ChainConfig{}, mrdb.chainConfigs...), nil
+}
+
+// SetAssetBridge stores @bridge, replacing a bridge with the same source asset and exchanges.
+func (mrdb *MemoryRelDB) SetAssetBridge(bridge dia.AssetBridge) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	for i, b := range mrdb.assetBridges {
+		if b.ID() == bridge.ID() {
+			mrdb.assetBridges[i] = bridge
+			return nil
+		}
+	}
+	mrdb.assetBridges = append(mrdb.assetBridges, bridge)
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetAssetBridges() ([]dia.AssetBridge, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	return append([]dia.AssetBridge{}, mrdb.assetBridges...), nil
+}
+
+// GetAllAssetsBlockchains returns all blockchain names existent in the assets.
+func (mrdb *MemoryRelDB) GetAllAssetsBlockchains() ([]string, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	var blockchains []string
+	seen := make(map[string]struct{})
+	for _, asset := range mrdb.assets {
+		if asset.Name == "" {
+			continue
+		}
+		if _, ok := seen[asset.Blockchain]; !ok {
+			seen[asset.Blockchain] = struct{}{}
+			blockchains = append(blockchains, asset.Blockchain)
+		}
+	}
+	sort.Strings(blockchains)
+	return blockchains, nil
+}
+
+// ------------------------------------------------------------------------------
+// CACHING
+// ------------------------------------------------------------------------------
+
+// SetAssetCache caches @asset. As in RelDB, @asset is only cached iff it exists.
+func (mrdb *MemoryRelDB) SetAssetCache(asset dia.Asset) error {
+	ID, err := mrdb.GetAssetID(asset)
+	if err != nil {
+		return err
+	}
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.assetCache[ID] = asset
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetAssetCache(assetID string) (dia.Asset, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	asset, ok := mrdb.assetCache[assetID]
+	if !ok {
+		return dia.Asset{}, redis.Nil
+	}
+	return asset, nil
+}
+
+func (mrdb *MemoryRelDB) CountCache() (uint32, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	return uint32(len(mrdb.assetCache)), nil
+}
+
+func (mrdb *MemoryRelDB) SetExchangePairCache(exchange string, pair dia.ExchangePair) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.pairCache[memoryExchangePairKey(exchange, pair.ForeignName)] = pair
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetExchangePairCache(exchange string, foreignName string) (dia.ExchangePair, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	pair, ok := mrdb.pairCache[memoryExchangePairKey(exchange, foreignName)]
+	if !ok {
+		return dia.ExchangePair{}, redis.Nil
+	}
+	return pair, nil
+}
+
+// ------------------------------------------------------------------------------
+// GENERAL, SCRAPERS AND BLOCK DATA
+// ------------------------------------------------------------------------------
+
+func (mrdb *MemoryRelDB) GetKeys(table string) ([]string, error) {
+	return []string{}, errMemoryNotSupported("GetKeys")
+}
+
+// GetScraperState decodes the stored state of @scraperName into @state.
+func (mrdb *MemoryRelDB) GetScraperState(ctx context.Context, scraperName string, state ScraperState) error {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	content, ok := mrdb.scraperStates[scraperName]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	return json.Unmarshal(content, state)
+}
+
+// SetScraperState stores the json encoded @state of @scraperName.
+func (mrdb *MemoryRelDB) SetScraperState(ctx context.Context, scraperName string, state ScraperState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.scraperStates[scraperName] = content
+	return nil
+}
+
+// GetScraperConfig decodes the stored config of @scraperName into @config.
+func (mrdb *MemoryRelDB) GetScraperConfig(ctx context.Context, scraperName string, config ScraperConfig) error {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	content, ok := mrdb.scraperConfigs[scraperName]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	return json.Unmarshal(content, config)
+}
+
+// SetScraperConfig stores the json encoded @config of @scraperName.
+func (mrdb *MemoryRelDB) SetScraperConfig(ctx context.Context, scraperName string, config ScraperConfig) error {
+	content, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	mrdb.scraperConfigs[scraperName] = content
+	return nil
+}
+
+func (mrdb *MemoryRelDB) SetBlockData(blockdata dia.BlockData) error {
+	mrdb.mu.Lock()
+	defer mrdb.mu.Unlock()
+	if _, ok := mrdb.blockData[blockdata.BlockchainName]; !ok {
+		mrdb.blockData[blockdata.BlockchainName] = make(map[int64]dia.BlockData)
+	}
+	mrdb.blockData[blockdata.BlockchainName][blockdata.BlockNumber] = blockdata
+	return nil
+}
+
+func (mrdb *MemoryRelDB) GetBlockData(blockchain string, blocknumber int64) (dia.BlockData, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	blockdata, ok := mrdb.blockData[blockchain][blocknumber]
+	if !ok {
+		return dia.BlockData{}, pgx.ErrNoRows
+	}
+	return blockdata, nil
+}
+
+// GetLastBlockBlockscraper returns the last stored block on @blockchain.
+func (mrdb *MemoryRelDB) GetLastBlockBlockscraper(blockchain string) (int64, error) {
+	mrdb.mu.RLock()
+	defer mrdb.mu.RUnlock()
+	var blockNumber int64
+	var found bool
+	for number := range mrdb.blockData[blockchain] {
+		if !found || number > blockNumber {
+			blockNumber = number
+			found = true
+		}
+	}
+	if !found {
+		return 0, pgx.ErrNoRows
+	}
+	return blockNumber, nil
+}
+
+// ------------------------------------------------------------------------------
+// NFTS AND ORACLE BUILDER (not supported)
+// ------------------------------------------------------------------------------
+
+func (mrdb *MemoryRelDB) SetNFTClass(nftClass dia.NFTClass) error {
+	return errMemoryNotSupported("SetNFTClass")
+}
+
+func (mrdb *MemoryRelDB) GetAllNFTClasses(blockchain string) ([]dia.NFTClass, error) {
+	return []dia.NFTClass{}, errMemoryNotSupported("GetAllNFTClasses")
+}
+
+func (mrdb *MemoryRelDB) GetNFTClasses(limit, offset uint64) ([]dia.NFTClass, error) {
+	return []dia.NFTClass{}, errMemoryNotSupported("GetNFTClasses")
+}
+
+func (mrdb *MemoryRelDB) GetNFTClass(address string, blockchain string) (dia.NFTClass, error) {
+	return dia.NFTClass{}, errMemoryNotSupported("GetNFTClass")
+}
+
+func (mrdb *MemoryRelDB) GetNFTClassID(address string, blockchain string) (string, error) {
+	return "", errMemoryNotSupported("GetNFTClassID")
+}
+
+func (mrdb *MemoryRelDB) GetNFTClassByID(id string) (dia.NFTClass, error) {
+	return dia.NFTClass{}, errMemoryNotSupported("GetNFTClassByID")
+}
+
+func (mrdb *MemoryRelDB) GetNFTClassesByNameSymbol(searchstring string) ([]dia.NFTClass, error) {
+	return []dia.NFTClass{}, errMemoryNotSupported("GetNFTClassesByNameSymbol")
+}
+
+func (mrdb *MemoryRelDB) UpdateNFTClassCategory(nftclassID string, category string) (bool, error) {
+	return false, errMemoryNotSupported("UpdateNFTClassCategory")
+}
+
+func (mrdb *MemoryRelDB) GetNFTCategories() ([]string, error) {
+	return []string{}, errMemoryNotSupported("GetNFTCategories")
+}
+
+func (mrdb *MemoryRelDB) SetNFT(nft dia.NFT) error {
+	return errMemoryNotSupported("SetNFT")
+}
+
+func (mrdb *MemoryRelDB) GetNFT(address string, blockchain string, tokenID string) (dia.NFT, error) {
+	return dia.NFT{}, errMemoryNotSupported("GetNFT")
+}
+
+func (mrdb *MemoryRelDB) GetNFTID(address string, blockchain string, tokenID string) (string, error) {
+	return "", errMemoryNotSupported("GetNFTID")
+}
+
+func (mrdb *MemoryRelDB) SetNFTTrade(trade dia.NFTTrade) error {
+	return errMemoryNotSupported("SetNFTTrade")
+}
+
+func (mrdb *MemoryRelDB) SetNFTTradeToTable(trade dia.NFTTrade, table string) error {
+	return errMemoryNotSupported("SetNFTTradeToTable")
+}
+
+func (mrdb *MemoryRelDB) GetNFTTrades(address string, blockchain string, tokenID string, starttime time.Time, endtime time.Time) ([]dia.NFTTrade, error) {
+	return []dia.NFTTrade{}, errMemoryNotSupported("GetNFTTrades")
+}
+
+func (mrdb *MemoryRelDB) GetNFTTradesCollection(address string, blockchain string, starttime time.Time, endtime time.Time) ([]dia.NFTTrade, error) {
+	return []dia.NFTTrade{}, errMemoryNotSupported("GetNFTTradesCollection")
+}
+
+func (mrdb *MemoryRelDB) GetNFTOffers(address string, blockchain string, tokenID string) ([]dia.NFTOffer, error) {
+	return []dia.NFTOffer{}, errMemoryNotSupported("GetNFTOffers")
+}
+
+func (mrdb *MemoryRelDB) GetNFTBids(address string, blockchain string, tokenID string) ([]dia.NFTBid, error) {
+	return []dia.NFTBid{}, errMemoryNotSupported("GetNFTBids")
+}
+
+func (mrdb *MemoryRelDB) GetNFTFloor(nftclass dia.NFTClass, timestamp time.Time, floorWindowSeconds time.Duration, noBundles bool, exchange string) (float64, error) {
+	return 0, errMemoryNotSupported("GetNFTFloor")
+}
+
+func (mrdb *MemoryRelDB) GetNFTFloorLevel(nftclass dia.NFTClass, timestamp time.Time, floorWindowSeconds time.Duration, currencies []dia.Asset, level float64, noBundles bool, exchange string) (float64, error) {
+	return 0, errMemoryNotSupported("GetNFTFloorLevel")
+}
+
+func (mrdb *MemoryRelDB) GetNFTFloorRecursive(nftClass dia.NFTClass, timestamp time.Time, floorWindowSeconds time.Duration, stepBackLimit int, noBundles bool, exchange string) (float64, error) {
+	return 0, errMemoryNotSupported("GetNFTFloorRecursive")
+}
+
+func (mrdb *MemoryRelDB) GetNFTFloorRange(nftClass dia.NFTClass, starttime time.Time, endtime time.Time, floorWindowSeconds time.Duration, stepBackLimit int, noBundles bool, exchange string) ([]float64, error) {
+	return []float64{}, errMemoryNotSupported("GetNFTFloorRange")
+}
+
+func (mrdb *MemoryRelDB) GetLastBlockheightTopshot(upperBound time.Time) (uint64, error) {
+	return 0, errMemoryNotSupported("GetLastBlockheightTopshot")
+}
+
+func (mrdb *MemoryRelDB) SetNFTBid(bid dia.NFTBid) error {
+	return errMemoryNotSupported("SetNFTBid")
+}
+
+func (mrdb *MemoryRelDB) GetLastNFTBid(address string, blockchain string, tokenID string, blockNumber uint64, blockPosition uint) (dia.NFTBid, error) {
+	return dia.NFTBid{}, errMemoryNotSupported("GetLastNFTBid")
+}
+
+func (mrdb *MemoryRelDB) GetLastBlockNFTBid(nftclass dia.NFTClass) (uint64, error) {
+	return 0, errMemoryNotSupported("GetLastBlockNFTBid")
+}
+
+func (mrdb *MemoryRelDB) GetLastBlockNFTOffer(nftclass dia.NFTClass) (uint64, error) {
+	return 0, errMemoryNotSupported("GetLastBlockNFTOffer")
+}
+
+func (mrdb *MemoryRelDB) GetLastBlockNFTTrade(nftclass dia.NFTClass) (uint64, error) {
+	return 0, errMemoryNotSupported("GetLastBlockNFTTrade")
+}
+
+func (mrdb *MemoryRelDB) SetNFTOffer(offer dia.NFTOffer) error {
+	return errMemoryNotSupported("SetNFTOffer")
+}
+
+func (mrdb *MemoryRelDB) GetLastNFTOffer(address string, blockchain string, tokenID string, blockNumber uint64, blockPosition uint) (dia.NFTOffer, error) {
+	return dia.NFTOffer{}, errMemoryNotSupported("GetLastNFTOffer")
+}
+
+func (mrdb *MemoryRelDB) GetTopNFTsEth(numCollections int, offset int64, exchanges []string, starttime time.Time, endtime time.Time) ([]struct {
+	Name       string
+	Address    string
+	Blockchain string
+	Volume     float64
+}, error) {
+	return nil, errMemoryNotSupported("GetTopNFTsEth")
+}
+
+func (mrdb *MemoryRelDB) GetNumNFTTrades(address string, blockchain string, exchange string, starttime time.Time, endtime time.Time) (int, error) {
+	return 0, errMemoryNotSupported("GetNumNFTTrades")
+}
+
+func (mrdb *MemoryRelDB) GetNFTVolume(address string, blockchain string, exchange string, starttime time.Time, endtime time.Time) (float64, error) {
+	return 0, errMemoryNotSupported("GetNFTVolume")
+}
+
+func (mrdb *MemoryRelDB) GetAllNFTExchanges() ([]dia.NFTExchange, error) {
+	return []dia.NFTExchange{}, errMemoryNotSupported("GetAllNFTExchanges")
+}
+
+func (mrdb *MemoryRelDB) GetNFTExchange(name string) (dia.Exchange, error) {
+	return dia.Exchange{}, errMemoryNotSupported("GetNFTExchange")
+}
+
+func (mrdb *MemoryRelDB) SetNFTExchange(exchange dia.NFTExchange) error {
+	return errMemoryNotSupported("SetNFTExchange")
+}
+
+func (mrdb *MemoryRelDB) GetCollectionCountByExchange(exchange string) (int64, error) {
+	return 0, errMemoryNotSupported("GetCollectionCountByExchange")
+}
+
+func (mrdb *MemoryRelDB) Get24HoursNFTExchangeVolume(exchange dia.NFTExchange) (float64, error) {
+	return 0, errMemoryNotSupported("Get24HoursNFTExchangeVolume")
+}
+
+func (mrdb *MemoryRelDB) Get24HoursNFTExchangeTrades(exchange dia.NFTExchange) (int64, error) {
+	return 0, errMemoryNotSupported("Get24HoursNFTExchangeTrades")
+}
+
+func (mrdb *MemoryRelDB) SetKeyPair(publickey string, privatekey string) error {
+	return errMemoryNotSupported("SetKeyPair")
+}
+
+func (mrdb *MemoryRelDB) GetKeyPairID(publickey string) string {
+	return ""
+}
+
+func (mrdb *MemoryRelDB) GetFeederAccessByID(id string) string {
+	return ""
+}
+
+func (mrdb *MemoryRelDB) GetFeederByID(id string) string {
+	return ""
+}
+
+func (mrdb *MemoryRelDB) SetOracleConfig(address, keypairID, creator, symbols, chainID, frequency, sleepseconds, deviationpermille string) error {
+	return errMemoryNotSupported("SetOracleConfig")
+}
+
+func (mrdb *MemoryRelDB) SetFeederConfig(feederid, oracleconfigid string) error {
+	return errMemoryNotSupported("SetFeederConfig")
+}
+
+func (mrdb *MemoryRelDB) GetFeederID(address string) string {
+	return ""
+}
+
+func (mrdb *MemoryRelDB) GetFeederLimit(owner string) int {
+	return 0
+}
+
+func (mrdb *MemoryRelDB) GetTotalFeeder(owner string) int {
+	return 0
+}
+
+func (mrdb *MemoryRelDB) GetOracleConfig(address string) (dia.OracleConfig, error) {
+	return dia.OracleConfig{}, errMemoryNotSupported("GetOracleConfig")
+}
+
+func (mrdb *MemoryRelDB) ChangeOracleState(feederID string, active bool) error {
+	return errMemoryNotSupported("ChangeOracleState")
+}
+
+var _ RelDatastore = (*MemoryRelDB)(nil)
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/go-redis/redis"
+	"github.com/jackc/pgx/v4"
+)
+
+func TestMemoryRelDBAssets(t *testing.T) {
+	mrdb := NewMemoryRelDataStore()
+
+	if _, err := mrdb.GetAsset(memoryTestETH.Address, memoryTestETH.Blockchain); err != pgx.ErrNoRows {
+		t.Errorf("expected pgx.ErrNoRows for missing asset, got %v", err)
+	}
+
+	for _, asset := range []dia.Asset{memoryTestETH, memoryTestUSDC} {
+		if err := mrdb.SetAsset(asset); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An asset with the same address and blockchain is not overwritten.
+	if err := mrdb.SetAsset(dia.Asset{Symbol: "WETH", Address: memoryTestETH.Address, Blockchain: memoryTestETH.Blockchain}); err != nil {
+		t.Fatal(err)
+	}
+
+	asset, err := mrdb.GetAsset(memoryTestETH.Address, memoryTestETH.Blockchain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset != memoryTestETH {
+		t.Errorf("expected asset %v, got %v", memoryTestETH, asset)
+	}
+
+	ID, err := mrdb.GetAssetID(memoryTestUSDC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset, err = mrdb.GetAssetByID(ID); err != nil || asset != memoryTestUSDC {
+		t.Errorf("expected asset %v for ID %s, got %v: %v", memoryTestUSDC, ID, asset, err)
+	}
+
+	count, err := mrdb.Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 assets, got %d", count)
+	}
+
+	identified, err := mrdb.IdentifyAsset(dia.Asset{Symbol: "USDC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(identified) != 1 || identified[0] != memoryTestUSDC {
+		t.Errorf("expected to identify %v, got %v", memoryTestUSDC, identified)
+	}
+
+	if _, err = mrdb.GetAssetCache(ID); err != redis.Nil {
+		t.Errorf("expected redis.Nil for uncached asset, got %v", err)
+	}
+	if err = mrdb.SetAssetCache(memoryTestUSDC); err != nil {
+		t.Fatal(err)
+	}
+	if asset, err = mrdb.GetAssetCache(ID); err != nil || asset != memoryTestUSDC {
+		t.Errorf("expected cached asset %v, got %v: %v", memoryTestUSDC, asset, err)
+	}
+	if err = mrdb.SetAssetCache(dia.Asset{Address: "0x01", Blockchain: dia.ETHEREUM}); err == nil {
+		t.Error("expected error when caching a missing asset")
+	}
+}
+
+func TestMemoryRelDBExchangePairs(t *testing.T) {
+	mrdb := NewMemoryRelDataStore()
+	if err := mrdb.SetAsset(memoryTestETH); err != nil {
+		t.Fatal(err)
+	}
+
+	pair := dia.ExchangePair{
+		Symbol:      "ETH",
+		ForeignName: "ETH-USDC",
+		UnderlyingPair: dia.Pair{
+			QuoteToken: memoryTestETH,
+			BaseToken:  memoryTestUSDC,
+		},
+	}
+	if err := mrdb.SetExchangePair(dia.UniswapExchange, pair, true); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := mrdb.GetExchangePair(dia.UniswapExchange, "ETH-USDC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Exchange != dia.UniswapExchange || stored.UnderlyingPair.QuoteToken != memoryTestETH {
+		t.Errorf("expected pair on %s with quote token %v, got %v", dia.UniswapExchange, memoryTestETH, stored)
+	}
+	// Assets missing in the asset table are not stored with the pair.
+	if (stored.UnderlyingPair.BaseToken != dia.Asset{}) {
+		t.Errorf("expected empty base token, got %v", stored.UnderlyingPair.BaseToken)
+	}
+
+	cached, err := mrdb.GetExchangePairCache(dia.UniswapExchange, "ETH-USDC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.ForeignName != "ETH-USDC" {
+		t.Errorf("expected cached pair ETH-USDC, got %v", cached)
+	}
+
+	if _, err = mrdb.GetExchangePair(dia.UniswapExchange, "BTC-USDC"); err != pgx.ErrNoRows {
+		t.Errorf("expected pgx.ErrNoRows for missing pair, got %v", err)
+	}
+}
+
+func TestMemoryRelDBExchanges(t *testing.T) {
+	mrdb := NewMemoryRelDataStore()
+	exchanges := []dia.Exchange{
+		{Name: dia.UniswapExchange, Centralized: false, BlockChain: dia.BlockChain{Name: dia.ETHEREUM}},
+		{Name: dia.BinanceExchange, Centralized: true},
+	}
+	for _, exchange := range exchanges {
+		if err := mrdb.SetExchange(exchange); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exchange, err := mrdb.GetExchange(dia.BinanceExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exchange.Centralized {
+		t.Errorf("expected %s to be centralized", dia.BinanceExchange)
+	}
+	if _, err = mrdb.GetExchange("unknown"); err != pgx.ErrNoRows {
+		t.Errorf("expected pgx.ErrNoRows for missing exchange, got %v", err)
+	}
+
+	names, err := mrdb.GetExchangeNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Errorf("expected 2 exchange names, got %v", names)
+	}
+}
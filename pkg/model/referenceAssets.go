@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+)
+
+// ReferenceAssetsFile is the json file in the config folder mapping assets onto their foreign quotations.
+const ReferenceAssetsFile = "references/referenceAssets"
+
+// ReferenceAsset maps an asset identified by blockchain and address onto the identifier of its
+// quotations from a foreign source, which is the symbol under which the foreign scrapers store them.
+type ReferenceAsset struct {
+	Asset     dia.Asset `json:"Asset"`
+	ForeignID string    `json:"ForeignID"`
+}
+
+type referenceAssetsConfig struct {
+	Assets []ReferenceAsset `json:"Assets"`
+}
+
+// GetReferenceAssetsFromConfig returns the reference assets listed in the json file @filename in the config folder.
+func GetReferenceAssetsFromConfig(filename string) ([]ReferenceAsset, error) {
+	content, err := configCollectors.ReadJSONFromConfig(filename)
+	if err != nil {
+		return []ReferenceAsset{}, err
+	}
+	var config referenceAssetsConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return []ReferenceAsset{}, err
+	}
+	for _, asset := range config.Assets {
+		if asset.Asset.Blockchain == "" || asset.Asset.Address == "" || asset.ForeignID == "" {
+			return []ReferenceAsset{}, errors.New("reference asset needs blockchain, address and foreign ID")
+		}
+	}
+	return config.Assets, nil
+}
@@ -64,6 +64,12 @@ type RelDatastore interface {
 	GetBlockchain(name string) (dia.BlockChain, error)
 	GetAllAssetsBlockchains() ([]string, error)
 	GetAllBlockchains(fullAsset bool) ([]dia.BlockChain, error)
+	SetChainConfig(chainconfig dia.ChainConfig) error
+	GetAllChainConfig() ([]dia.ChainConfig, error)
+
+	// ----------------- asset bridge methods -------------------
+	SetAssetBridge(bridge dia.AssetBridge) error
+	GetAssetBridges() ([]dia.AssetBridge, error)
 
 	// ------ Caching ------
 	SetAssetCache(asset dia.Asset) error
@@ -172,6 +178,7 @@ const (
 	assetVolumeTable        = "assetvolume"
 	aggregatedVolumeTable   = "aggregatedvolume"
 	tradesDistributionTable = "tradesdistribution"
+	assetBridgeTable        = "assetbridge"
 
 	// cache keys
 	keyAssetCache        = "dia_asset_"
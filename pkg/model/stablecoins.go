@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/diadata-org/diadata/pkg/dia"
+	"github.com/diadata-org/diadata/pkg/dia/helpers/configCollectors"
+)
+
+// StablecoinsFile is the json file in the config folder containing the default stablecoin pegs.
+const StablecoinsFile = "stablecoins/pegs"
+
+type stablecoinsConfig struct {
+	Stablecoins []dia.StablecoinPeg `json:"Stablecoins"`
+}
+
+// GetStablecoinPegsFromConfig returns the stablecoin pegs listed in the json file @filename in the config folder.
+func GetStablecoinPegsFromConfig(filename string) ([]dia.StablecoinPeg, error) {
+	content, err := configCollectors.ReadJSONFromConfig(filename)
+	if err != nil {
+		return []dia.StablecoinPeg{}, err
+	}
+	var config stablecoinsConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return []dia.StablecoinPeg{}, err
+	}
+	for _, peg := range config.Stablecoins {
+		if err := peg.Validate(); err != nil {
+			return []dia.StablecoinPeg{}, err
+		}
+	}
+	return config.Stablecoins, nil
+}
+
+// PegPrice returns a function fetching USD prices of stablecoin pegs from @datastore.
+// If @historical is true, the price at the given time is used. Otherwise the latest price from the cache.
+func PegPrice(datastore Datastore, historical bool) dia.PegPriceFunc {
+	return func(peg dia.Asset, timestamp time.Time) (float64, error) {
+		if historical {
+			return datastore.GetAssetPriceUSD(peg, timestamp)
+		}
+		quotation, err := datastore.GetAssetQuotationCache(peg)
+		if err != nil {
+			return 0, err
+		}
+		return quotation.Price, nil
+	}
+}
+
+// NewStablecoinChecker returns a checker for the stablecoins in the json file @filename in the config folder
+// and their bridged versions in @bridges. Prices of non-USD pegs are taken from @datastore.
+func NewStablecoinChecker(filename string, bridges *dia.AssetBridges, defaultTolerance float64, depegMode bool, datastore Datastore, historical bool) (*dia.StablecoinChecker, error) {
+	pegs, err := GetStablecoinPegsFromConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	pegs = dia.BridgedStablecoinPegs(pegs, bridges)
+	return dia.NewStablecoinChecker(pegs, defaultTolerance, depegMode, PegPrice(datastore, historical)), nil
+}
@@ -118,6 +118,17 @@ func (aq *AssetQuotationFull) MarshalBinary() ([]byte, error) {
 	return json.Marshal(aq)
 }
 
+// AssetQuotationBatchItem is the result for a single asset of a batch quotation request.
+// If no quotation is available for the asset, Error is set instead.
+type AssetQuotationBatchItem struct {
+	Blockchain        string              `json:"Blockchain"`
+	Address           string              `json:"Address"`
+	Quotation         *AssetQuotationFull `json:"Quotation,omitempty"`
+	CirculatingSupply *float64            `json:"CirculatingSupply,omitempty"`
+	MarketCap         *float64            `json:"MarketCap,omitempty"`
+	Error             string              `json:"Error,omitempty"`
+}
+
 // UnmarshalBinary for quotations
 func (aq *AssetQuotationFull) UnmarshalBinary(data []byte) error {
 	if err := json.Unmarshal(data, &aq); err != nil {